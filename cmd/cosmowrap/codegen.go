@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+
+	"github.com/ConsiderItDone/wasmos/x/wasm/client/codegen"
+	"github.com/ConsiderItDone/wasmos/x/wasm/polywrapvm"
+	"github.com/ConsiderItDone/wasmos/x/wasm/types"
+)
+
+const (
+	flagCodegenCodeID  = "code-id"
+	flagCodegenPackage = "package"
+	flagCodegenOut     = "out"
+)
+
+// CodegenCmd returns the codegen cobra Command that generates a typed Go client of a wrapper.
+func CodegenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "codegen [wrap_info_or_package_file]",
+		Short: "Generate a typed Go client package for a Polywrap wrapper",
+		Long: `Generate a typed Go client package from the wrap.info ABI of a Polywrap wrapper. The ABI is read
+from a local wrap.info or wrapper package file, or from the wrapper stored on chain with the given --code-id.
+The client has a function building the MsgExecuteContract and a function running the smart query of every
+wrapper method, with the method arguments, the query results and the custom types of the ABI as Go types.
+
+Example:
+$ cosmowrap codegen ./build/wrap.info --out ./hello/client.go
+$ cosmowrap codegen --code-id 1 --package hello --node tcp://localhost:26657
+`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			codeID, err := cmd.Flags().GetUint64(flagCodegenCodeID)
+			if err != nil {
+				return err
+			}
+			var manifest *polywrapvm.Manifest
+			switch {
+			case len(args) == 1 && codeID != 0:
+				return errors.New("either a wrap.info file or a code id can be given")
+			case len(args) == 1:
+				bz, err := os.ReadFile(args[0])
+				if err != nil {
+					return err
+				}
+				if manifest, err = codegen.LoadManifest(bz); err != nil {
+					return fmt.Errorf("%s: %w", args[0], err)
+				}
+			case codeID != 0:
+				if manifest, err = queryManifest(cmd, codeID); err != nil {
+					return err
+				}
+			default:
+				return errors.New("a wrap.info file or a code id is required")
+			}
+
+			pkg, err := cmd.Flags().GetString(flagCodegenPackage)
+			if err != nil {
+				return err
+			}
+			if pkg == "" {
+				pkg = codegen.PackageName(manifest.Name)
+			}
+			src, err := codegen.Generate(*manifest, pkg)
+			if err != nil {
+				return fmt.Errorf("generate client: %w", err)
+			}
+
+			out, err := cmd.Flags().GetString(flagCodegenOut)
+			if err != nil {
+				return err
+			}
+			if out == "" {
+				_, err = cmd.OutOrStdout().Write(src)
+				return err
+			}
+			if err := os.MkdirAll(filepath.Dir(out), 0o755); err != nil {
+				return err
+			}
+			return os.WriteFile(out, src, 0o644)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().Uint64(flagCodegenCodeID, 0, "Code id of the wrapper stored on chain to read the ABI from")
+	cmd.Flags().String(flagCodegenPackage, "", "Go package name of the client, derived from the wrapper name when not set")
+	cmd.Flags().String(flagCodegenOut, "", "File to write the client to, it is printed to stdout when not set")
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
+// queryManifest reads the wrap.info of a wrapper code from chain
+func queryManifest(cmd *cobra.Command, codeID uint64) (*polywrapvm.Manifest, error) {
+	clientCtx, err := client.GetClientQueryContext(cmd)
+	if err != nil {
+		return nil, err
+	}
+	res, err := types.NewQueryClient(clientCtx).CodeABI(cmd.Context(), &types.QueryCodeABIRequest{CodeId: codeID})
+	if err != nil {
+		return nil, err
+	}
+	return polywrapvm.DecodeManifest(res.Manifest)
+}
@@ -4,6 +4,8 @@ go 1.19
 
 require (
 	github.com/CosmWasm/wasmvm v1.1.1
+	github.com/btcsuite/btcd v0.22.1
+	github.com/bytecodealliance/wasmtime-go v1.0.0
 	github.com/cosmos/cosmos-proto v1.0.0-beta.1
 	github.com/cosmos/cosmos-sdk v0.45.11
 	github.com/cosmos/gogoproto v1.4.3
@@ -34,7 +36,6 @@ require (
 )
 
 require (
-	github.com/bytecodealliance/wasmtime-go v1.0.0 // indirect
 	github.com/consideritdone/polywrap-go v0.0.0-20220906144647-cd7bc8047f27 // indirect
 	github.com/spf13/viper v1.14.0 // indirect
 	github.com/valyala/fastjson v1.6.3 // indirect
@@ -49,7 +50,6 @@ require (
 	github.com/armon/go-metrics v0.4.0 // indirect
 	github.com/beorn7/perks v1.0.1 // indirect
 	github.com/bgentry/speakeasy v0.1.0 // indirect
-	github.com/btcsuite/btcd v0.22.1 // indirect
 	github.com/cespare/xxhash v1.1.0 // indirect
 	github.com/cespare/xxhash/v2 v2.1.2 // indirect
 	github.com/coinbase/rosetta-sdk-go v0.7.0 // indirect
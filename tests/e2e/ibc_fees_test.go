@@ -173,7 +173,7 @@ func TestIBCFeesWasm(t *testing.T) {
 
 	// then
 	// on chain A
-	gotCW20Balance, err := chainA.App.WasmKeeper.QuerySmart(chainA.GetContext(), cw20ContractAddr, []byte(fmt.Sprintf(`{"balance":{"address": %q}}`, actorChainA.String())))
+	gotCW20Balance, err := chainA.App.WasmKeeper.QuerySmart(chainA.GetContext(), cw20ContractAddr, []byte(fmt.Sprintf(`{"balance":{"address": %q}}`, actorChainA.String())), "")
 	require.NoError(t, err)
 	assert.JSONEq(t, `{"balance":"99999900"}`, string(gotCW20Balance))
 	payeeBalance := chainA.AllBalances(payee)
@@ -205,7 +205,7 @@ func TestIBCFeesWasm(t *testing.T) {
 
 	// then
 	// on chain A
-	gotCW20Balance, err = chainA.App.WasmKeeper.QuerySmart(chainA.GetContext(), cw20ContractAddr, []byte(fmt.Sprintf(`{"balance":{"address": %q}}`, actorChainA.String())))
+	gotCW20Balance, err = chainA.App.WasmKeeper.QuerySmart(chainA.GetContext(), cw20ContractAddr, []byte(fmt.Sprintf(`{"balance":{"address": %q}}`, actorChainA.String())), "")
 	require.NoError(t, err)
 	assert.JSONEq(t, `{"balance":"100000000"}`, string(gotCW20Balance))
 	// and on chain B
@@ -105,6 +105,7 @@ type (
 	MsgInstantiateContractResponse = types.MsgInstantiateContractResponse
 	MsgExecuteContract             = types.MsgExecuteContract
 	MsgExecuteContractResponse     = types.MsgExecuteContractResponse
+	MsgExecuteContractBatch        = types.MsgExecuteContractBatch
 	MsgMigrateContract             = types.MsgMigrateContract
 	MsgMigrateContractResponse     = types.MsgMigrateContractResponse
 	MsgUpdateAdmin                 = types.MsgUpdateAdmin
@@ -66,6 +66,7 @@ func ProposalStoreCodeCmd() *cobra.Command {
 				Source:                source,
 				Builder:               builder,
 				CodeHash:              codeHash,
+				Runtime:               src.Runtime,
 			}
 
 			msg, err := govtypes.NewMsgSubmitProposal(&content, deposit, clientCtx.GetFromAddress())
@@ -90,6 +91,7 @@ func ProposalStoreCodeCmd() *cobra.Command {
 	cmd.Flags().String(flagSource, "", "Code Source URL is a valid absolute HTTPS URI to the contract's source code,")
 	cmd.Flags().String(flagBuilder, "", "Builder is a valid docker image name with tag, such as \"cosmwasm/workspace-optimizer:0.12.9\"")
 	cmd.Flags().BytesHex(flagCodeHash, nil, "CodeHash is the sha256 hash of the wasm code")
+	cmd.Flags().String(flagRuntime, "", "Runtime of the code: \"cosmwasm\" or \"polywrap\", detected from the wasm exports when not set")
 
 	// proposal flags
 	cmd.Flags().String(cli.FlagTitle, "", "Title of proposal")
@@ -354,6 +356,7 @@ func ProposalStoreAndInstantiateContractCmd() *cobra.Command {
 				Source:                source,
 				Builder:               builder,
 				CodeHash:              codeHash,
+				Runtime:               src.Runtime,
 				Admin:                 adminStr,
 				Label:                 label,
 				Msg:                   []byte(args[1]),
@@ -381,6 +384,7 @@ func ProposalStoreAndInstantiateContractCmd() *cobra.Command {
 	cmd.Flags().String(flagSource, "", "Code Source URL is a valid absolute HTTPS URI to the contract's source code,")
 	cmd.Flags().String(flagBuilder, "", "Builder is a valid docker image name with tag, such as \"cosmwasm/workspace-optimizer:0.12.9\"")
 	cmd.Flags().BytesHex(flagCodeHash, nil, "CodeHash is the sha256 hash of the wasm code")
+	cmd.Flags().String(flagRuntime, "", "Runtime of the code: \"cosmwasm\" or \"polywrap\", detected from the wasm exports when not set")
 	cmd.Flags().StringSlice(flagInstantiateByAnyOfAddress, []string{}, "Any of the addresses can instantiate a contract from the code, optional")
 	cmd.Flags().String(flagAmount, "", "Coins to send to the contract during instantiation")
 	cmd.Flags().String(flagLabel, "", "A human-readable name for this contract in lists")
@@ -499,6 +503,63 @@ func ProposalExecuteContractCmd() *cobra.Command {
 	return cmd
 }
 
+func ProposalExecuteContractBatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "execute-contract-batch [json_encoded_calls]",
+		Short: "Submit a proposal to execute several wasm contract methods that succeed or fail together (run by any address)",
+		Long: fmt.Sprintf(`Submit a proposal to execute several wasm contract methods that succeed or fail together.
+The calls are run in order. Every call has a contract, a method, a json message and optional funds.
+Example:
+$ %s tx gov submit-proposal execute-contract-batch '[{"contract":"<contract_addr>","method":"setParams","msg":{"fee":"1"}}]' --run-as <addr> --title "Set params" --description "..." --deposit 1000stake
+`, version.AppName),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, proposalTitle, proposalDescr, deposit, err := getProposalInfo(cmd)
+			if err != nil {
+				return err
+			}
+
+			calls, err := parseContractCalls(args[0])
+			if err != nil {
+				return err
+			}
+			runAs, err := cmd.Flags().GetString(flagRunAs)
+			if err != nil {
+				return fmt.Errorf("run-as: %s", err)
+			}
+
+			if len(runAs) == 0 {
+				return errors.New("run-as address is required")
+			}
+
+			content := types.ExecuteContractBatchProposal{
+				Title:       proposalTitle,
+				Description: proposalDescr,
+				RunAs:       runAs,
+				Calls:       calls,
+			}
+
+			msg, err := govtypes.NewMsgSubmitProposal(&content, deposit, clientCtx.GetFromAddress())
+			if err != nil {
+				return err
+			}
+			if err = msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msg)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().String(flagRunAs, "", "The address that is passed as sender to the contracts on proposal execution")
+
+	// proposal flags
+	cmd.Flags().String(cli.FlagTitle, "", "Title of proposal")
+	cmd.Flags().String(cli.FlagDescription, "", "Description of proposal")
+	cmd.Flags().String(cli.FlagDeposit, "", "Deposit of proposal")
+	return cmd
+}
+
 func ProposalSudoContractCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "sudo-contract [contract_addr_bech32] [json_encoded_migration_args]",
@@ -35,6 +35,7 @@ func GetQueryCmd() *cobra.Command {
 		GetCmdListContractByCode(),
 		GetCmdQueryCode(),
 		GetCmdQueryCodeInfo(),
+		GetCmdQueryCodeABI(),
 		GetCmdGetContractInfo(),
 		GetCmdGetContractHistory(),
 		GetCmdGetContractState(),
@@ -275,6 +276,42 @@ func GetCmdQueryCodeInfo() *cobra.Command {
 	return cmd
 }
 
+// GetCmdQueryCodeABI gets the ABI of a wrapper code
+func GetCmdQueryCodeABI() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "code-abi [code_id]",
+		Short: "Prints out the wrap.info manifest and ABI of a Polywrap wrapper code id",
+		Long:  "Prints out the wrap.info manifest and ABI of a Polywrap wrapper code id",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			codeID, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			queryClient := types.NewQueryClient(clientCtx)
+			res, err := queryClient.CodeABI(
+				context.Background(),
+				&types.QueryCodeABIRequest{
+					CodeId: codeID,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return clientCtx.PrintProto(res)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddQueryFlagsToCmd(cmd)
+	return cmd
+}
+
 // GetCmdGetContractInfo gets details about a given contract
 func GetCmdGetContractInfo() *cobra.Command {
 	cmd := &cobra.Command{
@@ -416,9 +453,9 @@ func GetCmdGetContractStateRaw() *cobra.Command {
 func GetCmdGetContractStateSmart() *cobra.Command {
 	decoder := newArgDecoder(asciiDecodeString)
 	cmd := &cobra.Command{
-		Use:   "smart [bech32_address] [query]",
+		Use:   "smart [bech32_address] [query] --method [contract_method]",
 		Short: "Calls contract with given address with query data and prints the returned result",
-		Long:  "Calls contract with given address with query data and prints the returned result",
+		Long:  "Calls contract with given address with query data and prints the returned result. The method is required for Polywrap wrappers.",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
@@ -433,6 +470,10 @@ func GetCmdGetContractStateSmart() *cobra.Command {
 			if args[1] == "" {
 				return errors.New("query data must not be empty")
 			}
+			method, err := cmd.Flags().GetString(flagMethod)
+			if err != nil {
+				return fmt.Errorf("method: %s", err)
+			}
 
 			queryData, err := decoder.DecodeString(args[1])
 			if err != nil {
@@ -443,11 +484,21 @@ func GetCmdGetContractStateSmart() *cobra.Command {
 			}
 
 			queryClient := types.NewQueryClient(clientCtx)
+			if method == "" {
+				runtime, err := queryContractRuntime(queryClient, args[0])
+				if err != nil {
+					return err
+				}
+				if runtime.IsPolywrap() {
+					return errors.New("contract method must not be empty for Polywrap wrappers")
+				}
+			}
 			res, err := queryClient.SmartContractState(
 				context.Background(),
 				&types.QuerySmartContractStateRequest{
 					Address:   args[0],
 					QueryData: queryData,
+					Method:    method,
 				},
 			)
 			if err != nil {
@@ -458,10 +509,27 @@ func GetCmdGetContractStateSmart() *cobra.Command {
 		SilenceUsage: true,
 	}
 	decoder.RegisterFlags(cmd.PersistentFlags(), "query argument")
+	cmd.Flags().String(flagMethod, "", "Wrapper method to query, required for Polywrap wrappers")
 	flags.AddQueryFlagsToCmd(cmd)
 	return cmd
 }
 
+// queryContractRuntime returns the runtime of the contract code
+func queryContractRuntime(queryClient types.QueryClient, contractAddr string) (types.Runtime, error) {
+	contract, err := queryClient.ContractInfo(context.Background(), &types.QueryContractInfoRequest{Address: contractAddr})
+	if err != nil {
+		return types.RuntimeUnspecified, err
+	}
+	code, err := queryClient.Code(context.Background(), &types.QueryCodeRequest{CodeId: contract.CodeID})
+	if err != nil {
+		return types.RuntimeUnspecified, err
+	}
+	if code.CodeInfoResponse == nil {
+		return types.RuntimeUnspecified, fmt.Errorf("code %d not found", contract.CodeID)
+	}
+	return code.Runtime, nil
+}
+
 // GetCmdGetContractHistory prints the code history for a given contract
 func GetCmdGetContractHistory() *cobra.Command {
 	cmd := &cobra.Command{
@@ -2,10 +2,12 @@ package cli
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
@@ -45,6 +47,10 @@ const (
 	flagMaxFunds                  = "max-funds"
 	flagAllowAllMsgs              = "allow-all-messages"
 	flagNoTokenTransfer           = "no-token-transfer" //nolint:gosec
+	flagRuntime                   = "runtime"
+	flagAllowedMethods            = "allow-methods"
+	flagMaxMethodCalls            = "max-method-calls"
+	flagMethod                    = "method"
 )
 
 // GetTxCmd returns the transaction commands for this module
@@ -62,6 +68,7 @@ func GetTxCmd() *cobra.Command {
 		InstantiateContractCmd(),
 		InstantiateContract2Cmd(),
 		ExecuteContractCmd(),
+		ExecuteContractBatchCmd(),
 		MigrateContractCmd(),
 		UpdateContractAdminCmd(),
 		ClearContractAdminCmd(),
@@ -98,6 +105,7 @@ func StoreCodeCmd() *cobra.Command {
 	cmd.Flags().String(flagInstantiateNobody, "", "Nobody except the governance process can instantiate a contract from the code, optional")
 	cmd.Flags().String(flagInstantiateByAddress, "", "Deprecated: Only this address can instantiate a contract from the code, optional")
 	cmd.Flags().StringSlice(flagInstantiateByAnyOfAddress, []string{}, "Any of the addresses can instantiate a contract from the code, optional")
+	cmd.Flags().String(flagRuntime, "", "Runtime of the code: \"cosmwasm\" or \"polywrap\", detected from the wasm exports when not set")
 	flags.AddTxFlagsToCmd(cmd)
 	return cmd
 }
@@ -108,15 +116,15 @@ func parseStoreCodeArgs(file string, sender sdk.AccAddress, flags *flag.FlagSet)
 		return types.MsgStoreCode{}, err
 	}
 
-	// gzip the wasm file
-	if ioutils.IsWasm(wasm) {
+	// gzip the wasm file or wrapper package
+	if ioutils.IsWasm(wasm) || ioutils.IsTar(wasm) {
 		wasm, err = ioutils.GzipIt(wasm)
 
 		if err != nil {
 			return types.MsgStoreCode{}, err
 		}
 	} else if !ioutils.IsGzip(wasm) {
-		return types.MsgStoreCode{}, fmt.Errorf("invalid input file. Use wasm binary or gzip")
+		return types.MsgStoreCode{}, fmt.Errorf("invalid input file. Use wasm binary, wrapper package tar or gzip")
 	}
 
 	perm, err := parseAccessConfigFlags(flags)
@@ -124,14 +132,37 @@ func parseStoreCodeArgs(file string, sender sdk.AccAddress, flags *flag.FlagSet)
 		return types.MsgStoreCode{}, err
 	}
 
+	runtime, err := parseRuntimeFlag(flags)
+	if err != nil {
+		return types.MsgStoreCode{}, err
+	}
+
 	msg := types.MsgStoreCode{
 		Sender:                sender.String(),
 		WASMByteCode:          wasm,
 		InstantiatePermission: perm,
+		Runtime:               runtime,
 	}
 	return msg, nil
 }
 
+func parseRuntimeFlag(flags *flag.FlagSet) (types.Runtime, error) {
+	runtime, err := flags.GetString(flagRuntime)
+	if err != nil {
+		return types.RuntimeUnspecified, fmt.Errorf("runtime: %s", err)
+	}
+	switch runtime {
+	case "":
+		return types.RuntimeUnspecified, nil
+	case "cosmwasm":
+		return types.RuntimeCosmWasm, nil
+	case "polywrap":
+		return types.RuntimePolywrap, nil
+	default:
+		return types.RuntimeUnspecified, fmt.Errorf("unknown runtime: %q", runtime)
+	}
+}
+
 func parseAccessConfigFlags(flags *flag.FlagSet) (*types.AccessConfig, error) {
 	addrs, err := flags.GetStringSlice(flagInstantiateByAnyOfAddress)
 	if err != nil {
@@ -407,9 +438,55 @@ func parseExecuteArgs(contractAddr string, method string, execMsg string, sender
 	}, nil
 }
 
+// ExecuteContractBatchCmd executes several contract methods in one transaction.
+func ExecuteContractBatchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "execute-batch [json_encoded_calls]",
+		Short: "Execute several commands on wasm contracts that succeed or fail together",
+		Long: fmt.Sprintf(`Execute several commands on wasm contracts that succeed or fail together.
+The calls are run in order. Every call has a contract, a method, a json message and optional funds.
+Example:
+$ %s tx wasm execute-batch '[{"contract":"<contract_addr>","method":"init","msg":{}},{"contract":"<contract_addr>","method":"deposit","msg":{"amount":"10"},"funds":[{"denom":"stake","amount":"10"}]}]'
+`, version.AppName),
+		Aliases: []string{"exec-batch"},
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			calls, err := parseContractCalls(args[0])
+			if err != nil {
+				return err
+			}
+			msg := types.MsgExecuteContractBatch{
+				Sender: clientCtx.GetFromAddress().String(),
+				Calls:  calls,
+			}
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), &msg)
+		},
+		SilenceUsage: true,
+	}
+	flags.AddTxFlagsToCmd(cmd)
+	return cmd
+}
+
+// parseContractCalls decodes a json array of contract calls
+func parseContractCalls(callsJSON string) ([]types.ContractCall, error) {
+	var calls []types.ContractCall
+	if err := json.Unmarshal([]byte(callsJSON), &calls); err != nil {
+		return nil, fmt.Errorf("calls: %s", err)
+	}
+	return calls, nil
+}
+
 func GrantAuthorizationCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "grant [grantee] [message_type=\"execution\"|\"migration\"] [contract_addr_bech32] --allow-raw-msgs [msg1,msg2,...] --allow-msg-keys [key1,key2,...] --allow-all-messages",
+		Use:   "grant [grantee] [message_type=\"execution\"|\"batch-execution\"|\"migration\"] [contract_addr_bech32] --allow-raw-msgs [msg1,msg2,...] --allow-msg-keys [key1,key2,...] --allow-methods [method1,method2,...] --allow-all-messages",
 		Short: "Grant authorization to an address",
 		Long: fmt.Sprintf(`Grant authorization to an address.
 Examples:
@@ -418,7 +495,11 @@ $ %s tx grant <grantee_addr> execution <contract_addr> --allow-all-messages --ma
 $ %s tx grant <grantee_addr> execution <contract_addr> --allow-all-messages --max-funds 100000uwasm --expiration 1667979596
 
 $ %s tx grant <grantee_addr> execution <contract_addr> --allow-all-messages --max-calls 5 --max-funds 100000uwasm --expiration 1667979596
-`, version.AppName, version.AppName, version.AppName),
+
+$ %s tx grant <grantee_addr> execution <contract_addr> --allow-methods updateName,sayHello --max-method-calls updateName=2,sayHello=10 --no-token-transfer --expiration 1667979596
+
+$ %s tx grant <grantee_addr> batch-execution <contract_addr> --allow-methods init,deposit --max-calls 10 --no-token-transfer --expiration 1667979596
+`, version.AppName, version.AppName, version.AppName, version.AppName, version.AppName),
 		Args: cobra.ExactArgs(3),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			clientCtx, err := client.GetClientTxContext(cmd)
@@ -446,6 +527,11 @@ $ %s tx grant <grantee_addr> execution <contract_addr> --allow-all-messages --ma
 				return err
 			}
 
+			methods, err := cmd.Flags().GetStringSlice(flagAllowedMethods)
+			if err != nil {
+				return err
+			}
+
 			maxFundsStr, err := cmd.Flags().GetString(flagMaxFunds)
 			if err != nil {
 				return fmt.Errorf("max funds: %s", err)
@@ -456,6 +542,11 @@ $ %s tx grant <grantee_addr> execution <contract_addr> --allow-all-messages --ma
 				return err
 			}
 
+			methodCalls, err := parseMethodCalls(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
 			exp, err := cmd.Flags().GetInt64(flagExpiration)
 			if err != nil {
 				return err
@@ -476,6 +567,10 @@ $ %s tx grant <grantee_addr> execution <contract_addr> --allow-all-messages --ma
 
 			var limit types.ContractAuthzLimitX
 			switch {
+			case len(methodCalls) != 0 && (maxFundsStr != "" || maxCalls != 0):
+				return errors.New("cannot combine max method calls with other limits")
+			case len(methodCalls) != 0 && noTokenTransfer:
+				limit = types.NewMaxMethodCallsLimit(methodCalls...)
 			case maxFundsStr != "" && maxCalls != 0 && !noTokenTransfer:
 				maxFunds, err := sdk.ParseCoinsNormalized(maxFundsStr)
 				if err != nil {
@@ -496,7 +591,7 @@ $ %s tx grant <grantee_addr> execution <contract_addr> --allow-all-messages --ma
 
 			var filter types.ContractAuthzFilterX
 			switch {
-			case allowAllMsgs && len(msgKeys) != 0 || allowAllMsgs && len(rawMsgs) != 0 || len(msgKeys) != 0 && len(rawMsgs) != 0:
+			case countTrue(allowAllMsgs, len(msgKeys) != 0, len(rawMsgs) != 0, len(methods) != 0) > 1:
 				return errors.New("cannot set more than one filter within one grant")
 			case allowAllMsgs:
 				filter = types.NewAllowAllMessagesFilter()
@@ -508,6 +603,8 @@ $ %s tx grant <grantee_addr> execution <contract_addr> --allow-all-messages --ma
 					msgs[i] = types.RawContractMessage(msg)
 				}
 				filter = types.NewAcceptedMessagesFilter(msgs...)
+			case len(methods) != 0:
+				filter = types.NewAcceptedMethodsFilter(methods...)
 			default:
 				return errors.New("invalid filter setup")
 			}
@@ -521,6 +618,8 @@ $ %s tx grant <grantee_addr> execution <contract_addr> --allow-all-messages --ma
 			switch args[1] {
 			case "execution":
 				authorization = types.NewContractExecutionAuthorization(*grant)
+			case "batch-execution":
+				authorization = types.NewContractBatchExecutionAuthorization(*grant)
 			case "migration":
 				authorization = types.NewContractMigrationAuthorization(*grant)
 			default:
@@ -542,5 +641,38 @@ $ %s tx grant <grantee_addr> execution <contract_addr> --allow-all-messages --ma
 	cmd.Flags().Int64(flagExpiration, 0, "The Unix timestamp.")
 	cmd.Flags().Bool(flagAllowAllMsgs, false, "Allow all messages")
 	cmd.Flags().Bool(flagNoTokenTransfer, false, "Don't allow token transfer")
+	cmd.Flags().StringSlice(flagAllowedMethods, []string{}, "Allowed wrapper methods")
+	cmd.Flags().StringSlice(flagMaxMethodCalls, []string{}, "Maximal number of calls per wrapper method as method=calls, requires --no-token-transfer")
 	return cmd
 }
+
+// parseMethodCalls parses the method=calls pairs of the max method calls flag
+func parseMethodCalls(flags *flag.FlagSet) ([]types.MethodCalls, error) {
+	pairs, err := flags.GetStringSlice(flagMaxMethodCalls)
+	if err != nil {
+		return nil, err
+	}
+	calls := make([]types.MethodCalls, 0, len(pairs))
+	for _, pair := range pairs {
+		method, n, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("max method calls: %q is not method=calls", pair)
+		}
+		remaining, err := strconv.ParseUint(n, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("max method calls of %q: %s", method, err)
+		}
+		calls = append(calls, types.MethodCalls{Method: method, Remaining: remaining})
+	}
+	return calls, nil
+}
+
+func countTrue(values ...bool) int {
+	var n int
+	for _, v := range values {
+		if v {
+			n++
+		}
+	}
+	return n
+}
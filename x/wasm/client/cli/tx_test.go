@@ -3,6 +3,7 @@ package cli
 import (
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -57,3 +58,84 @@ func TestParseAccessConfigFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestParseMethodCalls(t *testing.T) {
+	specs := map[string]struct {
+		args     []string
+		expCalls []types.MethodCalls
+		expErr   bool
+	}{
+		"single": {
+			args:     []string{"--max-method-calls=updateName=2"},
+			expCalls: []types.MethodCalls{{Method: "updateName", Remaining: 2}},
+		},
+		"multiple": {
+			args:     []string{"--max-method-calls=updateName=2,sayHello=10"},
+			expCalls: []types.MethodCalls{{Method: "updateName", Remaining: 2}, {Method: "sayHello", Remaining: 10}},
+		},
+		"missing calls": {
+			args:   []string{"--max-method-calls=updateName"},
+			expErr: true,
+		},
+		"invalid calls": {
+			args:   []string{"--max-method-calls=updateName=-1"},
+			expErr: true,
+		},
+		"not set": {
+			args:     []string{},
+			expCalls: []types.MethodCalls{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			flags := GrantAuthorizationCmd().Flags()
+			require.NoError(t, flags.Parse(spec.args))
+			gotCalls, gotErr := parseMethodCalls(flags)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expCalls, gotCalls)
+		})
+	}
+}
+
+func TestParseContractCalls(t *testing.T) {
+	specs := map[string]struct {
+		src      string
+		expCalls []types.ContractCall
+		expErr   bool
+	}{
+		"single": {
+			src:      `[{"contract":"cosmos1","method":"sayHello","msg":{"name":"x"}}]`,
+			expCalls: []types.ContractCall{{Contract: "cosmos1", Method: "sayHello", Msg: types.RawContractMessage(`{"name":"x"}`)}},
+		},
+		"with funds": {
+			src: `[{"contract":"cosmos1","method":"deposit","msg":{},"funds":[{"denom":"stake","amount":"10"}]}]`,
+			expCalls: []types.ContractCall{{
+				Contract: "cosmos1", Method: "deposit", Msg: types.RawContractMessage(`{}`),
+				Funds: sdk.NewCoins(sdk.NewInt64Coin("stake", 10)),
+			}},
+		},
+		"invalid json": {
+			src:    `[{"contract":`,
+			expErr: true,
+		},
+		"not a list": {
+			src:    `{"contract":"cosmos1"}`,
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotCalls, gotErr := parseContractCalls(spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expCalls, gotCalls)
+		})
+	}
+}
@@ -0,0 +1,428 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+	"unicode"
+
+	"github.com/ConsiderItDone/wasmos/x/wasm/ioutils"
+	"github.com/ConsiderItDone/wasmos/x/wasm/polywrapvm"
+	"github.com/ConsiderItDone/wasmos/x/wasm/types"
+)
+
+// LoadManifest decodes a msgpack encoded wrap.info or extracts it from a, optionally gzipped,
+// wrapper package as uploaded with MsgStoreCode
+func LoadManifest(bz []byte) (*polywrapvm.Manifest, error) {
+	var err error
+	if ioutils.IsGzip(bz) {
+		if bz, err = ioutils.Uncompress(bz, uint64(types.MaxWasmSize)); err != nil {
+			return nil, err
+		}
+	}
+	if ioutils.IsTar(bz) {
+		if _, bz, err = polywrapvm.UnpackWrapper(bz, uint64(types.MaxWasmSize)); err != nil {
+			return nil, err
+		}
+	}
+	return polywrapvm.DecodeManifest(bz)
+}
+
+// PackageName derives a Go package name from the wrapper name, e.g. `hello-world` becomes `helloworld`
+func PackageName(wrapperName string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(wrapperName) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			sb.WriteRune(r)
+		}
+	}
+	name := strings.TrimLeftFunc(sb.String(), unicode.IsDigit)
+	if name == "" || token.IsKeyword(name) {
+		return "wrapper"
+	}
+	return name
+}
+
+// Generate writes the gofmt formatted source of a typed Go client package for the wrapper. Every module
+// method gets an args struct, a function building the MsgExecuteContract that invokes it and a function
+// running it as smart query and decoding its typed result. Object and enum types of the ABI become Go
+// types, the arguments are JSON encoded the way the chain coerces them into the msgpack arguments of the
+// method.
+func Generate(m polywrapvm.Manifest, pkg string) ([]byte, error) {
+	if !token.IsIdentifier(pkg) || token.IsKeyword(pkg) {
+		return nil, fmt.Errorf("invalid package name %q", pkg)
+	}
+	if err := m.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	g := generator{abi: m.ABI, names: map[string]string{"Client": "", "NewClient": ""}}
+
+	g.printf("// Code generated by cosmowrap codegen. DO NOT EDIT.\n\n")
+	g.printf("// Package %s is a client of the %q wrapper.\n", pkg, m.Name)
+	g.printf("package %s\n\n", pkg)
+	g.printf("import (\n")
+	g.printf("%q\n%q\n%q\n\n", "context", "encoding/json", "fmt")
+	g.printf("sdk %q\n\n", "github.com/cosmos/cosmos-sdk/types")
+	g.printf("wasmtypes %q\n", "github.com/ConsiderItDone/wasmos/x/wasm/types")
+	g.printf(")\n\n")
+	g.printf(clientSource, m.Name)
+
+	for _, e := range m.ABI.EnumTypes {
+		if err := g.enum(e); err != nil {
+			return nil, err
+		}
+	}
+	for _, o := range m.ABI.ObjectTypes {
+		name, err := g.declare(o.Type, "object "+o.Type)
+		if err != nil {
+			return nil, err
+		}
+		g.printf("// %s is the %s object type of the wrapper\n", name, o.Type)
+		if err := g.structType(name, o.Properties); err != nil {
+			return nil, err
+		}
+	}
+	for _, method := range m.ABI.Module.Methods {
+		if err := g.method(method); err != nil {
+			return nil, err
+		}
+	}
+	return format.Source(g.buf.Bytes())
+}
+
+// clientSource is the part of the generated package that does not depend on the ABI
+const clientSource = `// Client invokes the methods of a %q wrapper contract
+type Client struct {
+	contract string
+	querier  wasmtypes.QueryClient
+}
+
+// NewClient returns a client of the wrapper contract with the given bech32 address. The querier is only
+// used by the query functions and can be nil when the client only builds messages.
+func NewClient(contract string, querier wasmtypes.QueryClient) *Client {
+	return &Client{contract: contract, querier: querier}
+}
+
+// Contract returns the bech32 address of the wrapper contract
+func (c *Client) Contract() string {
+	return c.contract
+}
+
+func (c *Client) executeMsg(sender, method string, args interface{}, funds sdk.Coins) (*wasmtypes.MsgExecuteContract, error) {
+	msg, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("%%s: %%w", method, err)
+	}
+	res := &wasmtypes.MsgExecuteContract{
+		Sender:   sender,
+		Contract: c.contract,
+		Method:   method,
+		Msg:      msg,
+		Funds:    funds,
+	}
+	if err := res.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("%%s: %%w", method, err)
+	}
+	return res, nil
+}
+
+func (c *Client) query(ctx context.Context, method string, args interface{}) ([]byte, error) {
+	if c.querier == nil {
+		return nil, fmt.Errorf("%%s: client has no querier", method)
+	}
+	msg, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("%%s: %%w", method, err)
+	}
+	res, err := c.querier.SmartContractState(ctx, &wasmtypes.QuerySmartContractStateRequest{
+		Address:   c.contract,
+		QueryData: msg,
+		Method:    method,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%%s: %%w", method, err)
+	}
+	return res.Data, nil
+}
+
+// decodeResult decodes the JSON result of a query. A query without result data leaves res unchanged.
+func decodeResult(method string, data []byte, res interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, res); err != nil {
+		return fmt.Errorf("%%s: %%w", method, err)
+	}
+	return nil
+}
+
+`
+
+type generator struct {
+	buf bytes.Buffer
+	abi polywrapvm.ABI
+	// names maps the declared Go identifiers to the ABI name they were generated for
+	names map[string]string
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// declare reserves the Go identifier of an ABI name. Names that only differ in characters that are not
+// valid in Go identifiers would generate the same identifier and are rejected.
+func (g *generator) declare(abiName, what string) (string, error) {
+	name := exported(abiName)
+	if other, exists := g.names[name]; exists {
+		if other == "" {
+			return "", fmt.Errorf("%s: %s is reserved by the generated client", what, name)
+		}
+		return "", fmt.Errorf("%s: %s is already generated for %s", what, name, other)
+	}
+	g.names[name] = what
+	return name, nil
+}
+
+func (g *generator) enum(e polywrapvm.EnumDefinition) error {
+	name, err := g.declare(e.Type, "enum "+e.Type)
+	if err != nil {
+		return err
+	}
+	g.printf("// %s is the %s enum type of the wrapper\n", name, e.Type)
+	g.printf("type %s string\n\n", name)
+	g.printf("const (\n")
+	for _, c := range e.Constants {
+		constant, err := g.declare(name+exported(c), fmt.Sprintf("enum %s constant %s", e.Type, c))
+		if err != nil {
+			return err
+		}
+		g.printf("%s %s = %q\n", constant, name, c)
+	}
+	g.printf(")\n\n")
+
+	// wrappers return enums as the index of their constant
+	g.printf("// UnmarshalJSON decodes the %s constant or its index\n", e.Type)
+	g.printf("func (e *%s) UnmarshalJSON(bz []byte) error {\n", name)
+	g.printf("var s string\nif err := json.Unmarshal(bz, &s); err == nil {\n*e = %s(s)\nreturn nil\n}\n", name)
+	g.printf("var i int\nif err := json.Unmarshal(bz, &i); err != nil {\nreturn fmt.Errorf(\"%s: %%w\", err)\n}\n", name)
+	g.printf("switch i {\n")
+	for i, c := range e.Constants {
+		g.printf("case %d:\n*e = %s\n", i, name+exported(c))
+	}
+	g.printf("default:\nreturn fmt.Errorf(\"invalid %s value %%d\", i)\n}\nreturn nil\n}\n\n", name)
+	return nil
+}
+
+func (g *generator) structType(name string, props []polywrapvm.PropertyDefinition) error {
+	fields := make(map[string]string, len(props))
+	g.printf("type %s struct {\n", name)
+	for _, p := range props {
+		field := exported(p.Name)
+		if other, exists := fields[field]; exists {
+			return fmt.Errorf("%s: fields %s and %s are both generated as %s", name, other, p.Name, field)
+		}
+		fields[field] = p.Name
+		typ, err := g.goType(p.Type, p.Required)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", name, p.Name, err)
+		}
+		tag := p.Name
+		if !p.Required {
+			tag += ",omitempty"
+		}
+		g.printf("%s %s `json:%q`\n", field, typ, tag)
+	}
+	g.printf("}\n\n")
+	return nil
+}
+
+func (g *generator) method(method polywrapvm.MethodDefinition) error {
+	name, err := g.declare(method.Name, "method "+method.Name)
+	if err != nil {
+		return err
+	}
+	for _, fn := range []string{name + "Msg", "Query" + name} {
+		if _, err := g.declare(fn, "method "+method.Name); err != nil {
+			return err
+		}
+	}
+
+	argsParam, argsValue := "", "struct{}{}"
+	if len(method.Arguments) != 0 {
+		argsType, err := g.declare(name+"Args", "method "+method.Name)
+		if err != nil {
+			return err
+		}
+		g.printf("// %s are the arguments of the %s method\n", argsType, method.Name)
+		if err := g.structType(argsType, method.Arguments); err != nil {
+			return err
+		}
+		argsParam, argsValue = ", args "+argsType, "args"
+	}
+
+	g.printf("// %sMsg builds the MsgExecuteContract that invokes the %s method\n", name, method.Name)
+	g.printf("func (c *Client) %sMsg(sender string%s, funds sdk.Coins) (*wasmtypes.MsgExecuteContract, error) {\n", name, argsParam)
+	g.printf("return c.executeMsg(sender, %q, %s, funds)\n}\n\n", method.Name, argsValue)
+
+	// queries return the response data of the wrapper: String results are the data itself, results
+	// of the types the ABI declares are JSON and other types, e.g. imported ones, are passed through
+	ret := strings.TrimSuffix(method.Return.Type, "!")
+	typ, err := g.goType(method.Return.Type, method.Return.Required)
+	if err != nil {
+		return fmt.Errorf("method %s: %w", method.Name, err)
+	}
+	switch {
+	case ret == "String" || ret == "BigInt" || ret == "BigNumber":
+		g.printf("// Query%s invokes the %s method as smart query and returns its %s result\n", name, method.Name, ret)
+		g.printf("func (c *Client) Query%s(ctx context.Context%s) (string, error) {\n", name, argsParam)
+		g.printf("data, err := c.query(ctx, %q, %s)\n", method.Name, argsValue)
+		g.printf("return string(data), err\n}\n\n")
+	case ret == "JSON":
+		g.printf("// Query%s invokes the %s method as smart query and returns its JSON result\n", name, method.Name)
+		g.printf("func (c *Client) Query%s(ctx context.Context%s) (json.RawMessage, error) {\n", name, argsParam)
+		g.printf("data, err := c.query(ctx, %q, %s)\n", method.Name, argsValue)
+		g.printf("return json.RawMessage(data), err\n}\n\n")
+	case strings.TrimPrefix(typ, "*") == "json.RawMessage":
+		g.printf("// Query%s invokes the %s method as smart query and returns the data of its %s result\n", name, method.Name, method.Return.Type)
+		g.printf("func (c *Client) Query%s(ctx context.Context%s) ([]byte, error) {\n", name, argsParam)
+		g.printf("return c.query(ctx, %q, %s)\n}\n\n", method.Name, argsValue)
+	default:
+		g.printf("// Query%s invokes the %s method as smart query and decodes its %s result\n", name, method.Name, method.Return.Type)
+		g.printf("func (c *Client) Query%s(ctx context.Context%s) (%s, error) {\n", name, argsParam, typ)
+		g.printf("var res %s\n", typ)
+		g.printf("data, err := c.query(ctx, %q, %s)\n", method.Name, argsValue)
+		g.printf("if err != nil {\nreturn res, err\n}\n")
+		g.printf("err = decodeResult(%q, data, &res)\nreturn res, err\n}\n\n", method.Name)
+	}
+	return nil
+}
+
+// goType returns the Go type the schema type is JSON encoded from. Optional scalars and objects are
+// pointers so that they can be left out, lists, maps, Bytes and JSON are omitted when empty.
+func (g *generator) goType(typ string, required bool) (string, error) {
+	required = required || strings.HasSuffix(typ, "!")
+	typ = strings.TrimSuffix(typ, "!")
+
+	switch {
+	case strings.HasPrefix(typ, "[") && strings.HasSuffix(typ, "]"):
+		elem, err := g.goType(typ[1:len(typ)-1], true)
+		return "[]" + elem, err
+	case strings.HasPrefix(typ, "Map<") && strings.HasSuffix(typ, ">"):
+		keyType, valueType, ok := splitMapType(typ[len("Map<") : len(typ)-1])
+		if !ok {
+			return "", fmt.Errorf("invalid map type %s", typ)
+		}
+		key, err := g.goType(keyType, true)
+		if err != nil {
+			return "", err
+		}
+		if !mapKeyTypes[strings.TrimSuffix(keyType, "!")] {
+			return "", fmt.Errorf("unsupported map key type %s", keyType)
+		}
+		value, err := g.goType(valueType, true)
+		return "map[" + key + "]" + value, err
+	}
+
+	var res string
+	switch typ {
+	case "Bytes":
+		return "[]byte", nil
+	case "JSON":
+		return "json.RawMessage", nil
+	default:
+		if t, ok := scalarTypes[typ]; ok {
+			res = t
+		} else if g.isCustom(typ) {
+			res = exported(strings.TrimPrefix(typ, "Enum_"))
+		} else {
+			// types the ABI does not declare, e.g. imported ones, are passed through as plain JSON
+			return "json.RawMessage", nil
+		}
+	}
+	if !required {
+		return "*" + res, nil
+	}
+	return res, nil
+}
+
+func (g *generator) isCustom(typ string) bool {
+	for _, o := range g.abi.ObjectTypes {
+		if o.Type == typ {
+			return true
+		}
+	}
+	for _, e := range g.abi.EnumTypes {
+		if e.Type == typ || "Enum_"+e.Type == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// scalarTypes maps the schema scalars to Go. BigInt and BigNumber are decimal strings.
+var scalarTypes = map[string]string{
+	"String":    "string",
+	"Boolean":   "bool",
+	"UInt":      "uint32",
+	"UInt8":     "uint8",
+	"UInt16":    "uint16",
+	"UInt32":    "uint32",
+	"UInt64":    "uint64",
+	"Int":       "int32",
+	"Int8":      "int8",
+	"Int16":     "int16",
+	"Int32":     "int32",
+	"Int64":     "int64",
+	"BigInt":    "string",
+	"BigNumber": "string",
+}
+
+var mapKeyTypes = map[string]bool{
+	"String": true,
+	"UInt":   true, "UInt8": true, "UInt16": true, "UInt32": true, "UInt64": true,
+	"Int": true, "Int8": true, "Int16": true, "Int32": true, "Int64": true,
+}
+
+// exported converts a schema name into an exported Go identifier, e.g. `sayHello` becomes `SayHello`
+// and `token_id` becomes `TokenId`
+func exported(name string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range name {
+		if r >= unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	res := sb.String()
+	if res == "" || unicode.IsDigit(rune(res[0])) {
+		return "X" + res
+	}
+	return res
+}
+
+// splitMapType splits the `K, V` of a `Map<K, V>` type at the top level comma
+func splitMapType(s string) (string, string, bool) {
+	depth := 0
+	for i, r := range s {
+		switch r {
+		case '<', '[':
+			depth++
+		case '>', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				k, v := strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+				return k, v, k != "" && v != ""
+			}
+		}
+	}
+	return "", "", false
+}
@@ -0,0 +1,247 @@
+package codegen
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ConsiderItDone/wasmos/x/wasm/ioutils"
+	"github.com/ConsiderItDone/wasmos/x/wasm/polywrapvm"
+)
+
+func TestLoadManifest(t *testing.T) {
+	manifest, err := os.ReadFile("../../keeper/testdata/hello_world.wrap.info")
+	require.NoError(t, err)
+	code, err := os.ReadFile("../../keeper/testdata/hello_world.wasm")
+	require.NoError(t, err)
+	pkg := wrapperPackage(t, code, manifest)
+	gzipped, err := ioutils.GzipIt(pkg)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		src    []byte
+		expErr bool
+	}{
+		"wrap.info": {
+			src: manifest,
+		},
+		"wrapper package": {
+			src: pkg,
+		},
+		"gzipped wrapper package": {
+			src: gzipped,
+		},
+		"wasm code": {
+			src:    code,
+			expErr: true,
+		},
+		"empty": {
+			src:    nil,
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := LoadManifest(spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, "hello-world", got.Name)
+			assert.Len(t, got.ABI.Module.Methods, 3)
+		})
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	specs := map[string]string{
+		"hello-world": "helloworld",
+		"Vault_V2":    "vaultv2",
+		"2fa":         "fa",
+		"type":        "wrapper",
+		"---":         "wrapper",
+	}
+	for src, exp := range specs {
+		t.Run(src, func(t *testing.T) {
+			assert.Equal(t, exp, PackageName(src))
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := Generate(vaultManifest(), "vault")
+	require.NoError(t, err)
+	// gofmt aligns the struct fields
+	code := strings.Join(strings.Fields(string(src)), " ")
+
+	for _, exp := range []string{
+		"// Code generated by cosmowrap codegen. DO NOT EDIT.",
+		"package vault",
+		// enums are strings of their constants
+		"type Kind string",
+		`KindSAVING Kind = "SAVING"`,
+		// objects and optional properties
+		"type Account struct {",
+		"Owner string `json:\"owner\"`",
+		"Kind *Kind `json:\"kind,omitempty\"`",
+		"Balances map[string]string `json:\"balances,omitempty\"`",
+		"Labels []string `json:\"labels\"`",
+		"Meta json.RawMessage `json:\"meta,omitempty\"`",
+		"Limit *uint32 `json:\"limit,omitempty\"`",
+		"Nonce uint64 `json:\"nonce\"`",
+		"Raw []byte `json:\"raw\"`",
+		"Ext json.RawMessage `json:\"ext\"`",
+		// methods with arguments
+		"type DepositArgs struct {",
+		"Account Account `json:\"account\"`",
+		`func (c *Client) DepositMsg(sender string, args DepositArgs, funds sdk.Coins) (*wasmtypes.MsgExecuteContract, error) {`,
+		`return c.executeMsg(sender, "deposit", args, funds)`,
+		// results of types the ABI does not declare are the response data
+		`func (c *Client) QueryDeposit(ctx context.Context, args DepositArgs) ([]byte, error) {`,
+		// methods without arguments and with a String result
+		`func (c *Client) ListAccountsMsg(sender string, funds sdk.Coins) (*wasmtypes.MsgExecuteContract, error) {`,
+		`func (c *Client) QueryListAccounts(ctx context.Context) (string, error) {`,
+		`data, err := c.query(ctx, "list_accounts", struct{}{})`,
+		// typed results are decoded from JSON
+		`func (c *Client) QueryGetAccount(ctx context.Context, args GetAccountArgs) (*Account, error) {`,
+		`err = decodeResult("get_account", data, &res)`,
+		`func (c *Client) QueryCount(ctx context.Context) (uint64, error) {`,
+		`func (c *Client) QueryTotal(ctx context.Context) (string, error) {`,
+		// enums are returned as the index of their constant
+		`func (e *Kind) UnmarshalJSON(bz []byte) error {`,
+		`case 1: *e = KindCHECKING`,
+	} {
+		assert.Contains(t, code, strings.Join(strings.Fields(exp), " "))
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	specs := map[string]struct {
+		pkg    string
+		mutate func(m *polywrapvm.Manifest)
+	}{
+		"invalid package name": {
+			pkg:    "my-vault",
+			mutate: func(m *polywrapvm.Manifest) {},
+		},
+		"keyword package name": {
+			pkg:    "func",
+			mutate: func(m *polywrapvm.Manifest) {},
+		},
+		"invalid manifest": {
+			pkg: "vault",
+			mutate: func(m *polywrapvm.Manifest) {
+				m.ABI.Module = nil
+			},
+		},
+		"method collides with object": {
+			pkg: "vault",
+			mutate: func(m *polywrapvm.Manifest) {
+				m.ABI.ObjectTypes = append(m.ABI.ObjectTypes, polywrapvm.ObjectDefinition{Type: "Deposit"})
+			},
+		},
+		"method collides with client": {
+			pkg: "vault",
+			mutate: func(m *polywrapvm.Manifest) {
+				m.ABI.Module.Methods[0].Name = "client"
+			},
+		},
+		"methods generated with the same name": {
+			pkg: "vault",
+			mutate: func(m *polywrapvm.Manifest) {
+				m.ABI.Module.Methods = append(m.ABI.Module.Methods, polywrapvm.MethodDefinition{
+					Name: "listAccounts", Return: &polywrapvm.PropertyDefinition{Name: "listAccounts", Type: "String"},
+				})
+			},
+		},
+		"fields generated with the same name": {
+			pkg: "vault",
+			mutate: func(m *polywrapvm.Manifest) {
+				m.ABI.ObjectTypes[0].Properties = append(m.ABI.ObjectTypes[0].Properties,
+					polywrapvm.PropertyDefinition{Name: "Owner", Type: "String"})
+			},
+		},
+		"unsupported map key": {
+			pkg: "vault",
+			mutate: func(m *polywrapvm.Manifest) {
+				m.ABI.ObjectTypes[0].Properties[2].Type = "Map<Boolean, BigInt>"
+			},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			m := vaultManifest()
+			spec.mutate(&m)
+			_, err := Generate(m, spec.pkg)
+			require.Error(t, err)
+		})
+	}
+}
+
+func vaultManifest() polywrapvm.Manifest {
+	return polywrapvm.Manifest{
+		Version: "0.1",
+		Type:    "wasm",
+		Name:    "vault",
+		ABI: polywrapvm.ABI{
+			Version: "0.1",
+			Module: &polywrapvm.ModuleDefinition{Methods: []polywrapvm.MethodDefinition{
+				{
+					Name:      "deposit",
+					Arguments: []polywrapvm.PropertyDefinition{{Name: "account", Type: "Account", Required: true}},
+					Return:    &polywrapvm.PropertyDefinition{Name: "deposit", Type: "Response", Required: true},
+				},
+				{
+					Name:   "list_accounts",
+					Return: &polywrapvm.PropertyDefinition{Name: "list_accounts", Type: "String", Required: true},
+				},
+				{
+					Name:      "get_account",
+					Arguments: []polywrapvm.PropertyDefinition{{Name: "owner", Type: "String", Required: true}},
+					Return:    &polywrapvm.PropertyDefinition{Name: "get_account", Type: "Account"},
+				},
+				{
+					Name:   "count",
+					Return: &polywrapvm.PropertyDefinition{Name: "count", Type: "UInt64", Required: true},
+				},
+				{
+					Name:   "total",
+					Return: &polywrapvm.PropertyDefinition{Name: "total", Type: "BigInt", Required: true},
+				},
+			}},
+			ObjectTypes: []polywrapvm.ObjectDefinition{{
+				Type: "Account",
+				Properties: []polywrapvm.PropertyDefinition{
+					{Name: "owner", Type: "String", Required: true},
+					{Name: "kind", Type: "Enum_Kind"},
+					{Name: "balances", Type: "Map<String, BigInt>"},
+					{Name: "labels", Type: "[String!]", Required: true},
+					{Name: "meta", Type: "JSON"},
+					{Name: "limit", Type: "UInt"},
+					{Name: "nonce", Type: "UInt64", Required: true},
+					{Name: "raw", Type: "Bytes", Required: true},
+					{Name: "ext", Type: "Ext_Type", Required: true},
+				},
+			}},
+			EnumTypes: []polywrapvm.EnumDefinition{{Type: "Kind", Constants: []string{"SAVING", "CHECKING"}}},
+		},
+	}
+}
+
+func wrapperPackage(t *testing.T, code, manifest []byte) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range map[string][]byte{"wrap.wasm": code, "wrap.info": manifest} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
@@ -309,6 +309,34 @@ func TestGovRestHandlers(t *testing.T) {
 			},
 			expCode: http.StatusBadRequest,
 		},
+		"execute contract batch": {
+			srcPath: "/gov/proposals/wasm_execute_batch",
+			srcBody: dict{
+				"title":       "Test Proposal",
+				"description": "My proposal",
+				"run_as":      "cosmos100dejzacpanrldpjjwksjm62shqhyss44jf5xz",
+				"calls": []dict{
+					{"contract": "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr", "method": "setParams", "msg": dict{"foo": "bar"}},
+					{"contract": "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr", "msg": dict{"foo": "bar"}, "funds": []dict{{"denom": "ustake", "amount": "10"}}},
+				},
+				"deposit":  []dict{{"denom": "ustake", "amount": "10"}},
+				"proposer": "cosmos1ve557a5g9yw2g2z57js3pdmcvd5my6g8ze20np",
+				"base_req": aBaseReq,
+			},
+			expCode: http.StatusOK,
+		},
+		"execute contract batch fails without calls": {
+			srcPath: "/gov/proposals/wasm_execute_batch",
+			srcBody: dict{
+				"title":       "Test Proposal",
+				"description": "My proposal",
+				"run_as":      "cosmos100dejzacpanrldpjjwksjm62shqhyss44jf5xz",
+				"deposit":     []dict{{"denom": "ustake", "amount": "10"}},
+				"proposer":    "cosmos1ve557a5g9yw2g2z57js3pdmcvd5my6g8ze20np",
+				"base_req":    aBaseReq,
+			},
+			expCode: http.StatusBadRequest,
+		},
 		"sudo contract": {
 			srcPath: "/gov/proposals/wasm_sudo",
 			srcBody: dict{
@@ -236,6 +236,54 @@ func ExecuteProposalHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
 	}
 }
 
+type ExecuteBatchProposalJSONReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+
+	Proposer string    `json:"proposer" yaml:"proposer"`
+	Deposit  sdk.Coins `json:"deposit" yaml:"deposit"`
+
+	// RunAs is the role that is passed to the contracts' environment
+	RunAs string               `json:"run_as" yaml:"run_as"`
+	Calls []types.ContractCall `json:"calls" yaml:"calls"`
+}
+
+func (s ExecuteBatchProposalJSONReq) Content() govtypes.Content {
+	return &types.ExecuteContractBatchProposal{
+		Title:       s.Title,
+		Description: s.Description,
+		RunAs:       s.RunAs,
+		Calls:       s.Calls,
+	}
+}
+
+func (s ExecuteBatchProposalJSONReq) GetProposer() string {
+	return s.Proposer
+}
+
+func (s ExecuteBatchProposalJSONReq) GetDeposit() sdk.Coins {
+	return s.Deposit
+}
+
+func (s ExecuteBatchProposalJSONReq) GetBaseReq() rest.BaseReq {
+	return s.BaseReq
+}
+
+func ExecuteBatchProposalHandler(cliCtx client.Context) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "wasm_execute_batch",
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			var req ExecuteBatchProposalJSONReq
+			if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+				return
+			}
+			toStdTxResponse(cliCtx, w, req)
+		},
+	}
+}
+
 type SudoProposalJSONReq struct {
 	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
 
@@ -18,6 +18,7 @@ func registerTxRoutes(cliCtx client.Context, r *mux.Router) {
 	r.HandleFunc("/wasm/code", storeCodeHandlerFn(cliCtx)).Methods("POST")
 	r.HandleFunc("/wasm/code/{codeId}", instantiateContractHandlerFn(cliCtx)).Methods("POST")
 	r.HandleFunc("/wasm/contract/{contractAddr}", executeContractHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/wasm/batch", executeContractBatchHandlerFn(cliCtx)).Methods("POST")
 }
 
 type storeCodeReq struct {
@@ -39,6 +40,11 @@ type executeContractReq struct {
 	Amount  sdk.Coins    `json:"coins" yaml:"coins"`
 }
 
+type executeContractBatchReq struct {
+	BaseReq rest.BaseReq         `json:"base_req" yaml:"base_req"`
+	Calls   []types.ContractCall `json:"calls" yaml:"calls"`
+}
+
 func storeCodeHandlerFn(cliCtx client.Context) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req storeCodeReq
@@ -54,15 +60,15 @@ func storeCodeHandlerFn(cliCtx client.Context) http.HandlerFunc {
 		var err error
 		wasm := req.WasmBytes
 
-		// gzip the wasm file
-		if ioutils.IsWasm(wasm) {
+		// gzip the wasm file or wrapper package
+		if ioutils.IsWasm(wasm) || ioutils.IsTar(wasm) {
 			wasm, err = ioutils.GzipIt(wasm)
 			if err != nil {
 				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 				return
 			}
 		} else if !ioutils.IsGzip(wasm) {
-			rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid input file, use wasm binary or zip")
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "Invalid input file, use wasm binary, wrapper package tar or zip")
 			return
 		}
 
@@ -147,3 +153,29 @@ func executeContractHandlerFn(cliCtx client.Context) http.HandlerFunc {
 		tx.WriteGeneratedTxResponse(cliCtx, w, req.BaseReq, &msg)
 	}
 }
+
+func executeContractBatchHandlerFn(cliCtx client.Context) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req executeContractBatchReq
+		if !rest.ReadRESTReq(w, r, cliCtx.LegacyAmino, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		msg := types.MsgExecuteContractBatch{
+			Sender: req.BaseReq.From,
+			Calls:  req.Calls,
+		}
+
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		tx.WriteGeneratedTxResponse(cliCtx, w, req.BaseReq, &msg)
+	}
+}
@@ -33,6 +33,8 @@ func NewHandler(k types.ContractOpsKeeper) sdk.Handler {
 			res, err = msgServer.InstantiateContract2(sdk.WrapSDKContext(ctx), msg)
 		case *MsgExecuteContract:
 			res, err = msgServer.ExecuteContract(sdk.WrapSDKContext(ctx), msg)
+		case *MsgExecuteContractBatch:
+			res, err = msgServer.ExecuteContractBatch(sdk.WrapSDKContext(ctx), msg)
 		case *MsgMigrateContract:
 			res, err = msgServer.MigrateContract(sdk.WrapSDKContext(ctx), msg)
 		case *MsgUpdateAdmin:
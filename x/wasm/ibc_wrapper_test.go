@@ -0,0 +1,122 @@
+package wasm_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	channeltypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
+	ibctesting "github.com/cosmos/ibc-go/v4/testing"
+	"github.com/polywrap/go-client/msgpack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	wasmibctesting "github.com/ConsiderItDone/wasmos/x/wasm/ibctesting"
+	wasmkeeper "github.com/ConsiderItDone/wasmos/x/wasm/keeper"
+	"github.com/ConsiderItDone/wasmos/x/wasm/polywrapvm"
+	wasmtypes "github.com/ConsiderItDone/wasmos/x/wasm/types"
+)
+
+func TestWrapperIBCEntryPoints(t *testing.T) {
+	// scenario: two wrappers with IBC entry points open a channel, exchange a packet, let a packet
+	// time out and close the channel. The recorder wrappers store the arguments of every call.
+	wat, err := os.ReadFile("./keeper/testdata/ibc_recorder.wat")
+	require.NoError(t, err)
+	code, err := wasmtime.Wat2Wasm(string(wat))
+	require.NoError(t, err)
+	manifest, err := os.ReadFile("./keeper/testdata/ibc_recorder.wrap.info")
+	require.NoError(t, err)
+	wrapper := wasmkeeper.WrapperPackage(t, code, manifest)
+
+	var (
+		coordinator = wasmibctesting.NewCoordinator(t, 2)
+		chainA      = coordinator.GetChain(wasmibctesting.GetChainID(0))
+		chainB      = coordinator.GetChain(wasmibctesting.GetChainID(1))
+	)
+	contractA := chainA.InstantiateContract(chainA.StoreCode(wrapper).CodeID, []byte(`{}`))
+	contractB := chainB.InstantiateContract(chainB.StoreCode(wrapper).CodeID, []byte(`{}`))
+	portA := chainA.ContractInfo(contractA).IBCPortID
+	portB := chainB.ContractInfo(contractB).IBCPortID
+	require.NotEmpty(t, portA)
+	require.NotEmpty(t, portB)
+
+	path := wasmibctesting.NewPath(chainA, chainB)
+	path.EndpointA.ChannelConfig = &ibctesting.ChannelConfig{PortID: portA, Version: "recorder-1", Order: channeltypes.UNORDERED}
+	path.EndpointB.ChannelConfig = &ibctesting.ChannelConfig{PortID: portB, Version: "recorder-1", Order: channeltypes.UNORDERED}
+	coordinator.SetupConnections(path)
+	coordinator.CreateChannels(path)
+
+	// the proposed version is accepted on open init and open try
+	openA := recordedIBCMsg[polywrapvm.IBCChannelOpenMsg](t, chainA, contractA, "ibcChannelOpen")
+	assert.Nil(t, openA.CounterpartyVersion)
+	assert.Equal(t, polywrapvm.IBCEndpoint{PortId: portA, ChannelId: path.EndpointA.ChannelID}, openA.Channel.Endpoint)
+	openB := recordedIBCMsg[polywrapvm.IBCChannelOpenMsg](t, chainB, contractB, "ibcChannelOpen")
+	require.NotNil(t, openB.CounterpartyVersion)
+	assert.Equal(t, "recorder-1", *openB.CounterpartyVersion)
+	assert.Equal(t, "recorder-1", path.EndpointA.GetChannel().Version)
+
+	connectA := recordedIBCMsg[polywrapvm.IBCChannelConnectMsg](t, chainA, contractA, "ibcChannelConnect")
+	require.NotNil(t, connectA.CounterpartyVersion)
+	assert.Equal(t, channeltypes.UNORDERED.String(), connectA.Channel.Order)
+	connectB := recordedIBCMsg[polywrapvm.IBCChannelConnectMsg](t, chainB, contractB, "ibcChannelConnect")
+	assert.Nil(t, connectB.CounterpartyVersion)
+	assert.Equal(t, polywrapvm.IBCEndpoint{PortId: portA, ChannelId: path.EndpointA.ChannelID}, connectB.Channel.CounterpartyEndpoint)
+
+	// the wrapper on chain A sends a packet, chain B acknowledges it with its response data
+	sendPacket(t, chainA, contractA, path.EndpointA.ChannelID, "ping", `{"block":{"revision":1,"height":1111111}}`)
+	require.NoError(t, coordinator.RelayAndAckPendingPackets(path))
+
+	received := recordedIBCMsg[polywrapvm.IBCPacketReceiveMsg](t, chainB, contractB, "ibcPacketReceive")
+	assert.Equal(t, []byte("ping"), received.Packet.Data)
+	assert.Equal(t, uint64(1), received.Packet.Sequence)
+	assert.Equal(t, &polywrapvm.IBCTimeoutBlock{Revision: 1, Height: 1111111}, received.Packet.Timeout.Block)
+	assert.Nil(t, received.Packet.Timeout.Timestamp)
+	assert.Equal(t, chainB.SenderAccount.GetAddress().String(), received.Relayer)
+
+	ack := recordedIBCMsg[polywrapvm.IBCPacketAckMsg](t, chainA, contractA, "ibcPacketAck")
+	assert.Equal(t, []byte("ibcPacketReceive"), ack.Acknowledgement)
+	assert.Equal(t, received.Packet, ack.OriginalPacket)
+
+	// a packet that is not relayed in time is returned to the wrapper
+	timeout := uint64(chainB.LastHeader.GetTime().Add(time.Second).UnixNano())
+	sendPacket(t, chainA, contractA, path.EndpointA.ChannelID, "lost", fmt.Sprintf(`{"timestamp":"%d"}`, timeout))
+	require.NoError(t, coordinator.TimeoutPendingPackets(path))
+
+	timedOut := recordedIBCMsg[polywrapvm.IBCPacketTimeoutMsg](t, chainA, contractA, "ibcPacketTimeout")
+	assert.Equal(t, []byte("lost"), timedOut.Packet.Data)
+	assert.Equal(t, &timeout, timedOut.Packet.Timeout.Timestamp)
+	assert.Nil(t, timedOut.Packet.Timeout.Block)
+
+	coordinator.CloseChannel(path)
+	assert.False(t, recordedIBCMsg[polywrapvm.IBCChannelCloseMsg](t, chainA, contractA, "ibcChannelClose").Confirm)
+	assert.True(t, recordedIBCMsg[polywrapvm.IBCChannelCloseMsg](t, chainB, contractB, "ibcChannelClose").Confirm)
+}
+
+// sendPacket executes the "send" method of the recorder wrapper with an IBC send packet message
+func sendPacket(t *testing.T, chain *wasmibctesting.TestChain, contract sdk.AccAddress, channelID, data, timeout string) {
+	t.Helper()
+	msg := fmt.Sprintf(`{"ibc":{"send_packet":{"channel_id":%q,"data":%q,"timeout":%s}}}`, channelID, base64.StdEncoding.EncodeToString([]byte(data)), timeout)
+	args := fmt.Sprintf(`{"msg":%q}`, msg)
+	_, err := chain.SendMsgs(&wasmtypes.MsgExecuteContract{
+		Sender:   chain.SenderAccount.GetAddress().String(),
+		Contract: contract.String(),
+		Msg:      []byte(args),
+		Method:   "send",
+	})
+	require.NoError(t, err)
+}
+
+// recordedIBCMsg decodes the msg argument of the last call of the IBC method stored by the recorder
+func recordedIBCMsg[T any](t *testing.T, chain *wasmibctesting.TestChain, contract sdk.AccAddress, method string) T {
+	t.Helper()
+	bz := chain.App.WasmKeeper.QueryRaw(chain.GetContext(), contract, []byte(method))
+	require.NotEmpty(t, bz, method)
+	args, err := msgpack.Decode[struct{ Msg T }](bz)
+	require.NoError(t, err)
+	return args.Msg
+}
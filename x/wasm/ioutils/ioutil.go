@@ -1,9 +1,12 @@
 package ioutils
 
 import (
+	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"fmt"
 	"io"
+	"path"
 
 	"github.com/ConsiderItDone/wasmos/x/wasm/types"
 )
@@ -22,6 +25,37 @@ func Uncompress(gzipSrc []byte, limit uint64) ([]byte, error) {
 	return io.ReadAll(LimitReader(zr, int64(limit)))
 }
 
+// Untar expects a valid tar source and returns the content of its regular files by cleaned path.
+// The content of all files together must not exceed the limit. See IsTar
+func Untar(tarSrc []byte, limit uint64) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	tr := tar.NewReader(bytes.NewReader(tarSrc))
+	r := LimitReader(tr, int64(limit))
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			continue
+		case tar.TypeReg:
+		default:
+			return nil, fmt.Errorf("unsupported tar entry %q", hdr.Name)
+		}
+		name := path.Clean(hdr.Name)
+		if _, exists := files[name]; exists {
+			return nil, fmt.Errorf("duplicate tar entry %q", name)
+		}
+		if files[name], err = io.ReadAll(r); err != nil {
+			return nil, err
+		}
+	}
+}
+
 // LimitReader returns a Reader that reads from r
 // but stops with types.ErrLimit after n bytes.
 // The underlying implementation is a *io.LimitedReader.
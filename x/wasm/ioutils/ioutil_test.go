@@ -1,6 +1,7 @@
 package ioutils
 
 import (
+	"archive/tar"
 	"bytes"
 	"compress/gzip"
 	"errors"
@@ -69,6 +70,77 @@ func TestUncompress(t *testing.T) {
 	}
 }
 
+func TestUntar(t *testing.T) {
+	const maxSize = 1_000
+
+	specs := map[string]struct {
+		src       []byte
+		expError  bool
+		expResult map[string][]byte
+	}{
+		"handle files": {
+			src:       asTar(&tar.Header{Name: "a"}, []byte("foo"), &tar.Header{Name: "./b/c"}, []byte("bar")),
+			expResult: map[string][]byte{"a": []byte("foo"), "b/c": []byte("bar")},
+		},
+		"skip directories": {
+			src:       asTar(&tar.Header{Name: "b/", Typeflag: tar.TypeDir}, nil, &tar.Header{Name: "b/c"}, []byte("bar")),
+			expResult: map[string][]byte{"b/c": []byte("bar")},
+		},
+		"handle limit output": {
+			src:       asTar(&tar.Header{Name: "a"}, bytes.Repeat([]byte{0x1}, maxSize/2), &tar.Header{Name: "b"}, nil),
+			expResult: map[string][]byte{"a": bytes.Repeat([]byte{0x1}, maxSize/2), "b": {}},
+		},
+		"reject big output": {
+			src:      asTar(&tar.Header{Name: "a"}, bytes.Repeat([]byte{0x1}, maxSize/2), &tar.Header{Name: "b"}, bytes.Repeat([]byte{0x1}, maxSize/2+1)),
+			expError: true,
+		},
+		"reject symlinks": {
+			src:      asTar(&tar.Header{Name: "a", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}, nil),
+			expError: true,
+		},
+		"reject duplicates": {
+			src:      asTar(&tar.Header{Name: "a"}, []byte("foo"), &tar.Header{Name: "./a"}, []byte("bar")),
+			expError: true,
+		},
+		"reject truncated": {
+			src:      asTar(&tar.Header{Name: "a"}, []byte("foo"))[:514],
+			expError: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			r, err := Untar(spec.src, maxSize)
+			if spec.expError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expResult, r)
+		})
+	}
+}
+
+// asTar writes pairs of header and content into a tar archive
+func asTar(entries ...any) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for i := 0; i < len(entries); i += 2 {
+		hdr := entries[i].(*tar.Header)
+		content, _ := entries[i+1].([]byte)
+		hdr.Mode, hdr.Size = 0o644, int64(len(content))
+		if err := tw.WriteHeader(hdr); err != nil {
+			panic(err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			panic(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}
+
 func asGzip(src []byte) []byte {
 	var buf bytes.Buffer
 	zipper := gzip.NewWriter(&buf)
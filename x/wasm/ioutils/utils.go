@@ -13,6 +13,11 @@ var (
 	gzipIdent = []byte("\x1F\x8B\x08")
 
 	wasmIdent = []byte("\x00\x61\x73\x6D")
+
+	// magic bytes at offset 257 of a POSIX (ustar) or GNU tar header.
+	// See https://www.gnu.org/software/tar/manual/html_node/Standard.html
+	tarIdent  = []byte("ustar")
+	tarOffset = 257
 )
 
 // IsGzip returns checks if the file contents are gzip compressed
@@ -25,6 +30,11 @@ func IsWasm(input []byte) bool {
 	return bytes.Equal(input[:4], wasmIdent)
 }
 
+// IsTar checks if the file contents are a tar archive
+func IsTar(input []byte) bool {
+	return len(input) >= tarOffset+len(tarIdent) && bytes.Equal(tarIdent, input[tarOffset:tarOffset+len(tarIdent)])
+}
+
 // GzipIt compresses the input ([]byte)
 func GzipIt(input []byte) ([]byte, error) {
 	// Create gzip writer.
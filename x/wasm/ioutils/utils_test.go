@@ -1,6 +1,7 @@
 package ioutils
 
 import (
+	"archive/tar"
 	"os"
 	"testing"
 
@@ -46,6 +47,19 @@ func TestIsGzip(t *testing.T) {
 	require.True(t, IsGzip(gzipData))
 }
 
+func TestIsTar(t *testing.T) {
+	wasmCode, someRandomStr, gzipData, err := GetTestData()
+	require.NoError(t, err)
+	tarData := asTar(&tar.Header{Name: "wrap.wasm"}, wasmCode)
+
+	require.False(t, IsTar(wasmCode))
+	require.False(t, IsTar(someRandomStr))
+	require.False(t, IsTar(gzipData))
+	require.False(t, IsTar(nil))
+	require.True(t, IsTar(tarData[:tarOffset+len(tarIdent)]))
+	require.True(t, IsTar(tarData))
+}
+
 func TestGzipIt(t *testing.T) {
 	wasmCode, someRandomStr, _, err := GetTestData()
 	originalGzipData := []byte{
@@ -10,7 +10,7 @@ var _ types.ContractOpsKeeper = PermissionedKeeper{}
 
 // decoratedKeeper contains a subset of the wasm keeper that are already or can be guarded by an authorization policy in the future
 type decoratedKeeper interface {
-	create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, authZ AuthorizationPolicy) (codeID uint64, checksum []byte, err error)
+	create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, runtime types.Runtime, authZ AuthorizationPolicy) (codeID uint64, checksum []byte, err error)
 
 	instantiate(
 		ctx sdk.Context,
@@ -28,6 +28,7 @@ type decoratedKeeper interface {
 	pinCode(ctx sdk.Context, codeID uint64) error
 	unpinCode(ctx sdk.Context, codeID uint64) error
 	execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, method string, coins sdk.Coins) ([]byte, error)
+	executeBatch(ctx sdk.Context, caller sdk.AccAddress, calls []types.ContractCall) ([][]byte, error)
 	Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte) ([]byte, error)
 	setContractInfoExtension(ctx sdk.Context, contract sdk.AccAddress, extra types.ContractInfoExtension) error
 	setAccessConfig(ctx sdk.Context, codeID uint64, caller sdk.AccAddress, newConfig types.AccessConfig, autz AuthorizationPolicy) error
@@ -52,7 +53,12 @@ func NewDefaultPermissionKeeper(nested decoratedKeeper) *PermissionedKeeper {
 }
 
 func (p PermissionedKeeper) Create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig) (codeID uint64, checksum []byte, err error) {
-	return p.nested.create(ctx, creator, wasmCode, instantiateAccess, p.authZPolicy)
+	return p.nested.create(ctx, creator, wasmCode, instantiateAccess, types.RuntimeUnspecified, p.authZPolicy)
+}
+
+// CreateWithRuntime uploads the code for the given runtime. The runtime is detected from the wasm exports when unspecified.
+func (p PermissionedKeeper) CreateWithRuntime(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, runtime types.Runtime) (codeID uint64, checksum []byte, err error) {
+	return p.nested.create(ctx, creator, wasmCode, instantiateAccess, runtime, p.authZPolicy)
 }
 
 // Instantiate creates an instance of a WASM contract using the classic sequence based address generator
@@ -95,6 +101,10 @@ func (p PermissionedKeeper) Execute(ctx sdk.Context, contractAddress sdk.AccAddr
 	return p.nested.execute(ctx, contractAddress, caller, msg, method, coins)
 }
 
+func (p PermissionedKeeper) ExecuteBatch(ctx sdk.Context, caller sdk.AccAddress, calls []types.ContractCall) ([][]byte, error) {
+	return p.nested.executeBatch(ctx, caller, calls)
+}
+
 func (p PermissionedKeeper) Migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte) ([]byte, error) {
 	return p.nested.migrate(ctx, contractAddress, caller, newCodeID, msg, p.authZPolicy)
 }
@@ -0,0 +1,88 @@
+package keeper
+
+import (
+	"errors"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/ConsiderItDone/wasmos/x/wasm/polywrapvm"
+	"github.com/ConsiderItDone/wasmos/x/wasm/types"
+)
+
+var _ types.ContractEngine = cosmwasmEngine{}
+
+// cosmwasmEngine adapts the wasmvm engine to the ContractEngine interface. CosmWasm contracts have a single
+// execute and query entry point, so the wrapper method is ignored.
+type cosmwasmEngine struct {
+	types.WasmerEngine
+}
+
+func (e cosmwasmEngine) Execute(checksum wasmvm.Checksum, env wasmvmtypes.Env, info wasmvmtypes.MessageInfo, executeMsg []byte, _ string, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) (*wasmvmtypes.Response, uint64, error) {
+	return e.WasmerEngine.Execute(checksum, env, info, executeMsg, store, goapi, querier, gasMeter, gasLimit, deserCost)
+}
+
+func (e cosmwasmEngine) Query(checksum wasmvm.Checksum, env wasmvmtypes.Env, queryMsg []byte, _ string, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost wasmvmtypes.UFraction) ([]byte, uint64, error) {
+	return e.WasmerEngine.Query(checksum, env, queryMsg, store, goapi, querier, gasMeter, gasLimit, deserCost)
+}
+
+// engine returns the contract engine for the code runtime. Code stored without a runtime is a Polywrap wrapper.
+func (k Keeper) engine(runtime types.Runtime) types.ContractEngine {
+	if runtime.IsPolywrap() {
+		return k.polywrapVm
+	}
+	return cosmwasmEngine{k.wasmVM}
+}
+
+// detectRuntime selects the runtime from the wasm exports. Polywrap wrappers export `_wrap_invoke`.
+func detectRuntime(wasmCode []byte) types.Runtime {
+	if polywrapvm.IsWrapper(wasmCode) {
+		return types.RuntimePolywrap
+	}
+	return types.RuntimeCosmWasm
+}
+
+// engineError wraps a failed engine call into the error of the keeper operation. Messages rejected by the
+// engine before the contract is invoked keep their ErrInvalidMsg, so clients can tell them apart.
+func engineError(failure *sdkerrors.Error, err error) error {
+	if errors.Is(err, types.ErrInvalidMsg) {
+		return sdkerrors.Wrap(err, failure.Error())
+	}
+	return sdkerrors.Wrap(failure, err.Error())
+}
+
+// codeValidator is implemented by engines that check new uploads beyond what Create checks. Create also
+// stores code imported from genesis or a snapshot, which the chain accepted before.
+type codeValidator interface {
+	ValidateCode(code wasmvm.WasmCode) error
+}
+
+// manifestEngine is implemented by engines that run wrapper code against its wrap.info manifest
+type manifestEngine interface {
+	WithManifest(manifest []byte) types.ContractEngine
+}
+
+// codeEngine returns the contract engine for the code runtime with the manifest of the code bound to it
+func (k Keeper) codeEngine(ctx sdk.Context, runtime types.Runtime, codeID uint64) types.ContractEngine {
+	engine := k.engine(runtime)
+	if m, ok := engine.(manifestEngine); ok {
+		return m.WithManifest(k.GetManifest(ctx, codeID))
+	}
+	return engine
+}
+
+// storeManifest validates the wrap.info of a wrapper code and stores it in the module state
+func (k Keeper) storeManifest(ctx sdk.Context, codeID uint64, runtime types.Runtime, manifest []byte) error {
+	if !runtime.IsPolywrap() {
+		return sdkerrors.Wrap(types.ErrCreateFailed, "manifest not supported by the code runtime")
+	}
+	if _, err := polywrapvm.DecodeManifest(manifest); err != nil {
+		return sdkerrors.Wrap(types.ErrCreateFailed, sdkerrors.Wrap(err, "invalid manifest").Error())
+	}
+	store := ctx.KVStore(k.storeKey)
+	// 0x0a | codeID (uint64) -> manifest
+	store.Set(types.GetManifestKey(codeID), manifest)
+	return nil
+}
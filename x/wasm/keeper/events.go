@@ -6,7 +6,6 @@ import (
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/ConsiderItDone/wasmos/x/wasm/types"
 )
@@ -23,20 +22,18 @@ func newWasmModuleEvent(customAttributes []wasmvmtypes.EventAttribute, contractA
 	return sdk.Events{sdk.NewEvent(types.WasmModuleEventType, attrs...)}, nil
 }
 
-const eventTypeMinLength = 2
-
 // newCustomEvents converts wasmvm events from a contract response to sdk type events
 func newCustomEvents(evts wasmvmtypes.Events, contractAddr sdk.AccAddress) (sdk.Events, error) {
 	events := make(sdk.Events, 0, len(evts))
 	for _, e := range evts {
-		typ := strings.TrimSpace(e.Type)
-		if len(typ) <= eventTypeMinLength {
-			return nil, sdkerrors.Wrap(types.ErrInvalidEvent, fmt.Sprintf("Event type too short: '%s'", typ))
+		if err := types.ValidateEventType(e.Type); err != nil {
+			return nil, err
 		}
 		attributes, err := contractSDKEventAttributes(e.Attributes, contractAddr)
 		if err != nil {
 			return nil, err
 		}
+		typ := strings.TrimSpace(e.Type)
 		events = append(events, sdk.NewEvent(fmt.Sprintf("%s%s", types.CustomContractEventPrefix, typ), attributes...))
 	}
 	return events, nil
@@ -47,21 +44,11 @@ func contractSDKEventAttributes(customAttributes []wasmvmtypes.EventAttribute, c
 	attrs := []sdk.Attribute{sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddr.String())}
 	// append attributes from wasm to the sdk.Event
 	for _, l := range customAttributes {
-		// ensure key and value are non-empty (and trim what is there)
-		key := strings.TrimSpace(l.Key)
-		if len(key) == 0 {
-			return nil, sdkerrors.Wrap(types.ErrInvalidEvent, fmt.Sprintf("Empty attribute key. Value: %s", l.Value))
-		}
-		value := strings.TrimSpace(l.Value)
-		// TODO: check if this is legal in the SDK - if it is, we can remove this check
-		if len(value) == 0 {
-			return nil, sdkerrors.Wrap(types.ErrInvalidEvent, fmt.Sprintf("Empty attribute value. Key: %s", key))
-		}
-		// and reserve all _* keys for our use (not contract)
-		if strings.HasPrefix(key, types.AttributeReservedPrefix) {
-			return nil, sdkerrors.Wrap(types.ErrInvalidEvent, fmt.Sprintf("Attribute key starts with reserved prefix %s: '%s'", types.AttributeReservedPrefix, key))
+		// ensure key and value are non-empty, not reserved (and trim what is there)
+		if err := types.ValidateEventAttribute(l.Key, l.Value); err != nil {
+			return nil, err
 		}
-		attrs = append(attrs, sdk.NewAttribute(key, value))
+		attrs = append(attrs, sdk.NewAttribute(strings.TrimSpace(l.Key), strings.TrimSpace(l.Value)))
 	}
 	return attrs, nil
 }
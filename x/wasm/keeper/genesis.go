@@ -25,6 +25,11 @@ func InitGenesis(ctx sdk.Context, keeper *Keeper, data types.GenesisState) ([]ab
 		if err != nil {
 			return nil, sdkerrors.Wrapf(err, "code %d with id: %d", i, code.CodeID)
 		}
+		if len(code.Manifest) != 0 {
+			if err := keeper.importManifest(ctx, code.CodeID, code.Manifest); err != nil {
+				return nil, sdkerrors.Wrapf(err, "code %d with id: %d", i, code.CodeID)
+			}
+		}
 		if code.CodeID > maxCodeID {
 			maxCodeID = code.CodeID
 		}
@@ -83,6 +88,7 @@ func ExportGenesis(ctx sdk.Context, keeper *Keeper) *types.GenesisState {
 			CodeInfo:  info,
 			CodeBytes: bytecode,
 			Pinned:    keeper.IsPinnedCode(ctx, codeID),
+			Manifest:  keeper.GetManifest(ctx, codeID),
 		})
 		return false
 	})
@@ -570,6 +570,8 @@ func TestImportContractWithCodeHistoryPreserved(t *testing.T) {
 			Permission: types.AccessTypeOnlyAddress,
 			Address:    codeCreatorAddr,
 		},
+		// detected on import
+		Runtime: types.RuntimeCosmWasm,
 	}
 	assert.Equal(t, expCodeInfo, *gotCodeInfo)
 
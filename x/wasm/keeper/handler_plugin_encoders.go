@@ -215,10 +215,17 @@ func EncodeWasmMsg(sender sdk.AccAddress, msg *wasmvmtypes.WasmMsg) ([]sdk.Msg,
 			return nil, err
 		}
 
+		// WasmMsg::Execute has no method field, wrappers are addressed with a method envelope
+		execMsg, method, err := types.UnwrapMethodEnvelope(msg.Execute.Msg)
+		if err != nil {
+			return nil, err
+		}
+
 		sdkMsg := types.MsgExecuteContract{
 			Sender:   sender.String(),
 			Contract: msg.Execute.ContractAddr,
-			Msg:      msg.Execute.Msg,
+			Msg:      execMsg,
+			Method:   method,
 			Funds:    coins,
 		}
 		return []sdk.Msg{&sdkMsg}, nil
@@ -163,6 +163,50 @@ func TestEncoding(t *testing.T) {
 				},
 			},
 		},
+		"wasm execute with method envelope": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Wasm: &wasmvmtypes.WasmMsg{
+					Execute: &wasmvmtypes.ExecuteMsg{
+						ContractAddr: addr2.String(),
+						Msg:          []byte(`{"wasmos":{"method":"sayHello","msg":{"foo": 123}}}`),
+						Funds:        []wasmvmtypes.Coin{},
+					},
+				},
+			},
+			output: []sdk.Msg{
+				&types.MsgExecuteContract{
+					Sender:   addr1.String(),
+					Contract: addr2.String(),
+					Msg:      jsonMsg,
+					Method:   "sayHello",
+				},
+			},
+		},
+		"wasm execute with invalid envelope method": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Wasm: &wasmvmtypes.WasmMsg{
+					Execute: &wasmvmtypes.ExecuteMsg{
+						ContractAddr: addr2.String(),
+						Msg:          []byte(`{"wasmos":{"method":"say-hello","msg":{}}}`),
+					},
+				},
+			},
+			isError: true,
+		},
+		"wasm execute with empty envelope method": {
+			sender: addr1,
+			srcMsg: wasmvmtypes.CosmosMsg{
+				Wasm: &wasmvmtypes.WasmMsg{
+					Execute: &wasmvmtypes.ExecuteMsg{
+						ContractAddr: addr2.String(),
+						Msg:          []byte(`{"wasmos":{"msg":{}}}`),
+					},
+				},
+			},
+			isError: true,
+		},
 		"wasm instantiate": {
 			sender: addr1,
 			srcMsg: wasmvmtypes.CosmosMsg{
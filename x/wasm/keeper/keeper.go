@@ -39,6 +39,8 @@ type contextKey int
 const (
 	// private type creates an interface key for Context that cannot be accessed by any other package
 	contextKeyQueryStackSize contextKey = iota
+	// contextKeyBridgeCallers holds the contracts waiting for a message they execute through the proto bridge
+	contextKeyBridgeCallers
 )
 
 // Option is an extension point to instantiate keeper with non default values
@@ -103,7 +105,8 @@ type Keeper struct {
 	maxQueryStackSize    uint32
 	acceptedAccountTypes map[reflect.Type]struct{}
 	accountPruner        AccountPruner
-	polywrapVm           *polywrapvm.VM
+	polywrapVm           types.ContractEngine
+	protoBridge          ProtoBridge
 }
 
 // NewKeeper creates a new contract Keeper instance
@@ -121,7 +124,7 @@ func NewKeeper(
 	capabilityKeeper types.CapabilityKeeper,
 	portSource types.ICS20TransferPortSource,
 	router MessageRouter,
-	_ GRPCQueryRouter,
+	queryRouter GRPCQueryRouter,
 	homeDir string,
 	wasmConfig types.WasmConfig,
 	availableCapabilities string,
@@ -136,7 +139,7 @@ func NewKeeper(
 		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
 	}
 
-	polywrapVm, err := polywrapvm.NewVM(filepath.Join(homeDir, "cosmowrap"))
+	polywrapVm, err := polywrapvm.NewVM(filepath.Join(homeDir, "cosmowrap"), availableCapabilities, contractMemoryLimit, wasmConfig.MemoryCacheSize)
 	if err != nil {
 		panic(err)
 	}
@@ -157,6 +160,7 @@ func NewKeeper(
 		maxQueryStackSize:    types.DefaultMaxQueryStackSize,
 		acceptedAccountTypes: defaultAcceptedAccountTypes,
 		polywrapVm:           polywrapVm,
+		protoBridge:          NewProtoBridge(router, queryRouter, cdc, AcceptedBridgeQueries{}),
 	}
 	keeper.wasmVMQueryHandler = DefaultQueryPlugins(bankKeeper, stakingKeeper, distKeeper, channelKeeper, keeper)
 	for _, o := range opts {
@@ -190,7 +194,7 @@ func (k Keeper) SetParams(ctx sdk.Context, ps types.Params) {
 	k.paramSpace.SetParamSet(ctx, &ps)
 }
 
-func (k Keeper) create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, authZ AuthorizationPolicy) (codeID uint64, checksum []byte, err error) {
+func (k Keeper) create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *types.AccessConfig, runtime types.Runtime, authZ AuthorizationPolicy) (codeID uint64, checksum []byte, err error) {
 	if creator == nil {
 		return 0, checksum, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "cannot be nil")
 	}
@@ -217,19 +221,43 @@ func (k Keeper) create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte,
 		}
 	}
 
+	// a wrapper package bundles the wasm module with its wrap.info manifest
+	var manifest []byte
+	if ioutils.IsTar(wasmCode) {
+		wasmCode, manifest, err = polywrapvm.UnpackWrapper(wasmCode, uint64(types.MaxWasmSize))
+		if err != nil {
+			return 0, checksum, sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
+		}
+	}
+
+	if runtime == types.RuntimeUnspecified {
+		runtime = detectRuntime(wasmCode)
+	}
+	engine := k.engine(runtime)
+
 	ctx.GasMeter().ConsumeGas(k.gasRegister.CompileCosts(len(wasmCode)), "Compiling wasm bytecode")
-	//checksum, err = k.wasmVM.Create(wasmCode)
-	checksum, err = k.polywrapVm.Create(wasmCode)
+	if v, ok := engine.(codeValidator); ok {
+		if err := v.ValidateCode(wasmCode); err != nil {
+			return 0, checksum, sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
+		}
+	}
+	checksum, err = engine.Create(wasmCode)
 	if err != nil {
 		return 0, checksum, sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
 	}
-	report, err := k.polywrapVm.AnalyzeCode(checksum)
+	codeID = k.autoIncrementID(ctx, types.KeyLastCodeID)
+	if manifest != nil {
+		if err := k.storeManifest(ctx, codeID, runtime, manifest); err != nil {
+			return 0, checksum, err
+		}
+	}
+	report, err := k.codeEngine(ctx, runtime, codeID).AnalyzeCode(checksum)
 	if err != nil {
 		return 0, checksum, sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
 	}
-	codeID = k.autoIncrementID(ctx, types.KeyLastCodeID)
-	k.Logger(ctx).Debug("storing new contract", "capabilities", report.RequiredCapabilities, "code_id", codeID)
+	k.Logger(ctx).Debug("storing new contract", "capabilities", report.RequiredCapabilities, "code_id", codeID, "runtime", runtime)
 	codeInfo := types.NewCodeInfo(checksum, creator, *instantiateAccess)
+	codeInfo.Runtime = runtime
 	k.storeCodeInfo(ctx, codeID, codeInfo)
 
 	evt := sdk.NewEvent(
@@ -259,7 +287,11 @@ func (k Keeper) importCode(ctx sdk.Context, codeID uint64, codeInfo types.CodeIn
 			return sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
 		}
 	}
-	newCodeHash, err := k.polywrapVm.Create(wasmCode)
+	// code exported before the runtime was recorded
+	if codeInfo.Runtime == types.RuntimeUnspecified {
+		codeInfo.Runtime = detectRuntime(wasmCode)
+	}
+	newCodeHash, err := k.engine(codeInfo.Runtime).Create(wasmCode)
 	if err != nil {
 		return sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
 	}
@@ -277,6 +309,15 @@ func (k Keeper) importCode(ctx sdk.Context, codeID uint64, codeInfo types.CodeIn
 	return nil
 }
 
+// importManifest stores the wrap.info of an imported wrapper code
+func (k Keeper) importManifest(ctx sdk.Context, codeID uint64, manifest []byte) error {
+	codeInfo := k.GetCodeInfo(ctx, codeID)
+	if codeInfo == nil {
+		return sdkerrors.Wrapf(types.ErrNotFound, "code %d", codeID)
+	}
+	return k.storeManifest(ctx, codeID, codeInfo.Runtime, manifest)
+}
+
 func (k Keeper) instantiate(
 	ctx sdk.Context,
 	codeID uint64,
@@ -362,10 +403,11 @@ func (k Keeper) instantiate(
 
 	// instantiate wasm contract
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, err := k.polywrapVm.Init(codeInfo.CodeHash, env, info, initMsg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+	engine := k.codeEngine(ctx, codeInfo.Runtime, codeID)
+	res, gasUsed, err := engine.Instantiate(codeInfo.CodeHash, env, info, initMsg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if err != nil {
-		return nil, nil, sdkerrors.Wrap(types.ErrInstantiateFailed, err.Error())
+		return nil, nil, engineError(types.ErrInstantiateFailed, err)
 	}
 
 	// persist instance first
@@ -373,7 +415,7 @@ func (k Keeper) instantiate(
 	contractInfo := types.NewContractInfo(codeID, creator, admin, label, createdAt)
 
 	// check for IBC flag
-	report, err := k.polywrapVm.AnalyzeCode(codeInfo.CodeHash)
+	report, err := engine.AnalyzeCode(codeInfo.CodeHash)
 	if err != nil {
 		return nil, nil, sdkerrors.Wrap(types.ErrInstantiateFailed, err.Error())
 	}
@@ -410,6 +452,9 @@ func (k Keeper) instantiate(
 // Execute executes the contract instance
 func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, method string, coins sdk.Coins) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "execute")
+	if err := checkBridgeReentrancy(ctx, contractAddress); err != nil {
+		return nil, err
+	}
 	contractInfo, codeInfo, prefixStore, err := k.contractInstance(ctx, contractAddress)
 	if err != nil {
 		return nil, err
@@ -431,10 +476,10 @@ func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddress)
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.polywrapVm.Execute(codeInfo.CodeHash, env, info, msg, method, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+	res, gasUsed, execErr := k.codeEngine(ctx, codeInfo.Runtime, contractInfo.CodeID).Execute(codeInfo.CodeHash, env, info, msg, method, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if execErr != nil {
-		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return nil, engineError(types.ErrExecuteFailed, execErr)
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
@@ -442,7 +487,34 @@ func (k Keeper) execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 		sdk.NewAttribute(types.AttributeKeyContractAddr, contractAddress.String()),
 	))
 
-	return res.Data, nil
+	data, err := k.handleContractResponse(ctx, contractAddress, contractInfo.IBCPortID, res.Messages, res.Attributes, res.Data, res.Events)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "dispatch")
+	}
+
+	return data, nil
+}
+
+// executeBatch runs the calls in order in one cache context that is only committed when all calls
+// succeed
+func (k Keeper) executeBatch(ctx sdk.Context, caller sdk.AccAddress, calls []types.ContractCall) ([][]byte, error) {
+	if len(calls) == 0 {
+		return nil, sdkerrors.Wrap(types.ErrEmpty, "calls")
+	}
+	cacheCtx, commit := ctx.CacheContext()
+	data := make([][]byte, len(calls))
+	for i, c := range calls {
+		contractAddr, err := sdk.AccAddressFromBech32(c.Contract)
+		if err != nil {
+			return nil, sdkerrors.Wrapf(err, "call %d: contract", i)
+		}
+		if data[i], err = k.execute(cacheCtx, contractAddr, caller, c.Msg, c.Method, c.Funds); err != nil {
+			return nil, sdkerrors.Wrapf(err, "call %d", i)
+		}
+	}
+	commit()
+	ctx.EventManager().EmitEvents(cacheCtx.EventManager().Events())
+	return data, nil
 }
 
 func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte, authZ AuthorizationPolicy) ([]byte, error) {
@@ -450,6 +522,9 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	migrateSetupCosts := k.gasRegister.InstantiateContractCosts(k.IsPinnedCode(ctx, newCodeID), len(msg))
 	ctx.GasMeter().ConsumeGas(migrateSetupCosts, "Loading CosmWasm module: migrate")
 
+	if err := checkBridgeReentrancy(ctx, contractAddress); err != nil {
+		return nil, err
+	}
 	contractInfo := k.GetContractInfo(ctx, contractAddress)
 	if contractInfo == nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "unknown contract")
@@ -468,7 +543,8 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	}
 
 	// check for IBC flag
-	switch report, err := k.wasmVM.AnalyzeCode(newCodeInfo.CodeHash); {
+	engine := k.codeEngine(ctx, newCodeInfo.Runtime, newCodeID)
+	switch report, err := engine.AnalyzeCode(newCodeInfo.CodeHash); {
 	case err != nil:
 		return nil, sdkerrors.Wrap(types.ErrMigrationFailed, err.Error())
 	case !report.HasIBCEntryPoints && contractInfo.IBCPortID != "":
@@ -491,10 +567,10 @@ func (k Keeper) migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller
 	prefixStoreKey := types.GetContractStorePrefix(contractAddress)
 	prefixStore := prefix.NewStore(ctx.KVStore(k.storeKey), prefixStoreKey)
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, err := k.wasmVM.Migrate(newCodeInfo.CodeHash, env, msg, &prefixStore, cosmwasmAPI, &querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+	res, gasUsed, err := engine.Migrate(newCodeInfo.CodeHash, env, msg, &prefixStore, cosmwasmAPI, &querier, k.gasMeter(ctx), gas, costJSONDeserialization)
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if err != nil {
-		return nil, sdkerrors.Wrap(types.ErrMigrationFailed, err.Error())
+		return nil, engineError(types.ErrMigrationFailed, err)
 	}
 	// delete old secondary index entry
 	k.removeFromContractCodeSecondaryIndex(ctx, contractAddress, k.getLastContractHistoryEntry(ctx, contractAddress))
@@ -536,10 +612,10 @@ func (k Keeper) Sudo(ctx sdk.Context, contractAddress sdk.AccAddress, msg []byte
 	// prepare querier
 	querier := k.newQueryHandler(ctx, contractAddress)
 	gas := k.runtimeGasForContract(ctx)
-	res, gasUsed, execErr := k.wasmVM.Sudo(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+	res, gasUsed, execErr := k.codeEngine(ctx, codeInfo.Runtime, contractInfo.CodeID).Sudo(codeInfo.CodeHash, env, msg, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if execErr != nil {
-		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return nil, engineError(types.ErrExecuteFailed, execErr)
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
@@ -572,10 +648,10 @@ func (k Keeper) reply(ctx sdk.Context, contractAddress sdk.AccAddress, reply was
 	querier := k.newQueryHandler(ctx, contractAddress)
 	gas := k.runtimeGasForContract(ctx)
 
-	res, gasUsed, execErr := k.wasmVM.Reply(codeInfo.CodeHash, env, reply, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
+	res, gasUsed, execErr := k.codeEngine(ctx, codeInfo.Runtime, contractInfo.CodeID).Reply(codeInfo.CodeHash, env, reply, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), gas, costJSONDeserialization)
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if execErr != nil {
-		return nil, sdkerrors.Wrap(types.ErrExecuteFailed, execErr.Error())
+		return nil, engineError(types.ErrExecuteFailed, execErr)
 	}
 
 	ctx.EventManager().EmitEvent(sdk.NewEvent(
@@ -695,7 +771,7 @@ func (k Keeper) getLastContractHistoryEntry(ctx sdk.Context, contractAddr sdk.Ac
 }
 
 // QuerySmart queries the smart contract itself.
-func (k Keeper) QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte) ([]byte, error) {
+func (k Keeper) QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte, method string) ([]byte, error) {
 	defer telemetry.MeasureSince(time.Now(), "wasm", "contract", "query-smart")
 
 	// checks and increase query stack size
@@ -716,10 +792,10 @@ func (k Keeper) QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []b
 	querier := k.newQueryHandler(ctx, contractAddr)
 
 	env := types.NewEnv(ctx, contractAddr)
-	queryResult, gasUsed, qErr := k.wasmVM.Query(codeInfo.CodeHash, env, req, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), k.runtimeGasForContract(ctx), costJSONDeserialization)
+	queryResult, gasUsed, qErr := k.codeEngine(ctx, codeInfo.Runtime, contractInfo.CodeID).Query(codeInfo.CodeHash, env, req, method, prefixStore, cosmwasmAPI, querier, k.gasMeter(ctx), k.runtimeGasForContract(ctx), costJSONDeserialization)
 	k.consumeRuntimeGas(ctx, gasUsed)
 	if qErr != nil {
-		return nil, sdkerrors.Wrap(types.ErrQueryFailed, qErr.Error())
+		return nil, engineError(types.ErrQueryFailed, qErr)
 	}
 	return queryResult, nil
 }
@@ -886,7 +962,12 @@ func (k Keeper) GetByteCode(ctx sdk.Context, codeID uint64) ([]byte, error) {
 		return nil, nil
 	}
 	k.cdc.MustUnmarshal(codeInfoBz, &codeInfo)
-	return k.polywrapVm.GetCode(codeInfo.CodeHash)
+	return k.engine(codeInfo.Runtime).GetCode(codeInfo.CodeHash)
+}
+
+// GetManifest returns the wrap.info manifest of a wrapper code. It is nil for codes stored without a manifest.
+func (k Keeper) GetManifest(ctx sdk.Context, codeID uint64) []byte {
+	return ctx.KVStore(k.storeKey).Get(types.GetManifestKey(codeID))
 }
 
 // PinCode pins the wasm contract in wasmvm cache
@@ -896,7 +977,7 @@ func (k Keeper) pinCode(ctx sdk.Context, codeID uint64) error {
 		return sdkerrors.Wrap(types.ErrNotFound, "code info")
 	}
 
-	if err := k.wasmVM.Pin(codeInfo.CodeHash); err != nil {
+	if err := k.engine(codeInfo.Runtime).Pin(codeInfo.CodeHash); err != nil {
 		return sdkerrors.Wrap(types.ErrPinContractFailed, err.Error())
 	}
 	store := ctx.KVStore(k.storeKey)
@@ -916,7 +997,7 @@ func (k Keeper) unpinCode(ctx sdk.Context, codeID uint64) error {
 	if codeInfo == nil {
 		return sdkerrors.Wrap(types.ErrNotFound, "code info")
 	}
-	if err := k.wasmVM.Unpin(codeInfo.CodeHash); err != nil {
+	if err := k.engine(codeInfo.Runtime).Unpin(codeInfo.CodeHash); err != nil {
 		return sdkerrors.Wrap(types.ErrUnpinContractFailed, err.Error())
 	}
 
@@ -947,7 +1028,7 @@ func (k Keeper) InitializePinnedCodes(ctx sdk.Context) error {
 		if codeInfo == nil {
 			return sdkerrors.Wrap(types.ErrNotFound, "code info")
 		}
-		if err := k.wasmVM.Pin(codeInfo.CodeHash); err != nil {
+		if err := k.engine(codeInfo.Runtime).Pin(codeInfo.CodeHash); err != nil {
 			return sdkerrors.Wrap(types.ErrPinContractFailed, err.Error())
 		}
 	}
@@ -993,24 +1074,32 @@ func (k *Keeper) handleContractResponse(
 	data []byte,
 	evts wasmvmtypes.Events,
 ) ([]byte, error) {
-	attributeGasCost := k.gasRegister.EventCosts(attrs, evts)
-	ctx.GasMeter().ConsumeGas(attributeGasCost, "Custom contract event attributes")
 	// emit all events from this contract itself
+	if err := emitContractEvents(ctx, k.gasRegister, contractAddr, attrs, evts); err != nil {
+		return nil, err
+	}
+	return k.wasmVMResponseHandler.Handle(ctx, contractAddr, ibcPort, msgs, data)
+}
+
+// emitContractEvents charges and emits the custom attributes and events of a contract
+func emitContractEvents(ctx sdk.Context, gasRegister GasRegister, contractAddr sdk.AccAddress, attrs []wasmvmtypes.EventAttribute, evts wasmvmtypes.Events) error {
+	attributeGasCost := gasRegister.EventCosts(attrs, evts)
+	ctx.GasMeter().ConsumeGas(attributeGasCost, "Custom contract event attributes")
 	if len(attrs) != 0 {
 		wasmEvents, err := newWasmModuleEvent(attrs, contractAddr)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		ctx.EventManager().EmitEvents(wasmEvents)
 	}
 	if len(evts) > 0 {
 		customEvents, err := newCustomEvents(evts, contractAddr)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		ctx.EventManager().EmitEvents(customEvents)
 	}
-	return k.wasmVMResponseHandler.Handle(ctx, contractAddr, ibcPort, msgs, data)
+	return nil
 }
 
 func (k Keeper) runtimeGasForContract(ctx sdk.Context) uint64 {
@@ -1087,7 +1176,10 @@ func (k Keeper) importContract(ctx sdk.Context, contractAddr sdk.AccAddress, c *
 }
 
 func (k Keeper) newQueryHandler(ctx sdk.Context, contractAddress sdk.AccAddress) QueryHandler {
-	return NewQueryHandler(ctx, k.wasmVMQueryHandler, contractAddress, k.gasRegister)
+	q := NewQueryHandler(ctx, k.wasmVMQueryHandler, contractAddress, k.gasRegister)
+	q.contracts = k
+	q.bridge = &k.protoBridge
+	return q
 }
 
 // MultipliedGasMeter wraps the GasMeter from context and multiplies all reads by out defined multiplier
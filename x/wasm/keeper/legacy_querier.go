@@ -114,7 +114,7 @@ func queryContractState(ctx sdk.Context, bech, queryMethod string, data []byte,
 			return nil, sdkerrors.Wrap(err, "json msg")
 		}
 		// this returns raw bytes (must be base64-encoded)
-		bz, err := keeper.QuerySmart(ctx, contractAddr, msg)
+		bz, err := keeper.QuerySmart(ctx, contractAddr, msg, "")
 		return bz, err
 	default:
 		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, queryMethod)
@@ -129,6 +129,7 @@ func queryCodeList(ctx sdk.Context, keeper types.ViewKeeper) ([]types.CodeInfoRe
 			Creator:               res.Creator,
 			DataHash:              res.CodeHash,
 			InstantiatePermission: res.InstantiateConfig,
+			Runtime:               res.Runtime,
 		})
 		return false
 	})
@@ -29,12 +29,22 @@ type WasmVMMetricsCollector struct {
 
 // NewWasmVMMetricsCollector constructor
 func NewWasmVMMetricsCollector(s metricSource) *WasmVMMetricsCollector {
+	return newVMMetricsCollector(s, "wasmvm")
+}
+
+// NewPolywrapVMMetricsCollector creates a collector for the compiled module cache of the Polywrap runtime.
+// Its metrics have the same names as the wasmvm ones with the polywrapvm prefix.
+func NewPolywrapVMMetricsCollector(s metricSource) *WasmVMMetricsCollector {
+	return newVMMetricsCollector(s, "polywrapvm")
+}
+
+func newVMMetricsCollector(s metricSource, namespace string) *WasmVMMetricsCollector {
 	return &WasmVMMetricsCollector{
 		source:             s,
-		CacheHitsDescr:     prometheus.NewDesc("wasmvm_cache_hits_total", "Total number of cache hits", []string{"type"}, nil),
-		CacheMissesDescr:   prometheus.NewDesc("wasmvm_cache_misses_total", "Total number of cache misses", nil, nil),
-		CacheElementsDescr: prometheus.NewDesc("wasmvm_cache_elements_total", "Total number of elements in the cache", []string{"type"}, nil),
-		CacheSizeDescr:     prometheus.NewDesc("wasmvm_cache_size_bytes", "Total number of elements in the cache", []string{"type"}, nil),
+		CacheHitsDescr:     prometheus.NewDesc(namespace+"_cache_hits_total", "Total number of cache hits", []string{"type"}, nil),
+		CacheMissesDescr:   prometheus.NewDesc(namespace+"_cache_misses_total", "Total number of cache misses", nil, nil),
+		CacheElementsDescr: prometheus.NewDesc(namespace+"_cache_elements_total", "Total number of elements in the cache", []string{"type"}, nil),
+		CacheSizeDescr:     prometheus.NewDesc(namespace+"_cache_size_bytes", "Total number of elements in the cache", []string{"type"}, nil),
 	}
 }
 
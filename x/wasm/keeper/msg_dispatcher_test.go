@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/tendermint/tendermint/libs/log"
 
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
@@ -14,6 +15,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/ConsiderItDone/wasmos/x/wasm/keeper/wasmtesting"
+	"github.com/ConsiderItDone/wasmos/x/wasm/types"
 )
 
 func TestDispatchSubmessages(t *testing.T) {
@@ -424,3 +426,72 @@ func (m mockReplyer) reply(ctx sdk.Context, contractAddress sdk.AccAddress, repl
 	}
 	return m.replyFn(ctx, contractAddress, reply)
 }
+
+func TestDispatchSubmessagesWithMethodEnvelope(t *testing.T) {
+	// scenario: a wrapper dispatches an execute sub message that names the method of the target
+	// wrapper with a method envelope. The routed message carries the method and the unwrapped msg.
+	var gotMsgs []sdk.Msg
+	router := wasmtesting.MessageRouterFunc(func(msg sdk.Msg) baseapp.MsgServiceHandler {
+		return func(ctx sdk.Context, req sdk.Msg) (*sdk.Result, error) {
+			gotMsgs = append(gotMsgs, msg)
+			return &sdk.Result{}, nil
+		}
+	})
+	encoders := DefaultEncoders(MakeEncodingConfig(t).Marshaler, wasmtesting.MockIBCTransferKeeper{})
+	contractAddr, targetAddr := RandomAccountAddress(t), RandomBech32AccountAddress(t)
+
+	specs := map[string]struct {
+		msg       []byte
+		expErr    bool
+		expMsg    types.RawContractMessage
+		expMethod string
+	}{
+		"envelope": {
+			msg:       []byte(`{"wasmos":{"method":"sayHello","msg":{"name":"wasmos"}}}`),
+			expMsg:    []byte(`{"name":"wasmos"}`),
+			expMethod: "sayHello",
+		},
+		"plain message": {
+			msg:    []byte(`{"say_hello":{}}`),
+			expMsg: []byte(`{"say_hello":{}}`),
+		},
+		"invalid method": {
+			msg:    []byte(`{"wasmos":{"method":"say hello","msg":{}}}`),
+			expErr: true,
+		},
+		"empty method": {
+			msg:    []byte(`{"wasmos":{"method":"","msg":{}}}`),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotMsgs = nil
+			var mockStore wasmtesting.MockCommitMultiStore
+			ctx := sdk.Context{}.WithMultiStore(&mockStore).
+				WithGasMeter(sdk.NewInfiniteGasMeter()).
+				WithEventManager(sdk.NewEventManager()).WithLogger(log.TestingLogger())
+			d := NewMessageDispatcher(NewSDKMessageHandler(router, encoders), &mockReplyer{})
+			msgs := []wasmvmtypes.SubMsg{{
+				Msg: wasmvmtypes.CosmosMsg{Wasm: &wasmvmtypes.WasmMsg{Execute: &wasmvmtypes.ExecuteMsg{
+					ContractAddr: targetAddr,
+					Msg:          spec.msg,
+				}}},
+				ReplyOn: wasmvmtypes.ReplyNever,
+			}}
+			_, gotErr := d.DispatchSubmessages(ctx, contractAddr, "any_port", msgs)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.Empty(t, gotMsgs)
+				return
+			}
+			require.NoError(t, gotErr)
+			require.Len(t, gotMsgs, 1)
+			gotMsg, ok := gotMsgs[0].(*types.MsgExecuteContract)
+			require.True(t, ok)
+			assert.Equal(t, contractAddr.String(), gotMsg.Sender)
+			assert.Equal(t, spec.expMsg, gotMsg.Msg)
+			assert.Equal(t, spec.expMethod, gotMsg.Method)
+		})
+	}
+}
@@ -35,7 +35,7 @@ func (m msgServer) StoreCode(goCtx context.Context, msg *types.MsgStoreCode) (*t
 		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
 	))
 
-	codeID, checksum, err := m.keeper.Create(ctx, senderAddr, msg.WASMByteCode, msg.InstantiatePermission)
+	codeID, checksum, err := m.keeper.CreateWithRuntime(ctx, senderAddr, msg.WASMByteCode, msg.InstantiatePermission, msg.Runtime)
 	if err != nil {
 		return nil, err
 	}
@@ -146,6 +146,33 @@ func (m msgServer) ExecuteContract(goCtx context.Context, msg *types.MsgExecuteC
 	}, nil
 }
 
+func (m msgServer) ExecuteContractBatch(goCtx context.Context, msg *types.MsgExecuteContractBatch) (*types.MsgExecuteContractBatchResponse, error) {
+	if err := msg.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil {
+		return nil, sdkerrors.Wrap(err, "sender")
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeMessage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender),
+	))
+
+	data, err := m.keeper.ExecuteBatch(ctx, senderAddr, msg.Calls)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MsgExecuteContractBatchResponse{
+		Data: data,
+	}, nil
+}
+
 func (m msgServer) MigrateContract(goCtx context.Context, msg *types.MsgMigrateContract) (*types.MsgMigrateContractResponse, error) {
 	if err := msg.ValidateBasic(); err != nil {
 		return nil, err
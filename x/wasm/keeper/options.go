@@ -68,6 +68,14 @@ func WithQueryPlugins(x *QueryPlugins) Option {
 	})
 }
 
+// WithProtoBridgeQueries is an optional constructor parameter to set the gRPC queries wrappers can send
+// through the proto bridge. No queries are accepted by default.
+func WithProtoBridgeQueries(acceptList AcceptedBridgeQueries) Option {
+	return optsFn(func(k *Keeper) {
+		k.protoBridge.acceptedQueries = acceptList
+	})
+}
+
 // WithMessageEncoders is an optional constructor parameter to pass custom message encoder to the default wasm message handler.
 // This option expects the `DefaultMessageHandler` set and should not be combined with Option `WithMessageHandler` or `WithMessageHandlerDecorator`.
 func WithMessageEncoders(x *MessageEncoders) Option {
@@ -113,6 +121,9 @@ func WithAccountPruner(x AccountPruner) Option {
 func WithVMCacheMetrics(r prometheus.Registerer) Option {
 	return optsFn(func(k *Keeper) {
 		NewWasmVMMetricsCollector(k.wasmVM).Register(r)
+		if s, ok := k.polywrapVm.(metricSource); ok {
+			NewPolywrapVMMetricsCollector(s).Register(r)
+		}
 	})
 }
 
@@ -43,6 +43,8 @@ func NewWasmProposalHandlerX(k types.ContractOpsKeeper, enabledProposalTypes []t
 			return handleSudoProposal(ctx, k, *c)
 		case *types.ExecuteContractProposal:
 			return handleExecuteProposal(ctx, k, *c)
+		case *types.ExecuteContractBatchProposal:
+			return handleExecuteBatchProposal(ctx, k, *c)
 		case *types.UpdateAdminProposal:
 			return handleUpdateAdminProposal(ctx, k, *c)
 		case *types.ClearAdminProposal:
@@ -70,7 +72,7 @@ func handleStoreCodeProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types
 	if err != nil {
 		return sdkerrors.Wrap(err, "run as address")
 	}
-	codeID, checksum, err := k.Create(ctx, runAsAddr, p.WASMByteCode, p.InstantiatePermission)
+	codeID, checksum, err := k.CreateWithRuntime(ctx, runAsAddr, p.WASMByteCode, p.InstantiatePermission, p.Runtime)
 	if err != nil {
 		return err
 	}
@@ -160,7 +162,7 @@ func handleStoreAndInstantiateContractProposal(ctx sdk.Context, k types.Contract
 		}
 	}
 
-	codeID, checksum, err := k.Create(ctx, runAsAddr, p.WASMByteCode, p.InstantiatePermission)
+	codeID, checksum, err := k.CreateWithRuntime(ctx, runAsAddr, p.WASMByteCode, p.InstantiatePermission, p.Runtime)
 	if err != nil {
 		return err
 	}
@@ -256,6 +258,29 @@ func handleExecuteProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.E
 	return nil
 }
 
+func handleExecuteBatchProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.ExecuteContractBatchProposal) error {
+	if err := p.ValidateBasic(); err != nil {
+		return err
+	}
+
+	runAsAddr, err := sdk.AccAddressFromBech32(p.RunAs)
+	if err != nil {
+		return sdkerrors.Wrap(err, "run as address")
+	}
+	data, err := k.ExecuteBatch(ctx, runAsAddr, p.Calls)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range data {
+		ctx.EventManager().EmitEvent(sdk.NewEvent(
+			types.EventTypeGovContractResult,
+			sdk.NewAttribute(types.AttributeKeyResultDataHex, hex.EncodeToString(d)),
+		))
+	}
+	return nil
+}
+
 func handleUpdateAdminProposal(ctx sdk.Context, k types.ContractOpsKeeper, p types.UpdateAdminProposal) error {
 	if err := p.ValidateBasic(); err != nil {
 		return err
@@ -486,6 +486,58 @@ func TestExecuteProposal(t *testing.T) {
 	require.Equal(t, bal.Amount, sdk.NewInt(0))
 }
 
+func TestExecuteBatchProposal(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	govKeeper := keepers.GovKeeper
+
+	recorderCode := StoreRecorderExampleContract(t, ctx, keepers)
+	contractAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, recorderCode.CodeID, recorderCode.CreatorAddr, nil, []byte(`{}`), "recorder", nil)
+	require.NoError(t, err)
+	_, _, runAs := keyPubAddr()
+
+	// the whole batch fails with one unknown contract
+	badSrc := types.ExecuteContractBatchProposal{
+		Title:       "First",
+		Description: "Unknown contract",
+		RunAs:       runAs.String(),
+		Calls: []types.ContractCall{
+			{Contract: contractAddr.String(), Method: "setParams", Msg: []byte(`{}`)},
+			{Contract: RandomBech32AccountAddress(t), Method: "setParams", Msg: []byte(`{}`)},
+		},
+	}
+	_, err = govKeeper.SubmitProposal(ctx, &badSrc)
+	require.Error(t, err)
+	assert.Nil(t, keepers.WasmKeeper.QueryRaw(ctx, contractAddr, []byte("setParams")))
+
+	src := types.ExecuteContractBatchProposal{
+		Title:       "Second",
+		Description: "Set params and resume",
+		RunAs:       runAs.String(),
+		Calls: []types.ContractCall{
+			{Contract: contractAddr.String(), Method: "setParams", Msg: []byte(`{}`)},
+			{Contract: contractAddr.String(), Method: "resume", Msg: []byte(`{}`)},
+		},
+	}
+	storedProposal, err := govKeeper.SubmitProposal(ctx, &src)
+	require.NoError(t, err)
+
+	em := sdk.NewEventManager()
+	handler := govKeeper.Router().GetRoute(storedProposal.ProposalRoute())
+	err = handler(ctx.WithEventManager(em), storedProposal.GetContent())
+	require.NoError(t, err)
+
+	assert.NotNil(t, keepers.WasmKeeper.QueryRaw(ctx, contractAddr, []byte("setParams")))
+	assert.NotNil(t, keepers.WasmKeeper.QueryRaw(ctx, contractAddr, []byte("resume")))
+	// the result data of every call is emitted
+	var results []string
+	for _, e := range em.Events() {
+		if e.Type == types.EventTypeGovContractResult {
+			results = append(results, string(e.Attributes[0].Value))
+		}
+	}
+	assert.Equal(t, []string{hex.EncodeToString([]byte("setParams")), hex.EncodeToString([]byte("resume"))}, results)
+}
+
 func TestSudoProposal(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, "staking")
 	govKeeper, bankKeeper := keepers.GovKeeper, keepers.BankKeeper
@@ -0,0 +1,144 @@
+package keeper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/gogo/protobuf/proto"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/ConsiderItDone/wasmos/x/wasm/types"
+)
+
+// AcceptedBridgeQuery are the request and response types of a gRPC query wrappers can send through the
+// proto bridge
+type AcceptedBridgeQuery struct {
+	Request  codec.ProtoMarshaler
+	Response codec.ProtoMarshaler
+}
+
+// AcceptedBridgeQueries define the gRPC queries accepted by the proto bridge as a map with the path as key.
+// For example:
+// acceptList["/cosmos.bank.v1beta1.Query/Balance"] = AcceptedBridgeQuery{Request: &banktypes.QueryBalanceRequest{}, Response: &banktypes.QueryBalanceResponse{}}
+//
+// Warning: the same as for AcceptListStargateQuerier applies, chains need to test and maintain their accept
+// list carefully.
+type AcceptedBridgeQueries map[string]AcceptedBridgeQuery
+
+// ProtoBridge gives wrappers access to the Msg and gRPC Query services of the app by protobuf type URL and
+// query path, so that chain modules are reachable without custom message encoders or query plugins.
+// Messages are routed by the MessageRouter with the contract as only signer. Queries are routed by the
+// GRPCQueryRouter and must be accepted, none are by default.
+type ProtoBridge struct {
+	messages        SDKMessageHandler
+	queryRouter     GRPCQueryRouter
+	cdc             codec.Codec
+	acceptedQueries AcceptedBridgeQueries
+}
+
+func NewProtoBridge(router MessageRouter, queryRouter GRPCQueryRouter, cdc codec.Codec, acceptedQueries AcceptedBridgeQueries) ProtoBridge {
+	return ProtoBridge{
+		messages:        NewSDKMessageHandler(router, nil),
+		queryRouter:     queryRouter,
+		cdc:             cdc,
+		acceptedQueries: acceptedQueries,
+	}
+}
+
+// Execute decodes the protobuf JSON message with the type URL, executes it on behalf of the contract and
+// returns the protobuf JSON encoded Msg service response with the events of the message
+func (b ProtoBridge) Execute(ctx sdk.Context, contractAddr sdk.AccAddress, typeURL string, msgJSON []byte) ([]byte, []sdk.Event, error) {
+	if b.messages.router == nil {
+		return nil, nil, wasmvmtypes.UnsupportedRequest{Kind: "proto bridge messages are disabled"}
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(msgJSON, &fields); err != nil || fields == nil {
+		return nil, nil, sdkerrors.Wrap(types.ErrInvalidMsg, "msg must be a JSON object")
+	}
+	// the type URL selects the message type like in a JSON encoded Any
+	fields["@type"], _ = json.Marshal(typeURL)
+	anyJSON, err := json.Marshal(fields)
+	if err != nil {
+		return nil, nil, sdkerrors.Wrap(types.ErrInvalidMsg, err.Error())
+	}
+	var msg sdk.Msg
+	if err := b.cdc.UnmarshalInterfaceJSON(anyJSON, &msg); err != nil {
+		return nil, nil, sdkerrors.Wrapf(types.ErrInvalidMsg, "cannot decode message with type URL %s: %s", typeURL, err)
+	}
+	// Msg services respond with the <Msg>Response type, it is resolved before the message is executed
+	responseType := proto.MessageType(proto.MessageName(msg) + "Response")
+	if responseType == nil || responseType.Kind() != reflect.Ptr {
+		return nil, nil, sdkerrors.Wrapf(types.ErrInvalidMsg, "no Msg service response type for %s", typeURL)
+	}
+	response, ok := reflect.New(responseType.Elem()).Interface().(codec.ProtoMarshaler)
+	if !ok {
+		return nil, nil, sdkerrors.Wrapf(types.ErrInvalidMsg, "no Msg service response type for %s", typeURL)
+	}
+
+	res, err := b.messages.handleSdkMessage(ctx, contractAddr, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	bz, err := ConvertProtoToJSONMarshal(b.cdc, response, res.Data)
+	if err != nil {
+		return nil, nil, err
+	}
+	events := make([]sdk.Event, len(res.Events))
+	for i := range res.Events {
+		events[i] = sdk.Event(res.Events[i])
+	}
+	return bz, events, nil
+}
+
+// Query sends the protobuf JSON request to the accepted gRPC query path and returns the protobuf JSON
+// encoded response
+func (b ProtoBridge) Query(ctx sdk.Context, path string, requestJSON []byte) ([]byte, error) {
+	accepted, ok := b.acceptedQueries[path]
+	if !ok {
+		return nil, wasmvmtypes.UnsupportedRequest{Kind: fmt.Sprintf("'%s' path is not allowed from the contract", path)}
+	}
+	route := b.queryRouter.Route(path)
+	if route == nil {
+		return nil, wasmvmtypes.UnsupportedRequest{Kind: fmt.Sprintf("No route to query '%s'", path)}
+	}
+	// fresh instances, the accept list is shared by all queries
+	request := reflect.New(reflect.TypeOf(accepted.Request).Elem()).Interface().(codec.ProtoMarshaler)
+	if err := b.cdc.UnmarshalJSON(requestJSON, request); err != nil {
+		return nil, sdkerrors.Wrapf(types.ErrInvalid, "request of %s: %s", path, err)
+	}
+	data, err := b.cdc.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	res, err := route(ctx, abci.RequestQuery{Data: data, Path: path})
+	if err != nil {
+		return nil, err
+	}
+	response := reflect.New(reflect.TypeOf(accepted.Response).Elem()).Interface().(codec.ProtoMarshaler)
+	return ConvertProtoToJSONMarshal(b.cdc, response, res.Value)
+}
+
+// withBridgeCaller records the contract as waiting for a message it executes through the proto bridge
+func withBridgeCaller(ctx sdk.Context, contractAddr sdk.AccAddress) sdk.Context {
+	callers, _ := ctx.Context().Value(contextKeyBridgeCallers).([]string)
+	callers = append(append([]string{}, callers...), contractAddr.String())
+	return ctx.WithContext(context.WithValue(ctx.Context(), contextKeyBridgeCallers, callers))
+}
+
+// checkBridgeReentrancy rejects calls into a contract that is waiting for a message it executes through the
+// proto bridge. Wrappers can not be reentered, the same as for wrap://wasmos subinvocations.
+func checkBridgeReentrancy(ctx sdk.Context, contractAddr sdk.AccAddress) error {
+	callers, _ := ctx.Context().Value(contextKeyBridgeCallers).([]string)
+	for _, caller := range callers {
+		if caller == contractAddr.String() {
+			return sdkerrors.Wrapf(types.ErrUnsupportedForContract, "reentrant call of contract %s", caller)
+		}
+	}
+	return nil
+}
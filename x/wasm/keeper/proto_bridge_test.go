@@ -0,0 +1,191 @@
+package keeper
+
+import (
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ConsiderItDone/wasmos/x/wasm/types"
+)
+
+func TestProtoBridgeInvokeMsg(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	contractAddr := DeterministicAccountAddress(t, 1)
+	receiver := DeterministicAccountAddress(t, 2)
+	keepers.Faucet.Fund(ctx, contractAddr, sdk.NewInt64Coin("denom", 100))
+
+	const msgSend = "/cosmos.bank.v1beta1.MsgSend"
+	sendJSON := func(from sdk.AccAddress, amount int64) []byte {
+		return []byte(fmt.Sprintf(`{"from_address":%q,"to_address":%q,"amount":[{"denom":"denom","amount":"%d"}]}`, from.String(), receiver.String(), amount))
+	}
+
+	specs := map[string]struct {
+		typeURL    string
+		msg        []byte
+		expBalance int64
+		expErr     bool
+	}{
+		"send": {
+			typeURL:    msgSend,
+			msg:        sendJSON(contractAddr, 10),
+			expBalance: 10,
+		},
+		"other signer": {
+			typeURL: msgSend,
+			msg:     sendJSON(receiver, 10),
+			expErr:  true,
+		},
+		"insufficient funds": {
+			typeURL: msgSend,
+			msg:     sendJSON(contractAddr, 1000),
+			expErr:  true,
+		},
+		"unknown type url": {
+			typeURL: "/cosmos.bank.v1beta1.MsgUnknown",
+			msg:     []byte(`{}`),
+			expErr:  true,
+		},
+		"invalid message": {
+			typeURL: msgSend,
+			msg:     []byte(`{"from_address":1}`),
+			expErr:  true,
+		},
+		"not an object": {
+			typeURL: msgSend,
+			msg:     []byte(`[]`),
+			expErr:  true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+
+			gasBefore := ctx.GasMeter().GasConsumed()
+			res, gotErr := keepers.WasmKeeper.newQueryHandler(ctx, contractAddr).InvokeMsg(spec.typeURL, spec.msg, keepers.WasmKeeper.gasRegister.ToWasmVMGas(1_000_000))
+			if spec.expErr {
+				require.Error(t, gotErr)
+				assert.Empty(t, em.Events())
+				assert.Equal(t, int64(100), keepers.BankKeeper.GetBalance(ctx, contractAddr, "denom").Amount.Int64())
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Greater(t, ctx.GasMeter().GasConsumed(), gasBefore)
+			assert.JSONEq(t, `{}`, string(res))
+			assert.Equal(t, spec.expBalance, keepers.BankKeeper.GetBalance(ctx, receiver, "denom").Amount.Int64())
+			assert.NotEmpty(t, em.Events())
+			for _, e := range em.Events() {
+				assert.NotEqual(t, sdk.EventTypeMessage, e.Type)
+			}
+		})
+	}
+}
+
+func TestProtoBridgeQueryGrpc(t *testing.T) {
+	const balancePath = "/cosmos.bank.v1beta1.Query/Balance"
+	acceptList := AcceptedBridgeQueries{
+		balancePath:           {Request: &banktypes.QueryBalanceRequest{}, Response: &banktypes.QueryBalanceResponse{}},
+		"/no.such.Query/Path": {Request: &banktypes.QueryBalanceRequest{}, Response: &banktypes.QueryBalanceResponse{}},
+	}
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithProtoBridgeQueries(acceptList))
+	contractAddr := DeterministicAccountAddress(t, 1)
+	keepers.Faucet.Fund(ctx, contractAddr, sdk.NewInt64Coin("denom", 100))
+
+	specs := map[string]struct {
+		path   string
+		req    []byte
+		expRes string
+		expErr bool
+	}{
+		"balance": {
+			path:   balancePath,
+			req:    []byte(fmt.Sprintf(`{"address":%q,"denom":"denom"}`, contractAddr.String())),
+			expRes: `{"balance":{"denom":"denom","amount":"100"}}`,
+		},
+		"not accepted": {
+			path:   "/cosmos.bank.v1beta1.Query/AllBalances",
+			req:    []byte(fmt.Sprintf(`{"address":%q}`, contractAddr.String())),
+			expErr: true,
+		},
+		"no route": {
+			path:   "/no.such.Query/Path",
+			req:    []byte(`{}`),
+			expErr: true,
+		},
+		"invalid request": {
+			path:   balancePath,
+			req:    []byte(`{"address":1}`),
+			expErr: true,
+		},
+		"query error": {
+			path:   balancePath,
+			req:    []byte(`{"address":"invalid","denom":"denom"}`),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			res, gotErr := keepers.WasmKeeper.newQueryHandler(ctx, contractAddr).QueryGrpc(spec.path, spec.req, keepers.WasmKeeper.gasRegister.ToWasmVMGas(1_000_000))
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.JSONEq(t, spec.expRes, string(res))
+		})
+	}
+}
+
+func TestProtoBridgeReentrancy(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	contractAddr := DeterministicAccountAddress(t, 1)
+	otherAddr := DeterministicAccountAddress(t, 2)
+
+	bridgeCtx := withBridgeCaller(ctx, contractAddr)
+	require.NoError(t, checkBridgeReentrancy(ctx, contractAddr))
+	require.NoError(t, checkBridgeReentrancy(bridgeCtx, otherAddr))
+
+	_, err := keepers.WasmKeeper.execute(bridgeCtx, contractAddr, otherAddr, []byte(`{}`), "", nil)
+	assert.True(t, types.ErrUnsupportedForContract.Is(err), err)
+
+	_, err = keepers.WasmKeeper.migrate(bridgeCtx, contractAddr, otherAddr, 1, []byte(`{}`), nil)
+	assert.True(t, types.ErrUnsupportedForContract.Is(err), err)
+
+	// the caller stack is not shared with the parent context
+	withBridgeCaller(bridgeCtx, otherAddr)
+	require.NoError(t, checkBridgeReentrancy(bridgeCtx, otherAddr))
+}
+
+func TestProtoBridgeSubinvokeReentrancy(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	instantiate := func(codeID uint64) sdk.AccAddress {
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, nil, []byte(`{}`), "demo contract", nil)
+		require.NoError(t, err)
+		return addr
+	}
+	bridge := instantiate(StoreBridgeExampleContract(t, ctx, keepers).CodeID)
+	forwarder := instantiate(StoreForwarderExampleContract(t, ctx, keepers).CodeID)
+	call := func(uri string) []byte {
+		msg := fmt.Sprintf(`{"sender":%q,"contract":%q,"msg":{"uri":%q},"method":"forward"}`, bridge.String(), forwarder.String(), uri)
+		return []byte(fmt.Sprintf(`{"msg":%q}`, msg))
+	}
+
+	// bridge -> forwarder -> other contract
+	other := instantiate(StoreBridgeExampleContract(t, ctx, keepers).CodeID)
+	_, err := keepers.ContractKeeper.Execute(ctx, bridge, creator, call("wrap://wasmos/"+other.String()), "call", nil)
+	require.NoError(t, err)
+
+	// bridge -> forwarder -> bridge
+	_, err = keepers.ContractKeeper.Execute(ctx, bridge, creator, call("wrap://wasmos/"+bridge.String()), "call", nil)
+	require.Error(t, err)
+
+	// the bridge caller can not be resolved as subinvocation target
+	_, err = keepers.WasmKeeper.newQueryHandler(withBridgeCaller(ctx, bridge), forwarder).ResolveContract(bridge.String())
+	assert.True(t, types.ErrUnsupportedForContract.Is(err), err)
+}
@@ -3,6 +3,7 @@ package keeper
 import (
 	"context"
 	"encoding/binary"
+	"encoding/json"
 	"runtime/debug"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -14,6 +15,7 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/query"
 
+	"github.com/ConsiderItDone/wasmos/x/wasm/polywrapvm"
 	"github.com/ConsiderItDone/wasmos/x/wasm/types"
 )
 
@@ -193,7 +195,7 @@ func (q grpcQuerier) SmartContractState(c context.Context, req *types.QuerySmart
 		}
 	}()
 
-	bz, err := q.keeper.QuerySmart(ctx, contractAddr, req.QueryData)
+	bz, err := q.keeper.QuerySmart(ctx, contractAddr, req.QueryData, req.Method)
 	switch {
 	case err != nil:
 		return nil, err
@@ -241,6 +243,7 @@ func (q grpcQuerier) Codes(c context.Context, req *types.QueryCodesRequest) (*ty
 				Creator:               c.Creator,
 				DataHash:              c.CodeHash,
 				InstantiatePermission: c.InstantiateConfig,
+				Runtime:               c.Runtime,
 			})
 		}
 		return true, nil
@@ -276,6 +279,7 @@ func queryCode(ctx sdk.Context, codeID uint64, keeper types.ViewKeeper) (*types.
 		Creator:               res.Creator,
 		DataHash:              res.CodeHash,
 		InstantiatePermission: res.InstantiateConfig,
+		Runtime:               res.Runtime,
 	}
 
 	code, err := keeper.GetByteCode(ctx, codeID)
@@ -286,6 +290,36 @@ func queryCode(ctx sdk.Context, codeID uint64, keeper types.ViewKeeper) (*types.
 	return &types.QueryCodeResponse{CodeInfoResponse: &info, Data: code}, nil
 }
 
+func (q grpcQuerier) CodeABI(c context.Context, req *types.QueryCodeABIRequest) (*types.QueryCodeABIResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+	if req.CodeId == 0 {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, "code id")
+	}
+	ctx := sdk.UnwrapSDKContext(c)
+	info := q.keeper.GetCodeInfo(ctx, req.CodeId)
+	if info == nil {
+		return nil, types.ErrNotFound
+	}
+	if !info.Runtime.IsPolywrap() {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, "code is not a wrapper")
+	}
+	manifest := q.keeper.GetManifest(ctx, req.CodeId)
+	if len(manifest) == 0 {
+		return nil, sdkerrors.Wrap(types.ErrNotFound, "wrapper stored without manifest")
+	}
+	m, err := polywrapvm.DecodeManifest(manifest)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, err.Error())
+	}
+	abi, err := json.Marshal(m.ABI)
+	if err != nil {
+		return nil, err
+	}
+	return &types.QueryCodeABIResponse{Manifest: manifest, ABI: abi}, nil
+}
+
 func (q grpcQuerier) PinnedCodes(c context.Context, req *types.QueryPinnedCodesRequest) (*types.QueryPinnedCodesResponse, error) {
 	if req == nil {
 		return nil, status.Error(codes.InvalidArgument, "empty request")
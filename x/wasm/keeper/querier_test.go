@@ -159,7 +159,7 @@ func TestQuerySmartContractState(t *testing.T) {
 func TestQuerySmartContractPanics(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	contractAddr := BuildContractAddressClassic(1, 1)
-	keepers.WasmKeeper.storeCodeInfo(ctx, 1, types.CodeInfo{})
+	keepers.WasmKeeper.storeCodeInfo(ctx, 1, types.CodeInfo{Runtime: types.RuntimeCosmWasm})
 	keepers.WasmKeeper.storeContractInfo(ctx, contractAddr, &types.ContractInfo{
 		CodeID:  1,
 		Created: types.NewAbsoluteTxPosition(ctx),
@@ -201,6 +201,33 @@ func TestQuerySmartContractPanics(t *testing.T) {
 	}
 }
 
+func TestQuerySmartWrapperOutOfGas(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	example := StoreHelloWorldExampleContract(t, ctx, keepers)
+	initMsgBz := HelloWorldInitMsg{name: "Joe"}.GetBytes(t)
+	contractAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, initMsgBz, "demo contract", nil)
+	require.NoError(t, err)
+	ctx = ctx.WithLogger(log.TestingLogger())
+
+	// the wrapper runs out of gas after loading the contract, its manifest and the setup costs
+	queryData := types.RawContractMessage("{}")
+	gasMeter := sdk.NewInfiniteGasMeter()
+	_, _, _, err = keepers.WasmKeeper.contractInstance(ctx.WithGasMeter(gasMeter), contractAddr)
+	require.NoError(t, err)
+	pinned := keepers.WasmKeeper.IsPinnedCode(ctx.WithGasMeter(gasMeter), example.CodeID)
+	keepers.WasmKeeper.GetManifest(ctx.WithGasMeter(gasMeter), example.CodeID)
+	setupCosts := gasMeter.GasConsumed() + keepers.WasmKeeper.gasRegister.InstantiateContractCosts(pinned, len(queryData))
+	q := NewGrpcQuerier(keepers.WasmKeeper.cdc, keepers.WasmKeeper.storeKey, keepers.WasmKeeper, setupCosts+1)
+	got, err := q.SmartContractState(sdk.WrapSDKContext(ctx), &types.QuerySmartContractStateRequest{
+		Address:   contractAddr.String(),
+		QueryData: queryData,
+		Method:    "sayHello",
+	})
+	require.True(t, sdkErrors.ErrOutOfGas.Is(err), "got error: %+v", err)
+	assert.Nil(t, got)
+}
+
 func TestQueryRawContractState(t *testing.T) {
 	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
 	keeper := keepers.WasmKeeper
@@ -731,6 +758,7 @@ func TestQueryCodeInfo(t *testing.T) {
 					Creator:               codeInfo.Creator,
 					DataHash:              codeInfo.CodeHash,
 					InstantiatePermission: spec.accessConfig,
+					Runtime:               types.RuntimeCosmWasm,
 				},
 				Data: wasmCode,
 			}
@@ -791,6 +819,7 @@ func TestQueryCodeInfoList(t *testing.T) {
 			Creator:               code.codeInfo.Creator,
 			DataHash:              code.codeInfo.CodeHash,
 			InstantiatePermission: code.codeInfo.InstantiateConfig,
+			Runtime:               types.RuntimeCosmWasm,
 		})
 	}
 	q := Querier(keeper)
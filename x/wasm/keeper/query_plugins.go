@@ -7,12 +7,15 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	channeltypes "github.com/cosmos/ibc-go/v4/modules/core/04-channel/types"
 
+	"github.com/ConsiderItDone/wasmos/x/wasm/polywrapvm"
 	"github.com/ConsiderItDone/wasmos/x/wasm/types"
 
+	wasmvm "github.com/CosmWasm/wasmvm"
 	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -25,6 +28,17 @@ type QueryHandler struct {
 	Plugins     WasmVMQueryHandler
 	Caller      sdk.AccAddress
 	gasRegister GasRegister
+	// contracts resolves the wrap://wasmos subinvocations of wrappers, they are rejected when not set
+	contracts contractSource
+	// bridge serves the Msg and gRPC Query services to wrappers, they are rejected when not set
+	bridge *ProtoBridge
+}
+
+// contractSource gives the query handler access to the contracts and codes on chain
+type contractSource interface {
+	contractInstance(ctx sdk.Context, contractAddress sdk.AccAddress) (types.ContractInfo, types.CodeInfo, prefix.Store, error)
+	GetCodeInfo(ctx sdk.Context, codeID uint64) *types.CodeInfo
+	newQueryHandler(ctx sdk.Context, contractAddress sdk.AccAddress) QueryHandler
 }
 
 func NewQueryHandler(ctx sdk.Context, vmQueryHandler WasmVMQueryHandler, caller sdk.AccAddress, gasRegister GasRegister) QueryHandler {
@@ -42,7 +56,13 @@ type GRPCQueryRouter interface {
 
 // -- end baseapp interfaces --
 
-var _ wasmvmtypes.Querier = QueryHandler{}
+var (
+	_ wasmvmtypes.Querier          = QueryHandler{}
+	_ polywrapvm.ContractResolver  = QueryHandler{}
+	_ polywrapvm.ServiceBridge     = QueryHandler{}
+	_ polywrapvm.EventEmitter      = QueryHandler{}
+	_ polywrapvm.QueryGasConverter = QueryHandler{}
+)
 
 func (q QueryHandler) Query(request wasmvmtypes.QueryRequest, gasLimit uint64) ([]byte, error) {
 	// set a limit for a subCtx
@@ -75,6 +95,111 @@ func (q QueryHandler) GasConsumed() uint64 {
 	return q.Ctx.GasMeter().GasConsumed()
 }
 
+// ToWasmVMGas converts the SDK gas consumed by the queries of a wrapper into wasmvm gas
+func (q QueryHandler) ToWasmVMGas(source sdk.Gas) uint64 {
+	return q.gasRegister.ToWasmVMGas(source)
+}
+
+// ResolveContract returns the wrapper contract at the address for a wrap://wasmos subinvocation. The
+// contract gets its own store and queries the chain on its own behalf.
+func (q QueryHandler) ResolveContract(address string) (*polywrapvm.Contract, error) {
+	if q.contracts == nil {
+		return nil, sdkerrors.Wrap(types.ErrUnsupportedForContract, "subinvocation")
+	}
+	contractAddr, err := sdk.AccAddressFromBech32(address)
+	if err != nil {
+		return nil, sdkerrors.Wrap(types.ErrInvalid, address)
+	}
+	// a contract waiting for a message it executes through the proto bridge can not be reentered
+	if err := checkBridgeReentrancy(q.Ctx, contractAddr); err != nil {
+		return nil, err
+	}
+	// the callee runs on a branch of the caller state that is only written back when it succeeds
+	subCtx, commit := q.Ctx.CacheContext()
+	_, codeInfo, store, err := q.contracts.contractInstance(subCtx, contractAddr)
+	if err != nil {
+		return nil, err
+	}
+	if !codeInfo.Runtime.IsPolywrap() {
+		return nil, sdkerrors.Wrapf(types.ErrUnsupportedForContract, "contract %s is not a wrapper", address)
+	}
+	// the caller waits for the subinvocation, so the proto bridge of the callee must not reenter it
+	return &polywrapvm.Contract{
+		Checksum: codeInfo.CodeHash,
+		Store:    store,
+		Querier:  q.contracts.newQueryHandler(withBridgeCaller(subCtx, q.Caller), contractAddr),
+		Commit: func() {
+			commit()
+			q.Ctx.EventManager().EmitEvents(subCtx.EventManager().Events())
+		},
+	}, nil
+}
+
+// ResolveCode returns the checksum of the wrapper code for a wrap://wasmos/code subinvocation
+func (q QueryHandler) ResolveCode(codeID uint64) (wasmvm.Checksum, error) {
+	if q.contracts == nil {
+		return nil, sdkerrors.Wrap(types.ErrUnsupportedForContract, "subinvocation")
+	}
+	codeInfo := q.contracts.GetCodeInfo(q.Ctx, codeID)
+	if codeInfo == nil {
+		return nil, sdkerrors.Wrapf(types.ErrNotFound, "code %d", codeID)
+	}
+	if !codeInfo.Runtime.IsPolywrap() {
+		return nil, sdkerrors.Wrapf(types.ErrUnsupportedForContract, "code %d is not a wrapper", codeID)
+	}
+	return codeInfo.CodeHash, nil
+}
+
+// EmitEvents emits the events of a wrap://wasmos subinvocation for the callee contract, they are charged
+// and validated the same as the events of a contract response
+func (q QueryHandler) EmitEvents(attrs []wasmvmtypes.EventAttribute, evts wasmvmtypes.Events) error {
+	return emitContractEvents(q.Ctx, q.gasRegister, q.Caller, attrs, evts)
+}
+
+// InvokeMsg executes the message of a wrapper through the proto bridge on behalf of the contract. The
+// state changes and events of the message are only kept when it succeeds.
+func (q QueryHandler) InvokeMsg(typeURL string, msg []byte, gasLimit uint64) ([]byte, error) {
+	if q.bridge == nil {
+		return nil, wasmvmtypes.UnsupportedRequest{Kind: "proto bridge messages are disabled"}
+	}
+	sdkGas := q.gasRegister.FromWasmVMGas(gasLimit)
+	subCtx, commit := withBridgeCaller(q.Ctx, q.Caller).WithGasMeter(sdk.NewGasMeter(sdkGas)).CacheContext()
+
+	// make sure we charge the higher level context even on panic
+	defer func() {
+		q.Ctx.GasMeter().ConsumeGas(subCtx.GasMeter().GasConsumed(), "contract proto bridge message")
+	}()
+
+	res, events, err := q.bridge.Execute(subCtx, q.Caller, typeURL, msg)
+	if err != nil {
+		return nil, redactError(err)
+	}
+	commit()
+	q.Ctx.EventManager().EmitEvents(filterEvents(events))
+	return res, nil
+}
+
+// QueryGrpc sends the gRPC query of a wrapper through the proto bridge
+func (q QueryHandler) QueryGrpc(path string, request []byte, gasLimit uint64) ([]byte, error) {
+	if q.bridge == nil {
+		return nil, wasmvmtypes.UnsupportedRequest{Kind: "proto bridge queries are disabled"}
+	}
+	sdkGas := q.gasRegister.FromWasmVMGas(gasLimit)
+	// discard all changes/ events in subCtx by not committing the cached context
+	subCtx, _ := q.Ctx.WithGasMeter(sdk.NewGasMeter(sdkGas)).CacheContext()
+
+	// make sure we charge the higher level context even on panic
+	defer func() {
+		q.Ctx.GasMeter().ConsumeGas(subCtx.GasMeter().GasConsumed(), "contract proto bridge query")
+	}()
+
+	res, err := q.bridge.Query(subCtx, path, request)
+	if err != nil {
+		return nil, redactError(err)
+	}
+	return res, nil
+}
+
 type CustomQuerier func(ctx sdk.Context, request json.RawMessage) ([]byte, error)
 
 type QueryPlugins struct {
@@ -93,7 +218,7 @@ type contractMetaDataSource interface {
 type wasmQueryKeeper interface {
 	contractMetaDataSource
 	QueryRaw(ctx sdk.Context, contractAddress sdk.AccAddress, key []byte) []byte
-	QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte) ([]byte, error)
+	QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte, method string) ([]byte, error)
 	IsPinnedCode(ctx sdk.Context, codeID uint64) bool
 }
 
@@ -520,7 +645,11 @@ func WasmQuerier(k wasmQueryKeeper) func(ctx sdk.Context, request *wasmvmtypes.W
 			if err := msg.ValidateBasic(); err != nil {
 				return nil, sdkerrors.Wrap(err, "json msg")
 			}
-			return k.QuerySmart(ctx, addr, msg)
+			msg, method, err := types.UnwrapMethodEnvelope(msg)
+			if err != nil {
+				return nil, err
+			}
+			return k.QuerySmart(ctx, addr, msg, method)
 		case request.Raw != nil:
 			addr, err := sdk.AccAddressFromBech32(request.Raw.ContractAddr)
 			if err != nil {
@@ -460,6 +460,54 @@ func TestContractInfoWasmQuerier(t *testing.T) {
 	}
 }
 
+func TestSmartWasmQuerier(t *testing.T) {
+	myValidContractAddr := keeper.RandomBech32AccountAddress(t)
+	specs := map[string]struct {
+		msg       string
+		expMsg    types.RawContractMessage
+		expMethod string
+		expErr    bool
+	}{
+		"plain message": {
+			msg:    `{"foo":"bar"}`,
+			expMsg: types.RawContractMessage(`{"foo":"bar"}`),
+		},
+		"method envelope": {
+			msg:       `{"wasmos":{"method":"sayHello","msg":{"foo":"bar"}}}`,
+			expMsg:    types.RawContractMessage(`{"foo":"bar"}`),
+			expMethod: "sayHello",
+		},
+		"invalid method envelope": {
+			msg:    `{"wasmos":{"method":"","msg":{}}}`,
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var gotMsg types.RawContractMessage
+			var gotMethod string
+			mock := mockWasmQueryKeeper{
+				QuerySmartFn: func(ctx sdk.Context, contractAddr sdk.AccAddress, req types.RawContractMessage, method string) ([]byte, error) {
+					gotMsg, gotMethod = req, method
+					return []byte("result"), nil
+				},
+			}
+			q := keeper.WasmQuerier(mock)
+			gotBz, gotErr := q(sdk.Context{}, &wasmvmtypes.WasmQuery{
+				Smart: &wasmvmtypes.SmartQuery{ContractAddr: myValidContractAddr, Msg: []byte(spec.msg)},
+			})
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, []byte("result"), gotBz)
+			assert.Equal(t, spec.expMsg, gotMsg)
+			assert.Equal(t, spec.expMethod, gotMethod)
+		})
+	}
+}
+
 func TestQueryErrors(t *testing.T) {
 	specs := map[string]struct {
 		src    error
@@ -556,7 +604,7 @@ func TestAcceptListStargateQuerier(t *testing.T) {
 type mockWasmQueryKeeper struct {
 	GetContractInfoFn func(ctx sdk.Context, contractAddress sdk.AccAddress) *types.ContractInfo
 	QueryRawFn        func(ctx sdk.Context, contractAddress sdk.AccAddress, key []byte) []byte
-	QuerySmartFn      func(ctx sdk.Context, contractAddr sdk.AccAddress, req types.RawContractMessage) ([]byte, error)
+	QuerySmartFn      func(ctx sdk.Context, contractAddr sdk.AccAddress, req types.RawContractMessage, method string) ([]byte, error)
 	IsPinnedCodeFn    func(ctx sdk.Context, codeID uint64) bool
 }
 
@@ -574,11 +622,11 @@ func (m mockWasmQueryKeeper) QueryRaw(ctx sdk.Context, contractAddress sdk.AccAd
 	return m.QueryRawFn(ctx, contractAddress, key)
 }
 
-func (m mockWasmQueryKeeper) QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte) ([]byte, error) {
+func (m mockWasmQueryKeeper) QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte, method string) ([]byte, error) {
 	if m.QuerySmartFn == nil {
 		panic("not expected to be called")
 	}
-	return m.QuerySmartFn(ctx, contractAddr, req)
+	return m.QuerySmartFn(ctx, contractAddr, req, method)
 }
 
 func (m mockWasmQueryKeeper) IsPinnedCode(ctx sdk.Context, codeID uint64) bool {
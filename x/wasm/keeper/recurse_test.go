@@ -119,7 +119,7 @@ func TestGasCostOnQuery(t *testing.T) {
 			recurse := tc.msg
 			recurse.Contract = contractAddr
 			msg := buildRecurseQuery(t, recurse)
-			data, err := keeper.QuerySmart(ctx, contractAddr, msg)
+			data, err := keeper.QuerySmart(ctx, contractAddr, msg, "")
 			require.NoError(t, err)
 
 			// check the gas is what we expected
@@ -287,7 +287,7 @@ func TestLimitRecursiveQueryGas(t *testing.T) {
 			// if we expect out of gas, make sure this panics
 			if tc.expectOutOfGas {
 				require.Panics(t, func() {
-					_, err := keeper.QuerySmart(ctx, contractAddr, msg)
+					_, err := keeper.QuerySmart(ctx, contractAddr, msg, "")
 					t.Logf("Got error not panic: %#v", err)
 				})
 				assert.Equal(t, tc.expectQueriesFromContract, totalWasmQueryCounter)
@@ -295,7 +295,7 @@ func TestLimitRecursiveQueryGas(t *testing.T) {
 			}
 
 			// otherwise, we expect a successful call
-			_, err := keeper.QuerySmart(ctx, contractAddr, msg)
+			_, err := keeper.QuerySmart(ctx, contractAddr, msg, "")
 			if tc.expectError != "" {
 				require.ErrorContains(t, err, tc.expectError)
 			} else {
@@ -232,7 +232,7 @@ func TestMaskReflectCustomQuery(t *testing.T) {
 	}
 	ownerQueryBz, err := json.Marshal(ownerQuery)
 	require.NoError(t, err)
-	ownerRes, err := keeper.QuerySmart(ctx, contractAddr, ownerQueryBz)
+	ownerRes, err := keeper.QuerySmart(ctx, contractAddr, ownerQueryBz, "")
 	require.NoError(t, err)
 	var res testdata.OwnerResponse
 	err = json.Unmarshal(ownerRes, &res)
@@ -247,7 +247,7 @@ func TestMaskReflectCustomQuery(t *testing.T) {
 	}
 	customQueryBz, err := json.Marshal(customQuery)
 	require.NoError(t, err)
-	custom, err := keeper.QuerySmart(ctx, contractAddr, customQueryBz)
+	custom, err := keeper.QuerySmart(ctx, contractAddr, customQueryBz, "")
 	require.NoError(t, err)
 	var resp capitalizedResponse
 	err = json.Unmarshal(custom, &resp)
@@ -287,7 +287,7 @@ func TestReflectStargateQuery(t *testing.T) {
 		Chain: &testdata.ChainQuery{Request: &bankQuery},
 	})
 	require.NoError(t, err)
-	simpleRes, err := keeper.QuerySmart(ctx, contractAddr, simpleQueryBz)
+	simpleRes, err := keeper.QuerySmart(ctx, contractAddr, simpleQueryBz, "")
 	require.NoError(t, err)
 	var simpleChain testdata.ChainResponse
 	mustParse(t, simpleRes, &simpleChain)
@@ -336,7 +336,7 @@ func TestReflectTotalSupplyQuery(t *testing.T) {
 					},
 				},
 			})
-			simpleRes, err := keeper.QuerySmart(ctx, contractAddr, queryBz)
+			simpleRes, err := keeper.QuerySmart(ctx, contractAddr, queryBz, "")
 
 			// then
 			require.NoError(t, err)
@@ -385,7 +385,7 @@ func TestReflectInvalidStargateQuery(t *testing.T) {
 	require.NoError(t, err)
 
 	// make a query on the chain, should not be whitelisted
-	_, err = keeper.QuerySmart(ctx, contractAddr, protoQueryBz)
+	_, err = keeper.QuerySmart(ctx, contractAddr, protoQueryBz, "")
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "Unsupported query")
 
@@ -402,7 +402,7 @@ func TestReflectInvalidStargateQuery(t *testing.T) {
 	require.NoError(t, err)
 
 	// make a query on the chain, should be blacklisted
-	_, err = keeper.QuerySmart(ctx, contractAddr, protoQueryBz)
+	_, err = keeper.QuerySmart(ctx, contractAddr, protoQueryBz, "")
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "Unsupported query")
 
@@ -419,7 +419,7 @@ func TestReflectInvalidStargateQuery(t *testing.T) {
 	require.NoError(t, err)
 
 	// make a query on the chain, should be blacklisted
-	_, err = keeper.QuerySmart(ctx, contractAddr, protoQueryBz)
+	_, err = keeper.QuerySmart(ctx, contractAddr, protoQueryBz, "")
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "Unsupported query")
 }
@@ -449,7 +449,7 @@ func TestMaskReflectWasmQueries(t *testing.T) {
 
 	// for control, let's make some queries directly on the reflect
 	ownerQuery := buildReflectQuery(t, &testdata.ReflectQueryMsg{Owner: &struct{}{}})
-	res, err := keeper.QuerySmart(ctx, reflectAddr, ownerQuery)
+	res, err := keeper.QuerySmart(ctx, reflectAddr, ownerQuery, "")
 	require.NoError(t, err)
 	var ownerRes testdata.OwnerResponse
 	mustParse(t, res, &ownerRes)
@@ -470,7 +470,7 @@ func TestMaskReflectWasmQueries(t *testing.T) {
 		},
 	}}}}
 	reflectOwnerBin := buildReflectQuery(t, &reflectOwnerQuery)
-	res, err = keeper.QuerySmart(ctx, reflectAddr, reflectOwnerBin)
+	res, err = keeper.QuerySmart(ctx, reflectAddr, reflectOwnerBin, "")
 	require.NoError(t, err)
 	// first we pull out the data from chain response, before parsing the original response
 	var reflectRes testdata.ChainResponse
@@ -487,7 +487,7 @@ func TestMaskReflectWasmQueries(t *testing.T) {
 		},
 	}}}}
 	reflectStateBin := buildReflectQuery(t, &reflectStateQuery)
-	res, err = keeper.QuerySmart(ctx, reflectAddr, reflectStateBin)
+	res, err = keeper.QuerySmart(ctx, reflectAddr, reflectStateBin, "")
 	require.NoError(t, err)
 	// first we pull out the data from chain response, before parsing the original response
 	var reflectRawRes testdata.ChainResponse
@@ -530,7 +530,7 @@ func TestWasmRawQueryWithNil(t *testing.T) {
 		},
 	}}}}
 	reflectStateBin := buildReflectQuery(t, &reflectQuery)
-	res, err := keeper.QuerySmart(ctx, reflectAddr, reflectStateBin)
+	res, err := keeper.QuerySmart(ctx, reflectAddr, reflectStateBin, "")
 	require.NoError(t, err)
 
 	// first we pull out the data from chain response, before parsing the original response
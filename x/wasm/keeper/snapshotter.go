@@ -17,8 +17,13 @@ import (
 
 var _ snapshot.ExtensionSnapshotter = &WasmSnapshotter{}
 
-// SnapshotFormat format 1 is just gzipped wasm byte code for each item payload. No protobuf envelope, no metadata.
-const SnapshotFormat = 1
+const (
+	// SnapshotFormatV1 format 1 is just gzipped wasm byte code for each item payload. No protobuf envelope, no metadata.
+	SnapshotFormatV1 = 1
+	// SnapshotFormat format 2 is a protobuf encoded types.SnapshotCode for each item payload. It carries the code
+	// runtime next to the gzipped wasm byte code.
+	SnapshotFormat = 2
+)
 
 type WasmSnapshotter struct {
 	wasm *Keeper
@@ -42,7 +47,7 @@ func (ws *WasmSnapshotter) SnapshotFormat() uint32 {
 
 func (ws *WasmSnapshotter) SupportedFormats() []uint32 {
 	// If we support older formats, add them here and handle them in Restore
-	return []uint32{SnapshotFormat}
+	return []uint32{SnapshotFormat, SnapshotFormatV1}
 }
 
 func (ws *WasmSnapshotter) Snapshot(height uint64, protoWriter protoio.Writer) error {
@@ -77,7 +82,17 @@ func (ws *WasmSnapshotter) Snapshot(height uint64, protoWriter protoio.Writer) e
 			return true
 		}
 
-		err = snapshot.WriteExtensionItem(protoWriter, compressedWasm)
+		item := types.SnapshotCode{
+			Runtime:      info.Runtime,
+			WASMByteCode: compressedWasm,
+		}
+		bz, err := item.Marshal()
+		if err != nil {
+			rerr = err
+			return true
+		}
+
+		err = snapshot.WriteExtensionItem(protoWriter, bz)
 		if err != nil {
 			rerr = err
 			return true
@@ -92,8 +107,11 @@ func (ws *WasmSnapshotter) Snapshot(height uint64, protoWriter protoio.Writer) e
 func (ws *WasmSnapshotter) Restore(
 	height uint64, format uint32, protoReader protoio.Reader,
 ) (snapshot.SnapshotItem, error) {
-	if format == SnapshotFormat {
-		return ws.processAllItems(height, protoReader, restoreV1, finalizeV1)
+	switch format {
+	case SnapshotFormat:
+		return ws.processAllItems(height, protoReader, restoreV2, finalize)
+	case SnapshotFormatV1:
+		return ws.processAllItems(height, protoReader, restoreV1, finalize)
 	}
 	return snapshot.SnapshotItem{}, snapshot.ErrUnknownFormat
 }
@@ -107,16 +125,51 @@ func restoreV1(ctx sdk.Context, k *Keeper, compressedCode []byte) error {
 		return sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
 	}
 
-	// FIXME: check which codeIDs the checksum matches??
-	_, err = k.wasmVM.Create(wasmCode)
+	// format 1 carries no runtime, so it is detected from the exports. finalize checks that the
+	// code ended up in the engine of its code info.
+	_, err = k.engine(detectRuntime(wasmCode)).Create(wasmCode)
 	if err != nil {
 		return sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
 	}
 	return nil
 }
 
-func finalizeV1(ctx sdk.Context, k *Keeper) error {
-	// FIXME: ensure all codes have been uploaded?
+func restoreV2(ctx sdk.Context, k *Keeper, payload []byte) error {
+	var item types.SnapshotCode
+	if err := item.Unmarshal(payload); err != nil {
+		return sdkerrors.Wrap(types.ErrInvalid, err.Error())
+	}
+	if err := item.Runtime.ValidateBasic(); err != nil {
+		return err
+	}
+	if !ioutils.IsGzip(item.WASMByteCode) {
+		return types.ErrInvalid.Wrap("not a gzip")
+	}
+	wasmCode, err := ioutils.Uncompress(item.WASMByteCode, uint64(types.MaxWasmSize))
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
+	}
+
+	// manifests are part of the module state, which is restored with the state sync
+	if _, err := k.engine(item.Runtime).Create(wasmCode); err != nil {
+		return sdkerrors.Wrap(types.ErrCreateFailed, err.Error())
+	}
+	return nil
+}
+
+// finalize ensures the code of every code info was restored into its engine before the pinned codes are loaded
+func finalize(ctx sdk.Context, k *Keeper) error {
+	var rerr error
+	k.IterateCodeInfos(ctx, func(id uint64, info types.CodeInfo) bool {
+		if _, err := k.engine(info.Runtime).GetCode(info.CodeHash); err != nil {
+			rerr = sdkerrors.Wrapf(types.ErrNotFound, "code %d with checksum %X not restored", id, info.CodeHash)
+			return true
+		}
+		return false
+	})
+	if rerr != nil {
+		return rerr
+	}
 	return k.InitializePinnedCodes(ctx)
 }
 
@@ -26,6 +26,7 @@ import (
 func TestSnapshotter(t *testing.T) {
 	specs := map[string]struct {
 		wasmFiles []string
+		manifest  string
 	}{
 		"single contract": {
 			wasmFiles: []string{"./testdata/reflect.wasm"},
@@ -36,6 +37,16 @@ func TestSnapshotter(t *testing.T) {
 		"duplicate contracts": {
 			wasmFiles: []string{"./testdata/reflect.wasm", "./testdata/reflect.wasm"},
 		},
+		"single wrapper": {
+			wasmFiles: []string{"./testdata/hello_world.wasm"},
+		},
+		"wrapper package": {
+			wasmFiles: []string{"./testdata/hello_world.wasm"},
+			manifest:  "./testdata/hello_world.wrap.info",
+		},
+		"contracts and wrappers": {
+			wasmFiles: []string{"./testdata/reflect.wasm", "./testdata/hello_world.wasm", "./testdata/burner.wasm", "./testdata/hello_world.wasm"},
+		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
@@ -52,13 +63,22 @@ func TestSnapshotter(t *testing.T) {
 			contractKeeper := keeper.NewDefaultPermissionKeeper(&wasmKeeper)
 
 			srcCodeIDToChecksum := make(map[uint64][]byte, len(spec.wasmFiles))
+			srcCodeIDToRuntime := make(map[uint64]types.Runtime, len(spec.wasmFiles))
+			srcCodeIDToManifest := make(map[uint64][]byte, len(spec.wasmFiles))
 			for i, v := range spec.wasmFiles {
 				wasmCode, err := os.ReadFile(v)
 				require.NoError(t, err)
+				if spec.manifest != "" {
+					manifest, err := os.ReadFile(spec.manifest)
+					require.NoError(t, err)
+					wasmCode = keeper.WrapperPackage(t, wasmCode, manifest)
+				}
 				codeID, checksum, err := contractKeeper.Create(ctx, genesisAddr, wasmCode, nil)
 				require.NoError(t, err)
 				require.Equal(t, uint64(i+1), codeID)
 				srcCodeIDToChecksum[codeID] = checksum
+				srcCodeIDToRuntime[codeID] = wasmKeeper.GetCodeInfo(ctx, codeID).Runtime
+				srcCodeIDToManifest[codeID] = wasmKeeper.GetManifest(ctx, codeID)
 			}
 			// create snapshot
 			srcWasmApp.Commit()
@@ -89,7 +109,11 @@ func TestSnapshotter(t *testing.T) {
 			})
 
 			destCodeIDToChecksum := make(map[uint64][]byte, len(spec.wasmFiles))
+			destCodeIDToRuntime := make(map[uint64]types.Runtime, len(spec.wasmFiles))
+			destCodeIDToManifest := make(map[uint64][]byte, len(spec.wasmFiles))
 			wasmKeeper.IterateCodeInfos(ctx, func(id uint64, info types.CodeInfo) bool {
+				destCodeIDToRuntime[id] = info.Runtime
+				destCodeIDToManifest[id] = wasmKeeper.GetManifest(ctx, id)
 				bz, err := wasmKeeper.GetByteCode(ctx, id)
 				require.NoError(t, err)
 				hash := sha256.Sum256(bz)
@@ -98,6 +122,8 @@ func TestSnapshotter(t *testing.T) {
 				return false
 			})
 			assert.Equal(t, srcCodeIDToChecksum, destCodeIDToChecksum)
+			assert.Equal(t, srcCodeIDToRuntime, destCodeIDToRuntime)
+			assert.Equal(t, srcCodeIDToManifest, destCodeIDToManifest)
 		})
 	}
 }
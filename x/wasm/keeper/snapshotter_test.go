@@ -0,0 +1,51 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+
+	snapshot "github.com/cosmos/cosmos-sdk/snapshots/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	protoio "github.com/gogo/protobuf/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ConsiderItDone/wasmos/x/wasm/ioutils"
+	"github.com/ConsiderItDone/wasmos/x/wasm/types"
+)
+
+func TestSnapshotterRestoreV1LegacyWrapper(t *testing.T) {
+	srcCtx, srcKeepers := CreateTestInput(t, false, AvailableCapabilities)
+	creator := srcKeepers.Faucet.NewFundedRandomAccount(srcCtx, sdk.NewInt64Coin("denom", 100000))
+	codeID, _, err := srcKeepers.ContractKeeper.Create(srcCtx, creator, helloWorldWasm, nil)
+	require.NoError(t, err)
+	// code stored before the runtime was recorded
+	codeInfo := srcKeepers.WasmKeeper.GetCodeInfo(srcCtx, codeID)
+	codeInfo.Runtime = types.RuntimeUnspecified
+
+	// format 1 items are the gzipped wasm code only
+	compressed, err := ioutils.GzipIt(helloWorldWasm)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, snapshot.WriteExtensionItem(protoio.NewDelimitedWriter(&buf), compressed))
+
+	// restore into a node that has the code info from the state sync but not the code
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	keepers.WasmKeeper.storeCodeInfo(ctx, codeID, *codeInfo)
+	snapshotter := NewWasmSnapshotter(ctx.MultiStore(), keepers.WasmKeeper)
+	_, err = snapshotter.Restore(1, SnapshotFormatV1, protoio.NewDelimitedReader(&buf, math.MaxInt32))
+	require.NoError(t, err)
+
+	bz, err := keepers.WasmKeeper.GetByteCode(ctx, codeID)
+	require.NoError(t, err)
+	assert.Equal(t, helloWorldWasm, bz)
+
+	// and the legacy wrapper runs on the Polywrap VM
+	keepers.Faucet.Fund(ctx, creator, sdk.NewInt64Coin("denom", 100000))
+	initMsg := HelloWorldInitMsg{name: "Legacy"}.GetBytes(t)
+	_, data, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, nil, initMsg, "legacy wrapper", nil)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("Hello %s. CosmoWrap Initialized!", "Legacy"), string(data))
+}
@@ -462,7 +462,7 @@ func TestQueryStakingInfo(t *testing.T) {
 		BondedDenom: &struct{}{},
 	}}}}
 	reflectBondedBin := buildReflectQuery(t, &reflectBondedQuery)
-	res, err := keeper.QuerySmart(ctx, maskAddr, reflectBondedBin)
+	res, err := keeper.QuerySmart(ctx, maskAddr, reflectBondedBin, "")
 	require.NoError(t, err)
 	// first we pull out the data from chain response, before parsing the original response
 	var reflectRes testdata.ChainResponse
@@ -476,7 +476,7 @@ func TestQueryStakingInfo(t *testing.T) {
 		AllValidators: &wasmvmtypes.AllValidatorsQuery{},
 	}}}}
 	reflectAllValidatorsBin := buildReflectQuery(t, &reflectAllValidatorsQuery)
-	res, err = keeper.QuerySmart(ctx, maskAddr, reflectAllValidatorsBin)
+	res, err = keeper.QuerySmart(ctx, maskAddr, reflectAllValidatorsBin, "")
 	require.NoError(t, err)
 	// first we pull out the data from chain response, before parsing the original response
 	mustParse(t, res, &reflectRes)
@@ -497,7 +497,7 @@ func TestQueryStakingInfo(t *testing.T) {
 		},
 	}}}}
 	reflectValidatorBin := buildReflectQuery(t, &reflectValidatorQuery)
-	res, err = keeper.QuerySmart(ctx, maskAddr, reflectValidatorBin)
+	res, err = keeper.QuerySmart(ctx, maskAddr, reflectValidatorBin, "")
 	require.NoError(t, err)
 	// first we pull out the data from chain response, before parsing the original response
 	mustParse(t, res, &reflectRes)
@@ -519,7 +519,7 @@ func TestQueryStakingInfo(t *testing.T) {
 		},
 	}}}}
 	reflectNoValidatorBin := buildReflectQuery(t, &reflectNoValidatorQuery)
-	res, err = keeper.QuerySmart(ctx, maskAddr, reflectNoValidatorBin)
+	res, err = keeper.QuerySmart(ctx, maskAddr, reflectNoValidatorBin, "")
 	require.NoError(t, err)
 	// first we pull out the data from chain response, before parsing the original response
 	mustParse(t, res, &reflectRes)
@@ -534,7 +534,7 @@ func TestQueryStakingInfo(t *testing.T) {
 		},
 	}}}}
 	reflectAllDelegationsBin := buildReflectQuery(t, &reflectAllDelegationsQuery)
-	res, err = keeper.QuerySmart(ctx, maskAddr, reflectAllDelegationsBin)
+	res, err = keeper.QuerySmart(ctx, maskAddr, reflectAllDelegationsBin, "")
 	require.NoError(t, err)
 	// first we pull out the data from chain response, before parsing the original response
 	mustParse(t, res, &reflectRes)
@@ -558,7 +558,7 @@ func TestQueryStakingInfo(t *testing.T) {
 		},
 	}}}}
 	reflectDelegationBin := buildReflectQuery(t, &reflectDelegationQuery)
-	res, err = keeper.QuerySmart(ctx, maskAddr, reflectDelegationBin)
+	res, err = keeper.QuerySmart(ctx, maskAddr, reflectDelegationBin, "")
 	require.NoError(t, err)
 	// first we pull out the data from chain response, before parsing the original response
 	mustParse(t, res, &reflectRes)
@@ -710,7 +710,7 @@ func assertBalance(t *testing.T, ctx sdk.Context, keeper Keeper, contract sdk.Ac
 	}
 	queryBz, err := json.Marshal(query)
 	require.NoError(t, err)
-	res, err := keeper.QuerySmart(ctx, contract, queryBz)
+	res, err := keeper.QuerySmart(ctx, contract, queryBz, "")
 	require.NoError(t, err)
 	var balance BalanceResponse
 	err = json.Unmarshal(res, &balance)
@@ -726,7 +726,7 @@ func assertClaims(t *testing.T, ctx sdk.Context, keeper Keeper, contract sdk.Acc
 	}
 	queryBz, err := json.Marshal(query)
 	require.NoError(t, err)
-	res, err := keeper.QuerySmart(ctx, contract, queryBz)
+	res, err := keeper.QuerySmart(ctx, contract, queryBz, "")
 	require.NoError(t, err)
 	var claims ClaimsResponse
 	err = json.Unmarshal(res, &claims)
@@ -738,7 +738,7 @@ func assertSupply(t *testing.T, ctx sdk.Context, keeper Keeper, contract sdk.Acc
 	query := StakingQueryMsg{Investment: &struct{}{}}
 	queryBz, err := json.Marshal(query)
 	require.NoError(t, err)
-	res, err := keeper.QuerySmart(ctx, contract, queryBz)
+	res, err := keeper.QuerySmart(ctx, contract, queryBz, "")
 	require.NoError(t, err)
 	var invest InvestmentResponse
 	err = json.Unmarshal(res, &invest)
@@ -83,7 +83,7 @@ func TestDispatchSubMsgSuccessCase(t *testing.T) {
 	}
 	queryBz, err := json.Marshal(query)
 	require.NoError(t, err)
-	queryRes, err := keeper.QuerySmart(ctx, contractAddr, queryBz)
+	queryRes, err := keeper.QuerySmart(ctx, contractAddr, queryBz, "")
 	require.NoError(t, err)
 
 	var res wasmvmtypes.Reply
@@ -325,7 +325,7 @@ func TestDispatchSubMsgErrorHandling(t *testing.T) {
 				}
 				queryBz, err := json.Marshal(query)
 				require.NoError(t, err)
-				queryRes, err := keeper.QuerySmart(ctx, contractAddr, queryBz)
+				queryRes, err := keeper.QuerySmart(ctx, contractAddr, queryBz, "")
 				require.NoError(t, err)
 				var res wasmvmtypes.Reply
 				err = json.Unmarshal(queryRes, &res)
@@ -410,7 +410,7 @@ func TestDispatchSubMsgEncodeToNoSdkMsg(t *testing.T) {
 	}
 	queryBz, err := json.Marshal(query)
 	require.NoError(t, err)
-	queryRes, err := keeper.QuerySmart(ctx, contractAddr, queryBz)
+	queryRes, err := keeper.QuerySmart(ctx, contractAddr, queryBz, "")
 	require.NoError(t, err)
 
 	var res wasmvmtypes.Reply
@@ -535,7 +535,7 @@ func TestDispatchSubMsgConditionalReplyOn(t *testing.T) {
 			}
 			queryBz, err := json.Marshal(query)
 			require.NoError(t, err)
-			queryRes, err := keeper.QuerySmart(ctx, contractAddr, queryBz)
+			queryRes, err := keeper.QuerySmart(ctx, contractAddr, queryBz, "")
 			if tc.writeResult {
 				// we got some data for this call
 				require.NoError(t, err)
@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"archive/tar"
 	"bytes"
 	"encoding/binary"
 	"encoding/json"
@@ -9,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bytecodealliance/wasmtime-go"
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/std"
@@ -467,7 +469,7 @@ func handleStoreCode(ctx sdk.Context, k types.ContractOpsKeeper, msg *types.MsgS
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "sender")
 	}
-	codeID, _, err := k.Create(ctx, senderAddr, msg.WASMByteCode, msg.InstantiatePermission)
+	codeID, _, err := k.CreateWithRuntime(ctx, senderAddr, msg.WASMByteCode, msg.InstantiatePermission, msg.Runtime)
 	if err != nil {
 		return nil, err
 	}
@@ -551,6 +553,58 @@ func StoreHelloWorldExampleContract(t testing.TB, ctx sdk.Context, keepers TestK
 	return StoreExampleContract(t, ctx, keepers, "./testdata/hello_world.wasm")
 }
 
+// WrapperPackage tars the wrapper module and its wrap.info manifest as written by the polywrap build
+func WrapperPackage(t testing.TB, code, manifest []byte) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, f := range []struct {
+		name    string
+		content []byte
+	}{{"wrap.wasm", code}, {"wrap.info", manifest}} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(f.content))}))
+		_, err := tw.Write(f.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+func StoreMigrateExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) ExampleContract {
+	return StoreWatExampleContract(t, ctx, keepers, "./testdata/migrate.wat")
+}
+
+func StoreRecorderExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) ExampleContract {
+	return StoreWatExampleContract(t, ctx, keepers, "./testdata/recorder.wat")
+}
+
+func StoreResponseExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) ExampleContract {
+	return StoreWatExampleContract(t, ctx, keepers, "./testdata/response.wat")
+}
+
+func StoreEnvExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) ExampleContract {
+	return StoreWatExampleContract(t, ctx, keepers, "./testdata/env.wat")
+}
+
+func StoreForwarderExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) ExampleContract {
+	return StoreWatExampleContract(t, ctx, keepers, "./testdata/forwarder.wat")
+}
+
+func StoreEmitterExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) ExampleContract {
+	return StoreWatExampleContract(t, ctx, keepers, "./testdata/emitter.wat")
+}
+
+func StoreAborterExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) ExampleContract {
+	return StoreWatExampleContract(t, ctx, keepers, "./testdata/aborter.wat")
+}
+
+func StoreCatcherExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) ExampleContract {
+	return StoreWatExampleContract(t, ctx, keepers, "./testdata/catcher.wat")
+}
+
+func StoreBridgeExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) ExampleContract {
+	return StoreWatExampleContract(t, ctx, keepers, "./testdata/bridge.wat")
+}
+
 func StoreBurnerExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers) ExampleContract {
 	return StoreExampleContract(t, ctx, keepers, "./testdata/burner.wasm")
 }
@@ -577,6 +631,23 @@ func StoreExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers, wa
 	return ExampleContract{anyAmount, creator, creatorAddr, codeID, hash}
 }
 
+// StoreWatExampleContract compiles the given wasm text format wrapper and stores it
+func StoreWatExampleContract(t testing.TB, ctx sdk.Context, keepers TestKeepers, watFile string) ExampleContract {
+	anyAmount := sdk.NewCoins(sdk.NewInt64Coin("denom", 1000))
+	creator, _, creatorAddr := keyPubAddr()
+	fundAccounts(t, ctx, keepers.AccountKeeper, keepers.BankKeeper, creatorAddr, anyAmount)
+
+	wat, err := os.ReadFile(watFile)
+	require.NoError(t, err)
+	wasmCode, err := wasmtime.Wat2Wasm(string(wat))
+	require.NoError(t, err)
+
+	codeID, _, err := keepers.ContractKeeper.Create(ctx, creatorAddr, wasmCode, nil)
+	require.NoError(t, err)
+	hash := keepers.WasmKeeper.GetCodeInfo(ctx, codeID).CodeHash
+	return ExampleContract{anyAmount, creator, creatorAddr, codeID, hash}
+}
+
 var wasmIdent = []byte("\x00\x61\x73\x6D")
 
 type ExampleContractInstance struct {
@@ -0,0 +1,35 @@
+;; Minimal wrapper that stores "1" under the key "written" through the cosmos plugin and then
+;; fails with the invocation error "aborted" for every method but "init".
+(module
+  (import "wrap" "__wrap_invoke_args" (func $invoke_args (param i32 i32)))
+  (import "wrap" "__wrap_invoke_result" (func $invoke_result (param i32 i32)))
+  (import "wrap" "__wrap_invoke_error" (func $invoke_error (param i32 i32)))
+  (import "wrap" "__wrap_subinvoke" (func $subinvoke (param i32 i32 i32 i32 i32 i32) (result i32)))
+  (import "env" "memory" (memory 1))
+
+  (data (i32.const 32) "wrap://cosmos/cosmos.eth")
+  (data (i32.const 64) "DbSet")
+  (data (i32.const 80) "init")
+  (data (i32.const 96) "\81\a6result\a4init")
+  (data (i32.const 112) "\82\a3key\c4\07written\a5value\c6\00\00\00\011")
+  (data (i32.const 144) "aborted")
+
+  (global $method i32 (i32.const 1024))
+  (global $args i32 (i32.const 4096))
+
+  (func (export "_wrap_invoke") (param $method_len i32) (param $args_len i32) (param $env_len i32) (result i32)
+    (call $invoke_args (global.get $method) (global.get $args))
+    (if (i32.and
+          (i32.eq (local.get $method_len) (i32.const 4))
+          (i32.eq (i32.load (global.get $method)) (i32.load (i32.const 80))))
+      (then
+        (call $invoke_result (i32.const 96) (i32.const 13))
+        (return (i32.const 1))))
+
+    (if (i32.eqz (call $subinvoke
+          (i32.const 32) (i32.const 24)
+          (i32.const 64) (i32.const 5)
+          (i32.const 112) (i32.const 26)))
+      (then (unreachable)))
+    (call $invoke_error (i32.const 144) (i32.const 7))
+    (i32.const 0)))
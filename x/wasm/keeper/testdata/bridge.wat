@@ -0,0 +1,79 @@
+;; Minimal wrapper that executes a MsgExecuteContract through the proto bridge of the cosmos
+;; plugin for the "call" method with {"msg": String} args, the protobuf JSON of the message.
+;; It returns the msgpack string "ok", a bridge error is returned as invocation error. Every
+;; other method returns the msgpack string "reentered".
+(module
+  (import "wrap" "__wrap_invoke_args" (func $invoke_args (param i32 i32)))
+  (import "wrap" "__wrap_invoke_result" (func $invoke_result (param i32 i32)))
+  (import "wrap" "__wrap_invoke_error" (func $invoke_error (param i32 i32)))
+  (import "wrap" "__wrap_subinvoke" (func $subinvoke (param i32 i32 i32 i32 i32 i32) (result i32)))
+  (import "wrap" "__wrap_subinvoke_error_len" (func $subinvoke_error_len (result i32)))
+  (import "wrap" "__wrap_subinvoke_error" (func $subinvoke_error (param i32)))
+  (import "env" "memory" (memory 1))
+
+  (data (i32.const 32) "wrap://cosmos/cosmos.eth")
+  (data (i32.const 64) "InvokeMsg")
+  (data (i32.const 80) "call")
+  (data (i32.const 96) "\82\a7typeUrl\d9\24/cosmwasm.wasm.v1.MsgExecuteContract\a5value\c6")
+  (data (i32.const 160) "\a9reentered")
+  (data (i32.const 176) "\a2ok")
+
+  (global $method i32 (i32.const 1024))
+  (global $args i32 (i32.const 4096))
+  (global $invoke i32 (i32.const 16384))
+  (global $result i32 (i32.const 32768))
+
+  (func $copy (param $dst i32) (param $src i32) (param $len i32) (result i32)
+    (block $done
+      (loop $next
+        (br_if $done (i32.eqz (local.get $len)))
+        (i32.store8 (local.get $dst) (i32.load8_u (local.get $src)))
+        (local.set $dst (i32.add (local.get $dst) (i32.const 1)))
+        (local.set $src (i32.add (local.get $src) (i32.const 1)))
+        (local.set $len (i32.sub (local.get $len) (i32.const 1)))
+        (br $next)))
+    (local.get $dst))
+
+  (func (export "_wrap_invoke") (param $method_len i32) (param $args_len i32) (param $env_len i32) (result i32)
+    (local $msg i32)
+    (local $msg_len i32)
+    (local $ptr i32)
+    (local $len i32)
+    (call $invoke_args (global.get $method) (global.get $args))
+    (if (i32.eqz (i32.and
+          (i32.eq (local.get $method_len) (i32.const 4))
+          (i32.eq (i32.load (global.get $method)) (i32.load (i32.const 80)))))
+      (then
+        (call $invoke_result (i32.const 160) (i32.const 10))
+        (return (i32.const 1))))
+
+    ;; {"msg": str8 or str16}
+    (if (i32.eq (i32.load8_u offset=5 (global.get $args)) (i32.const 0xd9))
+      (then
+        (local.set $msg_len (i32.load8_u offset=6 (global.get $args)))
+        (local.set $msg (i32.add (global.get $args) (i32.const 7))))
+      (else
+        (local.set $msg_len (i32.or
+          (i32.shl (i32.load8_u offset=6 (global.get $args)) (i32.const 8))
+          (i32.load8_u offset=7 (global.get $args))))
+        (local.set $msg (i32.add (global.get $args) (i32.const 8)))))
+
+    ;; {"typeUrl": "/cosmwasm.wasm.v1.MsgExecuteContract", "value": bin32(msg)}
+    (local.set $ptr (call $copy (global.get $invoke) (i32.const 96) (i32.const 54)))
+    (i32.store8 (local.get $ptr) (i32.shr_u (local.get $msg_len) (i32.const 24)))
+    (i32.store8 offset=1 (local.get $ptr) (i32.shr_u (local.get $msg_len) (i32.const 16)))
+    (i32.store8 offset=2 (local.get $ptr) (i32.shr_u (local.get $msg_len) (i32.const 8)))
+    (i32.store8 offset=3 (local.get $ptr) (local.get $msg_len))
+    (local.set $ptr (call $copy (i32.add (local.get $ptr) (i32.const 4)) (local.get $msg) (local.get $msg_len)))
+
+    (if (i32.eqz (call $subinvoke
+          (i32.const 32) (i32.const 24)
+          (i32.const 64) (i32.const 9)
+          (global.get $invoke) (i32.sub (local.get $ptr) (global.get $invoke))))
+      (then
+        (local.set $len (call $subinvoke_error_len))
+        (call $subinvoke_error (global.get $result))
+        (call $invoke_error (global.get $result) (local.get $len))
+        (return (i32.const 0))))
+    (call $invoke_result (i32.const 176) (i32.const 3))
+    (i32.const 1)))
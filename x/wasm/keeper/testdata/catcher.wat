@@ -0,0 +1,43 @@
+;; Minimal wrapper that subinvokes the method at the URI of its {"uri": String} args for every
+;; method but "init", like the forwarder. A subinvocation error is ignored and the msgpack
+;; string "ok" is returned either way.
+(module
+  (import "wrap" "__wrap_invoke_args" (func $invoke_args (param i32 i32)))
+  (import "wrap" "__wrap_invoke_result" (func $invoke_result (param i32 i32)))
+  (import "wrap" "__wrap_subinvoke" (func $subinvoke (param i32 i32 i32 i32 i32 i32) (result i32)))
+  (import "env" "memory" (memory 1))
+
+  (data (i32.const 32) "init")
+  (data (i32.const 48) "\81\a6result\a4init")
+  (data (i32.const 64) "\80")
+  (data (i32.const 80) "\a2ok")
+
+  (global $method i32 (i32.const 1024))
+  (global $args i32 (i32.const 4096))
+
+  (func (export "_wrap_invoke") (param $method_len i32) (param $args_len i32) (param $env_len i32) (result i32)
+    (local $uri i32)
+    (local $uri_len i32)
+    (call $invoke_args (global.get $method) (global.get $args))
+    (if (i32.and
+          (i32.eq (local.get $method_len) (i32.const 4))
+          (i32.eq (i32.load (global.get $method)) (i32.load (i32.const 32))))
+      (then
+        (call $invoke_result (i32.const 48) (i32.const 13))
+        (return (i32.const 1))))
+
+    ;; {"uri": fixstr or str8}
+    (if (i32.eq (i32.load8_u offset=5 (global.get $args)) (i32.const 0xd9))
+      (then
+        (local.set $uri_len (i32.load8_u offset=6 (global.get $args)))
+        (local.set $uri (i32.add (global.get $args) (i32.const 7))))
+      (else
+        (local.set $uri_len (i32.and (i32.load8_u offset=5 (global.get $args)) (i32.const 0x1f)))
+        (local.set $uri (i32.add (global.get $args) (i32.const 6)))))
+
+    (drop (call $subinvoke
+      (local.get $uri) (local.get $uri_len)
+      (global.get $method) (local.get $method_len)
+      (i32.const 64) (i32.const 1)))
+    (call $invoke_result (i32.const 80) (i32.const 3))
+    (i32.const 1)))
@@ -0,0 +1,28 @@
+;; Minimal wrapper that emits through the cosmos plugin for every method: the custom event
+;; "transfer" with amount=10 and the attribute action=emit. It returns the msgpack string "ok",
+;; a failed emission traps.
+(module
+  (import "wrap" "__wrap_invoke_result" (func $invoke_result (param i32 i32)))
+  (import "wrap" "__wrap_subinvoke" (func $subinvoke (param i32 i32 i32 i32 i32 i32) (result i32)))
+  (import "env" "memory" (memory 1))
+
+  (data (i32.const 32) "wrap://cosmos/cosmos.eth")
+  (data (i32.const 64) "EmitEvent")
+  (data (i32.const 80) "AddAttribute")
+  (data (i32.const 96) "\82\a4type\a8transfer\aaattributes\91\82\a3key\a6amount\a5value\a210")
+  (data (i32.const 160) "\82\a3key\a6action\a5value\a4emit")
+  (data (i32.const 192) "\a2ok")
+
+  (func (export "_wrap_invoke") (param $method_len i32) (param $args_len i32) (param $env_len i32) (result i32)
+    (if (i32.eqz (call $subinvoke
+          (i32.const 32) (i32.const 24)
+          (i32.const 64) (i32.const 9)
+          (i32.const 96) (i32.const 48)))
+      (then (unreachable)))
+    (if (i32.eqz (call $subinvoke
+          (i32.const 32) (i32.const 24)
+          (i32.const 80) (i32.const 12)
+          (i32.const 160) (i32.const 23)))
+      (then (unreachable)))
+    (call $invoke_result (i32.const 192) (i32.const 3))
+    (i32.const 1)))
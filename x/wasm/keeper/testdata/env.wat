@@ -0,0 +1,36 @@
+;; Minimal wrapper returning the msgpack encoded invocation env as response data for every method.
+(module
+  (import "wrap" "__wrap_load_env" (func $load_env (param i32)))
+  (import "wrap" "__wrap_invoke_result" (func $invoke_result (param i32 i32)))
+  (import "env" "memory" (memory 1))
+
+  ;; {"messages": [], "data": bin32(env), ...
+  (data (i32.const 16) "\84\a8messages\90\a4data\c6")
+  ;; ... "attributes": [], "events": []}
+  (data (i32.const 48) "\aaattributes\90\a6events\90")
+
+  (global $result i32 (i32.const 1024))
+
+  (func $copy (param $dst i32) (param $src i32) (param $len i32) (result i32)
+    (block $done
+      (loop $next
+        (br_if $done (i32.eqz (local.get $len)))
+        (i32.store8 (local.get $dst) (i32.load8_u (local.get $src)))
+        (local.set $dst (i32.add (local.get $dst) (i32.const 1)))
+        (local.set $src (i32.add (local.get $src) (i32.const 1)))
+        (local.set $len (i32.sub (local.get $len) (i32.const 1)))
+        (br $next)))
+    (local.get $dst))
+
+  (func (export "_wrap_invoke") (param $method_len i32) (param $args_len i32) (param $env_len i32) (result i32)
+    (local $ptr i32)
+    (local.set $ptr (call $copy (global.get $result) (i32.const 16) (i32.const 17)))
+    (i32.store8 (local.get $ptr) (i32.shr_u (local.get $env_len) (i32.const 24)))
+    (i32.store8 offset=1 (local.get $ptr) (i32.shr_u (local.get $env_len) (i32.const 16)))
+    (i32.store8 offset=2 (local.get $ptr) (i32.shr_u (local.get $env_len) (i32.const 8)))
+    (i32.store8 offset=3 (local.get $ptr) (local.get $env_len))
+    (local.set $ptr (i32.add (local.get $ptr) (i32.const 4)))
+    (call $load_env (local.get $ptr))
+    (local.set $ptr (call $copy (i32.add (local.get $ptr) (local.get $env_len)) (i32.const 48) (i32.const 20)))
+    (call $invoke_result (global.get $result) (i32.sub (local.get $ptr) (global.get $result)))
+    (i32.const 1)))
@@ -0,0 +1,56 @@
+;; Minimal wrapper that forwards every method but "init" to the wrapper at the URI of its
+;; {"uri": String} args. The method is subinvoked with empty args and the raw result is
+;; returned, a subinvocation error is returned as invocation error.
+(module
+  (import "wrap" "__wrap_invoke_args" (func $invoke_args (param i32 i32)))
+  (import "wrap" "__wrap_invoke_result" (func $invoke_result (param i32 i32)))
+  (import "wrap" "__wrap_invoke_error" (func $invoke_error (param i32 i32)))
+  (import "wrap" "__wrap_subinvoke" (func $subinvoke (param i32 i32 i32 i32 i32 i32) (result i32)))
+  (import "wrap" "__wrap_subinvoke_result_len" (func $subinvoke_result_len (result i32)))
+  (import "wrap" "__wrap_subinvoke_result" (func $subinvoke_result (param i32)))
+  (import "wrap" "__wrap_subinvoke_error_len" (func $subinvoke_error_len (result i32)))
+  (import "wrap" "__wrap_subinvoke_error" (func $subinvoke_error (param i32)))
+  (import "env" "memory" (memory 1))
+
+  (data (i32.const 32) "init")
+  (data (i32.const 48) "\81\a6result\a4init")
+  (data (i32.const 64) "\80")
+
+  (global $method i32 (i32.const 1024))
+  (global $args i32 (i32.const 4096))
+  (global $result i32 (i32.const 32768))
+
+  (func (export "_wrap_invoke") (param $method_len i32) (param $args_len i32) (param $env_len i32) (result i32)
+    (local $uri i32)
+    (local $uri_len i32)
+    (local $len i32)
+    (call $invoke_args (global.get $method) (global.get $args))
+    (if (i32.and
+          (i32.eq (local.get $method_len) (i32.const 4))
+          (i32.eq (i32.load (global.get $method)) (i32.load (i32.const 32))))
+      (then
+        (call $invoke_result (i32.const 48) (i32.const 13))
+        (return (i32.const 1))))
+
+    ;; {"uri": fixstr or str8}
+    (if (i32.eq (i32.load8_u offset=5 (global.get $args)) (i32.const 0xd9))
+      (then
+        (local.set $uri_len (i32.load8_u offset=6 (global.get $args)))
+        (local.set $uri (i32.add (global.get $args) (i32.const 7))))
+      (else
+        (local.set $uri_len (i32.and (i32.load8_u offset=5 (global.get $args)) (i32.const 0x1f)))
+        (local.set $uri (i32.add (global.get $args) (i32.const 6)))))
+
+    (if (i32.eqz (call $subinvoke
+          (local.get $uri) (local.get $uri_len)
+          (global.get $method) (local.get $method_len)
+          (i32.const 64) (i32.const 1)))
+      (then
+        (local.set $len (call $subinvoke_error_len))
+        (call $subinvoke_error (global.get $result))
+        (call $invoke_error (global.get $result) (local.get $len))
+        (return (i32.const 0))))
+    (local.set $len (call $subinvoke_result_len))
+    (call $subinvoke_result (global.get $result))
+    (call $invoke_result (global.get $result) (local.get $len))
+    (i32.const 1)))
@@ -0,0 +1,41 @@
+;; Minimal wrapper exposing a "migrate" method that stores name="migrated"
+;; through the cosmos plugin and returns the msgpack string "migrated".
+(module
+  (import "wrap" "__wrap_invoke_args" (func $invoke_args (param i32 i32)))
+  (import "wrap" "__wrap_invoke_result" (func $invoke_result (param i32 i32)))
+  (import "wrap" "__wrap_invoke_error" (func $invoke_error (param i32 i32)))
+  (import "wrap" "__wrap_subinvoke" (func $subinvoke (param i32 i32 i32 i32 i32 i32) (result i32)))
+  (import "env" "memory" (memory 1))
+
+  (data (i32.const 16) "migrate")
+  (data (i32.const 32) "wrap://cosmos/cosmos.eth")
+  (data (i32.const 64) "DbSet")
+  ;; {"key": bin("name"), "value": bin("migrated")}
+  (data (i32.const 80) "\82\a3key\c4\04name\a5value\c4\08migrated")
+  ;; "migrated"
+  (data (i32.const 160) "\a8migrated")
+  (data (i32.const 200) "unknown method")
+
+  (func $equal (param $a i32) (param $b i32) (param $len i32) (result i32)
+    (block $done
+      (loop $next
+        (br_if $done (i32.eqz (local.get $len)))
+        (if (i32.ne (i32.load8_u (local.get $a)) (i32.load8_u (local.get $b)))
+          (then (return (i32.const 0))))
+        (local.set $a (i32.add (local.get $a) (i32.const 1)))
+        (local.set $b (i32.add (local.get $b) (i32.const 1)))
+        (local.set $len (i32.sub (local.get $len) (i32.const 1)))
+        (br $next)))
+    (i32.const 1))
+
+  (func (export "_wrap_invoke") (param $method_len i32) (param $args_len i32) (param $env_len i32) (result i32)
+    (call $invoke_args (i32.const 1024) (i32.const 2048))
+    (if (i32.and
+          (i32.eq (local.get $method_len) (i32.const 7))
+          (call $equal (i32.const 1024) (i32.const 16) (i32.const 7)))
+      (then
+        (drop (call $subinvoke (i32.const 32) (i32.const 24) (i32.const 64) (i32.const 5) (i32.const 80) (i32.const 27)))
+        (call $invoke_result (i32.const 160) (i32.const 9))
+        (return (i32.const 1))))
+    (call $invoke_error (i32.const 200) (i32.const 14))
+    (i32.const 0)))
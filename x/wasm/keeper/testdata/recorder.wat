@@ -0,0 +1,63 @@
+;; Minimal wrapper that accepts any method. It stores the msgpack encoded invocation
+;; args under the method name through the cosmos plugin and returns the method name
+;; as msgpack string, or as {"result": method} for "init".
+(module
+  (import "wrap" "__wrap_invoke_args" (func $invoke_args (param i32 i32)))
+  (import "wrap" "__wrap_invoke_result" (func $invoke_result (param i32 i32)))
+  (import "wrap" "__wrap_subinvoke" (func $subinvoke (param i32 i32 i32 i32 i32 i32) (result i32)))
+  (import "env" "memory" (memory 1))
+
+  (data (i32.const 32) "wrap://cosmos/cosmos.eth")
+  (data (i32.const 64) "DbSet")
+  (data (i32.const 80) "\82\a3key\c4")
+  (data (i32.const 96) "\a5value\c6")
+  (data (i32.const 112) "init")
+  (data (i32.const 128) "\81\a6result")
+
+  (global $method i32 (i32.const 1024))
+  (global $args i32 (i32.const 4096))
+  (global $dbset i32 (i32.const 16384))
+  (global $result i32 (i32.const 32768))
+
+  (func $copy (param $dst i32) (param $src i32) (param $len i32) (result i32)
+    (block $done
+      (loop $next
+        (br_if $done (i32.eqz (local.get $len)))
+        (i32.store8 (local.get $dst) (i32.load8_u (local.get $src)))
+        (local.set $dst (i32.add (local.get $dst) (i32.const 1)))
+        (local.set $src (i32.add (local.get $src) (i32.const 1)))
+        (local.set $len (i32.sub (local.get $len) (i32.const 1)))
+        (br $next)))
+    (local.get $dst))
+
+  (func (export "_wrap_invoke") (param $method_len i32) (param $args_len i32) (param $env_len i32) (result i32)
+    (local $ptr i32)
+    (call $invoke_args (global.get $method) (global.get $args))
+
+    ;; {"key": bin8(method), "value": bin32(args)}
+    (local.set $ptr (call $copy (global.get $dbset) (i32.const 80) (i32.const 6)))
+    (i32.store8 (local.get $ptr) (local.get $method_len))
+    (local.set $ptr (call $copy (i32.add (local.get $ptr) (i32.const 1)) (global.get $method) (local.get $method_len)))
+    (local.set $ptr (call $copy (local.get $ptr) (i32.const 96) (i32.const 7)))
+    (i32.store8 (local.get $ptr) (i32.shr_u (local.get $args_len) (i32.const 24)))
+    (i32.store8 offset=1 (local.get $ptr) (i32.shr_u (local.get $args_len) (i32.const 16)))
+    (i32.store8 offset=2 (local.get $ptr) (i32.shr_u (local.get $args_len) (i32.const 8)))
+    (i32.store8 offset=3 (local.get $ptr) (local.get $args_len))
+    (local.set $ptr (call $copy (i32.add (local.get $ptr) (i32.const 4)) (global.get $args) (local.get $args_len)))
+    (if (i32.eqz (call $subinvoke
+          (i32.const 32) (i32.const 24)
+          (i32.const 64) (i32.const 5)
+          (global.get $dbset) (i32.sub (local.get $ptr) (global.get $dbset))))
+      (then (unreachable)))
+
+    (local.set $ptr (global.get $result))
+    (if (i32.and
+          (i32.eq (local.get $method_len) (i32.const 4))
+          (i32.eq (i32.load (global.get $method)) (i32.load (i32.const 112))))
+      (then (local.set $ptr (call $copy (local.get $ptr) (i32.const 128) (i32.const 8)))))
+    ;; str8(method)
+    (i32.store8 (local.get $ptr) (i32.const 0xd9))
+    (i32.store8 offset=1 (local.get $ptr) (local.get $method_len))
+    (local.set $ptr (call $copy (i32.add (local.get $ptr) (i32.const 2)) (global.get $method) (local.get $method_len)))
+    (call $invoke_result (global.get $result) (i32.sub (local.get $ptr) (global.get $result)))
+    (i32.const 1)))
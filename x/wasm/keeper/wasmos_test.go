@@ -2,13 +2,23 @@ package keeper
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
+	"github.com/ConsiderItDone/wasmos/x/wasm/ioutils"
+	"github.com/ConsiderItDone/wasmos/x/wasm/polywrapvm"
 	"github.com/ConsiderItDone/wasmos/x/wasm/types"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/bytecodealliance/wasmtime-go"
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/address"
+	"github.com/polywrap/go-client/msgpack"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -192,3 +202,839 @@ func TestWasmosSayHello(t *testing.T) {
 	assert.Equal(t, "Hello from CosmoWrap, Joe", string(res))
 	t.Logf("Response: %s", res)
 }
+
+func TestWasmosExecuteOutOfGas(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	example := StoreHelloWorldExampleContract(t, ctx, keepers)
+	initMsgBz := HelloWorldInitMsg{name: "Joe"}.GetBytes(t)
+	addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, initMsgBz, "demo contract", nil)
+	require.NoError(t, err)
+
+	// leave no gas for the wrapper execution after the setup costs
+	setupCosts := keepers.WasmKeeper.gasRegister.InstantiateContractCosts(false, 0)
+	ctx = ctx.WithGasMeter(sdk.NewGasMeter(setupCosts))
+
+	// ensure we get an out of gas panic
+	defer func() {
+		r := recover()
+		require.NotNil(t, r)
+		_, ok := r.(sdk.ErrorOutOfGas)
+		require.True(t, ok, "%v", r)
+	}()
+
+	// this should throw out of gas exception (panic)
+	_, _ = keepers.ContractKeeper.Execute(ctx, addr, creator, nil, "sayHello", nil)
+	require.True(t, false, "We must panic before this line")
+}
+
+func TestWasmosQuerySmart(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	example := StoreHelloWorldExampleContract(t, ctx, keepers)
+	initMsgBz := HelloWorldInitMsg{name: "Joe"}.GetBytes(t)
+	addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, initMsgBz, "demo contract", nil)
+	require.NoError(t, err)
+
+	res, err := keepers.WasmKeeper.QuerySmart(ctx, addr, []byte(`{}`), "sayHello")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello from CosmoWrap, Joe", string(res))
+
+	// state modifications are rejected in queries
+	updateNameMsgBz := HelloWorldUpdateNameMsg{newName: "Fred"}.GetBytes(t)
+	_, err = keepers.WasmKeeper.QuerySmart(ctx, addr, updateNameMsgBz, "updateName")
+	require.ErrorIs(t, err, types.ErrQueryFailed)
+
+	prefixStore := prefix.NewStore(ctx.KVStore(keepers.WasmKeeper.storeKey), types.GetContractStorePrefix(addr))
+	assert.Equal(t, "Joe", string(prefixStore.Get([]byte("name"))))
+}
+
+func TestWasmosPluginChainQueries(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	example := StoreHelloWorldExampleContract(t, ctx, keepers)
+	initMsgBz := HelloWorldInitMsg{name: "Joe"}.GetBytes(t)
+	addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, initMsgBz, "demo contract", nil)
+	require.NoError(t, err)
+
+	k := keepers.WasmKeeper
+	querier := NewQueryHandler(ctx, k.wasmVMQueryHandler, addr, k.gasRegister)
+	plugin := polywrapvm.NewCosmosPlugin(nil, cosmwasmAPI, querier, k.gasRegister.ToWasmVMGas(1_000_000))
+
+	coin, err := plugin.QueryBalance(polywrapvm.QueryBalanceArgType{Address: creator.String(), Denom: "denom"})
+	require.NoError(t, err)
+	assert.Equal(t, polywrapvm.Coin{Denom: "denom", Amount: "100000"}, coin)
+
+	gasBefore := ctx.GasMeter().GasConsumed()
+	res, err := plugin.QuerySmart(polywrapvm.QuerySmartArgType{ContractAddr: addr.String(), Method: "sayHello", Msg: `{}`})
+	require.NoError(t, err)
+	assert.Equal(t, "Hello from CosmoWrap, Joe", string(res))
+	assert.Greater(t, ctx.GasMeter().GasConsumed(), gasBefore)
+}
+
+func TestWasmosPluginAddr(t *testing.T) {
+	addr := RandomAccountAddress(t)
+	plugin := polywrapvm.NewCosmosPlugin(nil, cosmwasmAPI, nil, 10*costHumanize)
+
+	valid, err := plugin.AddrValidate(polywrapvm.AddrArgType{Addr: addr.String()})
+	require.NoError(t, err)
+	assert.True(t, valid)
+	// not normalized
+	valid, err = plugin.AddrValidate(polywrapvm.AddrArgType{Addr: strings.ToUpper(addr.String())})
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	canonical, err := plugin.AddrCanonicalize(polywrapvm.AddrArgType{Addr: addr.String()})
+	require.NoError(t, err)
+	assert.Equal(t, []byte(addr), canonical)
+	human, err := plugin.AddrHumanize(polywrapvm.AddrHumanizeArgType{Canonical: canonical})
+	require.NoError(t, err)
+	assert.Equal(t, addr.String(), human)
+	assert.Equal(t, 3*(costCanonical+costHumanize), plugin.GasUsed())
+}
+
+func TestWasmosDualRuntime(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+
+	cosmwasmCode, err := os.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+	wrapperCode, err := os.ReadFile("./testdata/hello_world.wasm")
+	require.NoError(t, err)
+
+	// runtime detected from the wasm exports
+	cosmwasmID, _, err := keepers.ContractKeeper.Create(ctx, creator, cosmwasmCode, nil)
+	require.NoError(t, err)
+	assert.Equal(t, types.RuntimeCosmWasm, keepers.WasmKeeper.GetCodeInfo(ctx, cosmwasmID).Runtime)
+	wrapperID, _, err := keepers.ContractKeeper.Create(ctx, creator, wrapperCode, nil)
+	require.NoError(t, err)
+	assert.Equal(t, types.RuntimePolywrap, keepers.WasmKeeper.GetCodeInfo(ctx, wrapperID).Runtime)
+
+	// explicit runtime must match the code
+	_, _, err = keepers.ContractKeeper.CreateWithRuntime(ctx, creator, cosmwasmCode, nil, types.RuntimePolywrap)
+	require.ErrorIs(t, err, types.ErrCreateFailed)
+	_, _, err = keepers.ContractKeeper.CreateWithRuntime(ctx, creator, wrapperCode, nil, types.RuntimeCosmWasm)
+	require.ErrorIs(t, err, types.ErrCreateFailed)
+
+	// both contracts live on the same chain
+	initMsgBz := HackatomExampleInitMsg{Verifier: creator, Beneficiary: RandomAccountAddress(t)}.GetBytes(t)
+	cosmwasmAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, cosmwasmID, creator, nil, initMsgBz, "cosmwasm", nil)
+	require.NoError(t, err)
+	wrapperAddr, _, err := keepers.ContractKeeper.Instantiate(ctx, wrapperID, creator, nil, HelloWorldInitMsg{name: "Joe"}.GetBytes(t), "wrapper", nil)
+	require.NoError(t, err)
+
+	res, err := keepers.WasmKeeper.QuerySmart(ctx, cosmwasmAddr, []byte(`{"verifier":{}}`), "")
+	require.NoError(t, err)
+	assert.JSONEq(t, fmt.Sprintf(`{"verifier":%q}`, creator.String()), string(res))
+	res, err = keepers.WasmKeeper.QuerySmart(ctx, wrapperAddr, []byte(`{}`), "sayHello")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello from CosmoWrap, Joe", string(res))
+
+	gotCode, err := keepers.WasmKeeper.GetByteCode(ctx, wrapperID)
+	require.NoError(t, err)
+	assert.Equal(t, wrapperCode, gotCode)
+}
+
+func TestWasmosWrapperPackage(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	q := Querier(keepers.WasmKeeper)
+
+	wrapperCode, err := os.ReadFile("./testdata/hello_world.wasm")
+	require.NoError(t, err)
+	manifest, err := os.ReadFile("./testdata/hello_world.wrap.info")
+	require.NoError(t, err)
+	cosmwasmCode, err := os.ReadFile("./testdata/hackatom.wasm")
+	require.NoError(t, err)
+
+	// store a gzipped wrapper package
+	pkg, err := ioutils.GzipIt(WrapperPackage(t, wrapperCode, manifest))
+	require.NoError(t, err)
+	codeID, checksum, err := keepers.ContractKeeper.Create(ctx, creator, pkg, nil)
+	require.NoError(t, err)
+	expChecksum := sha256.Sum256(wrapperCode)
+	assert.Equal(t, expChecksum[:], checksum)
+	assert.Equal(t, types.RuntimePolywrap, keepers.WasmKeeper.GetCodeInfo(ctx, codeID).Runtime)
+	assert.Equal(t, manifest, keepers.WasmKeeper.GetManifest(ctx, codeID))
+
+	res, err := q.CodeABI(sdk.WrapSDKContext(ctx), &types.QueryCodeABIRequest{CodeId: codeID})
+	require.NoError(t, err)
+	assert.Equal(t, manifest, res.Manifest)
+	assert.JSONEq(t, `{
+		"version": "0.1",
+		"moduleType": {"methods": [
+			{"name": "init", "arguments": [{"name": "name", "type": "String", "required": true}], "return": {"name": "init", "type": "String", "required": true}},
+			{"name": "updateName", "arguments": [{"name": "newName", "type": "String", "required": true}], "return": {"name": "updateName", "type": "String", "required": true}},
+			{"name": "sayHello", "return": {"name": "sayHello", "type": "String", "required": true}}
+		]}
+	}`, string(res.ABI))
+
+	// the wrapper runs as before
+	addr, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, nil, HelloWorldInitMsg{name: "Joe"}.GetBytes(t), "wrapper", nil)
+	require.NoError(t, err)
+	data, err := keepers.WasmKeeper.QuerySmart(ctx, addr, []byte(`{}`), "sayHello")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello from CosmoWrap, Joe", string(data))
+
+	// the manifest belongs to the code id, so a plain upload of the same code has none
+	plainID, _, err := keepers.ContractKeeper.Create(ctx, creator, wrapperCode, nil)
+	require.NoError(t, err)
+	_, err = q.CodeABI(sdk.WrapSDKContext(ctx), &types.QueryCodeABIRequest{CodeId: plainID})
+	require.ErrorIs(t, err, types.ErrNotFound)
+
+	// and is part of the state, an upload that is not committed stores nothing
+	cacheCtx, _ := ctx.CacheContext()
+	discardedID, _, err := keepers.ContractKeeper.Create(cacheCtx, creator, pkg, nil)
+	require.NoError(t, err)
+	assert.Empty(t, keepers.WasmKeeper.GetManifest(ctx, discardedID))
+
+	// and is exported with the code
+	var exported []byte
+	for _, c := range ExportGenesis(ctx, keepers.WasmKeeper).Codes {
+		if c.CodeID == codeID {
+			exported = c.Manifest
+		}
+	}
+	assert.Equal(t, manifest, exported)
+
+	// invalid packages are rejected
+	_, _, err = keepers.ContractKeeper.Create(ctx, creator, WrapperPackage(t, wrapperCode, []byte("not a manifest")), nil)
+	require.ErrorIs(t, err, types.ErrCreateFailed)
+	_, _, err = keepers.ContractKeeper.Create(ctx, creator, WrapperPackage(t, cosmwasmCode, manifest), nil)
+	require.ErrorIs(t, err, types.ErrCreateFailed)
+
+	// CodeABI only serves wrappers
+	cosmwasmID, _, err := keepers.ContractKeeper.Create(ctx, creator, cosmwasmCode, nil)
+	require.NoError(t, err)
+	_, err = q.CodeABI(sdk.WrapSDKContext(ctx), &types.QueryCodeABIRequest{CodeId: cosmwasmID})
+	require.ErrorIs(t, err, types.ErrInvalid)
+	_, err = q.CodeABI(sdk.WrapSDKContext(ctx), &types.QueryCodeABIRequest{CodeId: 100})
+	require.ErrorIs(t, err, types.ErrNotFound)
+}
+
+func TestWasmosArgumentValidation(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+
+	manifest, err := os.ReadFile("./testdata/hello_world.wrap.info")
+	require.NoError(t, err)
+	codeID, _, err := keepers.ContractKeeper.Create(ctx, creator, WrapperPackage(t, helloWorldWasm, manifest), nil)
+	require.NoError(t, err)
+
+	_, _, err = keepers.ContractKeeper.Instantiate(ctx, codeID, creator, nil, []byte(`{"name":1}`), "wrapper", nil)
+	require.ErrorIs(t, err, types.ErrInvalidMsg)
+	addr, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, nil, []byte(`{"name":"Joe"}`), "wrapper", nil)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		method string
+		msg    []byte
+		expErr bool
+	}{
+		"valid arguments":  {method: "updateName", msg: []byte(`{"newName":"Ann"}`)},
+		"unknown method":   {method: "rename", msg: []byte(`{"newName":"Ann"}`), expErr: true},
+		"unknown argument": {method: "updateName", msg: []byte(`{"newName":"Ann","age":1}`), expErr: true},
+		"missing argument": {method: "updateName", msg: []byte(`{}`), expErr: true},
+		"wrong type":       {method: "updateName", msg: []byte(`{"newName":["Ann"]}`), expErr: true},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			_, err := keepers.ContractKeeper.Execute(ctx, addr, creator, spec.msg, spec.method, nil)
+			if spec.expErr {
+				require.ErrorIs(t, err, types.ErrInvalidMsg)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	_, err = keepers.WasmKeeper.QuerySmart(ctx, addr, []byte(`{"name":"Joe"}`), "sayHello")
+	require.ErrorIs(t, err, types.ErrInvalidMsg)
+	data, err := keepers.WasmKeeper.QuerySmart(ctx, addr, nil, "sayHello")
+	require.NoError(t, err)
+	assert.Equal(t, "Hello from CosmoWrap, Ann", string(data))
+}
+
+func TestWasmosMigrate(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	originalCode := StoreHelloWorldExampleContract(t, ctx, keepers)
+	newCode := StoreMigrateExampleContract(t, ctx, keepers)
+
+	initMsgBz := HelloWorldInitMsg{name: "Joe"}.GetBytes(t)
+	addr, _, err := keepers.ContractKeeper.Instantiate(ctx, originalCode.CodeID, creator, creator, initMsgBz, "demo contract", nil)
+	require.NoError(t, err)
+
+	migrateMsgBz := []byte(`{}`)
+	em := sdk.NewEventManager()
+	data, err := keepers.ContractKeeper.Migrate(ctx.WithEventManager(em), addr, creator, newCode.CodeID, migrateMsgBz)
+	require.NoError(t, err)
+	assert.Equal(t, "migrated", string(data))
+
+	// the new code operates on the existing contract store
+	prefixStore := prefix.NewStore(ctx.KVStore(keepers.WasmKeeper.storeKey), types.GetContractStorePrefix(addr))
+	assert.Equal(t, "migrated", string(prefixStore.Get([]byte("name"))))
+
+	info := keepers.WasmKeeper.GetContractInfo(ctx, addr)
+	require.NotNil(t, info)
+	assert.Equal(t, newCode.CodeID, info.CodeID)
+	assert.Equal(t, []types.ContractCodeHistoryEntry{{
+		Operation: types.ContractCodeHistoryOperationTypeInit,
+		CodeID:    originalCode.CodeID,
+		Updated:   types.NewAbsoluteTxPosition(ctx),
+		Msg:       initMsgBz,
+	}, {
+		Operation: types.ContractCodeHistoryOperationTypeMigrate,
+		CodeID:    newCode.CodeID,
+		Updated:   types.NewAbsoluteTxPosition(ctx),
+		Msg:       migrateMsgBz,
+	}}, keepers.WasmKeeper.GetContractHistory(ctx, addr))
+	contractsByCode := func(codeID uint64) []sdk.AccAddress {
+		var addrs []sdk.AccAddress
+		keepers.WasmKeeper.IterateContractsByCode(ctx, codeID, func(a sdk.AccAddress) bool {
+			addrs = append(addrs, a)
+			return false
+		})
+		return addrs
+	}
+	assert.Empty(t, contractsByCode(originalCode.CodeID))
+	assert.Equal(t, []sdk.AccAddress{addr}, contractsByCode(newCode.CodeID))
+
+	expEvt := sdk.NewEvent("migrate",
+		sdk.NewAttribute("code_id", fmt.Sprintf("%d", newCode.CodeID)),
+		sdk.NewAttribute("_contract_address", addr.String()))
+	assert.Contains(t, em.Events(), expEvt)
+}
+
+func TestWasmosSudo(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	example := StoreRecorderExampleContract(t, ctx, keepers)
+	addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, []byte(`{}`), "demo contract", nil)
+	require.NoError(t, err)
+
+	em := sdk.NewEventManager()
+	data, err := keepers.WasmKeeper.Sudo(ctx.WithEventManager(em), addr, []byte(`{"name":"Joe"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "sudo", string(data))
+
+	prefixStore := prefix.NewStore(ctx.KVStore(keepers.WasmKeeper.storeKey), types.GetContractStorePrefix(addr))
+	args, err := msgpack.Decode[map[string]string](prefixStore.Get([]byte("sudo")))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"name": "Joe"}, args)
+	assert.Contains(t, em.Events(), sdk.NewEvent("sudo", sdk.NewAttribute("_contract_address", addr.String())))
+}
+
+func TestWasmosReply(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	example := StoreRecorderExampleContract(t, ctx, keepers)
+	addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, []byte(`{}`), "demo contract", nil)
+	require.NoError(t, err)
+	prefixStore := prefix.NewStore(ctx.KVStore(keepers.WasmKeeper.storeKey), types.GetContractStorePrefix(addr))
+
+	errMsg := "submessage failed"
+	specs := map[string]struct {
+		reply wasmvmtypes.Reply
+		exp   polywrapvm.Reply
+	}{
+		"success": {
+			reply: wasmvmtypes.Reply{
+				ID: 1,
+				Result: wasmvmtypes.SubMsgResult{Ok: &wasmvmtypes.SubMsgResponse{
+					Events: wasmvmtypes.Events{{Type: "transfer", Attributes: wasmvmtypes.EventAttributes{{Key: "amount", Value: "1denom"}}}},
+					Data:   []byte("data"),
+				}},
+			},
+			exp: polywrapvm.Reply{
+				Id: 1,
+				Result: polywrapvm.SubMsgResult{Ok: &polywrapvm.SubMsgResponse{
+					Events: []polywrapvm.Event{{Type: "transfer", Attributes: []polywrapvm.EventAttribute{{Key: "amount", Value: "1denom"}}}},
+					Data:   []byte("data"),
+				}},
+			},
+		},
+		"error": {
+			reply: wasmvmtypes.Reply{
+				ID:     2,
+				Result: wasmvmtypes.SubMsgResult{Err: errMsg},
+			},
+			exp: polywrapvm.Reply{
+				Id:     2,
+				Result: polywrapvm.SubMsgResult{Err: &errMsg},
+			},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			em := sdk.NewEventManager()
+			data, err := keepers.WasmKeeper.reply(ctx.WithEventManager(em), addr, spec.reply)
+			require.NoError(t, err)
+			assert.Equal(t, "reply", string(data))
+
+			args, err := msgpack.Decode[struct{ Reply polywrapvm.Reply }](prefixStore.Get([]byte("reply")))
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, args.Reply)
+			assert.Contains(t, em.Events(), sdk.NewEvent("reply", sdk.NewAttribute("_contract_address", addr.String())))
+		})
+	}
+}
+
+func TestWasmosExecuteResponse(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 1000))
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, deposit.Add(deposit...)...)
+	example := StoreResponseExampleContract(t, ctx, keepers)
+	addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, []byte(`{}`), "demo contract", deposit)
+	require.NoError(t, err)
+
+	recipient := sdk.AccAddress(bytes.Repeat([]byte{2}, 20))
+	require.Equal(t, "cosmos1qgpqyqszqgpqyqszqgpqyqszqgpqyqszrh8mx2", recipient.String())
+	recipientBalance := keepers.BankKeeper.GetAllBalances(ctx, recipient)
+
+	em := sdk.NewEventManager()
+	data, err := keepers.ContractKeeper.Execute(ctx.WithEventManager(em), addr, creator, []byte(`{}`), "pay", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "response data", string(data))
+
+	// bank message dispatched
+	assert.Equal(t, recipientBalance.Add(sdk.NewInt64Coin("denom", 100)), keepers.BankKeeper.GetAllBalances(ctx, recipient))
+
+	// attributes and custom events emitted
+	assert.Contains(t, em.Events(), sdk.NewEvent("wasm",
+		sdk.NewAttribute("_contract_address", addr.String()),
+		sdk.NewAttribute("action", "pay")))
+	assert.Contains(t, em.Events(), sdk.NewEvent("wasm-payment",
+		sdk.NewAttribute("_contract_address", addr.String()),
+		sdk.NewAttribute("amount", "100denom")))
+}
+
+func TestWasmosEmitEvent(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	instantiate := func(codeID uint64) sdk.AccAddress {
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, nil, []byte(`{}`), "demo contract", nil)
+		require.NoError(t, err)
+		return addr
+	}
+	emitterCode := StoreEmitterExampleContract(t, ctx, keepers)
+	emitter := instantiate(emitterCode.CodeID)
+	forwarder := instantiate(StoreForwarderExampleContract(t, ctx, keepers).CodeID)
+	forward := func(uri string) []byte {
+		return []byte(fmt.Sprintf(`{"uri":%q}`, uri))
+	}
+	contractEvents := func(addr sdk.AccAddress) sdk.Events {
+		return sdk.Events{
+			sdk.NewEvent("wasm",
+				sdk.NewAttribute("_contract_address", addr.String()),
+				sdk.NewAttribute("action", "emit")),
+			sdk.NewEvent("wasm-transfer",
+				sdk.NewAttribute("_contract_address", addr.String()),
+				sdk.NewAttribute("amount", "10")),
+		}
+	}
+
+	specs := map[string]struct {
+		contract  sdk.AccAddress
+		msg       []byte
+		expEvents sdk.Events
+	}{
+		"execute": {
+			contract:  emitter,
+			msg:       []byte(`{}`),
+			expEvents: contractEvents(emitter),
+		},
+		"contract subinvocation": {
+			contract:  forwarder,
+			msg:       forward("wrap://wasmos/" + emitter.String()),
+			expEvents: contractEvents(emitter),
+		},
+		"code subinvocation": {
+			contract:  forwarder,
+			msg:       forward(fmt.Sprintf("wrap://wasmos/code/%d", emitterCode.CodeID)),
+			expEvents: contractEvents(forwarder),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			em := sdk.NewEventManager()
+			gasBefore := ctx.GasMeter().GasConsumed()
+			data, err := keepers.ContractKeeper.Execute(ctx.WithEventManager(em), spec.contract, creator, spec.msg, "emit", nil)
+			require.NoError(t, err)
+			assert.Equal(t, "ok", string(data))
+			assert.Greater(t, ctx.GasMeter().GasConsumed(), gasBefore)
+			for _, e := range spec.expEvents {
+				assert.Contains(t, em.Events(), e)
+			}
+		})
+	}
+
+	t.Run("rejected in queries", func(t *testing.T) {
+		_, err := keepers.WasmKeeper.QuerySmart(ctx, emitter, []byte(`{}`), "emit")
+		require.Error(t, err)
+		_, err = keepers.WasmKeeper.QuerySmart(ctx, forwarder, forward("wrap://wasmos/"+emitter.String()), "emit")
+		require.Error(t, err)
+	})
+}
+
+func TestWasmosEnv(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	ctx = types.WithTXCounter(ctx, 1)
+
+	deposit := sdk.NewCoins(sdk.NewInt64Coin("denom", 1000))
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, deposit.Add(deposit...)...)
+	example := StoreEnvExampleContract(t, ctx, keepers)
+	addr, data, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, []byte(`{}`), "demo contract", deposit)
+	require.NoError(t, err)
+
+	block := polywrapvm.BlockInfo{
+		Height:  uint64(ctx.BlockHeight()),
+		Time:    uint64(ctx.BlockTime().UnixNano()),
+		ChainId: ctx.ChainID(),
+	}
+	env, err := msgpack.Decode[polywrapvm.Env](data)
+	require.NoError(t, err)
+	assert.Equal(t, polywrapvm.Env{
+		Block:       block,
+		Transaction: &polywrapvm.TransactionInfo{Index: 1},
+		Contract:    polywrapvm.ContractInfo{Address: addr.String()},
+		Info: &polywrapvm.MessageInfo{
+			Sender: creator.String(),
+			Funds:  []polywrapvm.Coin{{Denom: "denom", Amount: "1000"}},
+		},
+	}, env)
+
+	// execute without funds
+	data, err = keepers.ContractKeeper.Execute(ctx, addr, creator, []byte(`{}`), "any", nil)
+	require.NoError(t, err)
+	env, err = msgpack.Decode[polywrapvm.Env](data)
+	require.NoError(t, err)
+	assert.Equal(t, &polywrapvm.MessageInfo{Sender: creator.String(), Funds: []polywrapvm.Coin{}}, env.Info)
+
+	// queries have no message info
+	data, err = keepers.WasmKeeper.QuerySmart(ctx, addr, []byte(`{}`), "any")
+	require.NoError(t, err)
+	env, err = msgpack.Decode[polywrapvm.Env](data)
+	require.NoError(t, err)
+	assert.Equal(t, polywrapvm.Env{
+		Block:       block,
+		Transaction: &polywrapvm.TransactionInfo{Index: 1},
+		Contract:    polywrapvm.ContractInfo{Address: addr.String()},
+	}, env)
+}
+
+func TestWasmosSubinvoke(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	ctx = types.WithTXCounter(ctx, 1)
+
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	instantiate := func(codeID uint64) sdk.AccAddress {
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, codeID, creator, nil, []byte(`{}`), "demo contract", nil)
+		require.NoError(t, err)
+		return addr
+	}
+	forwarder := instantiate(StoreForwarderExampleContract(t, ctx, keepers).CodeID)
+	recorderCode := StoreRecorderExampleContract(t, ctx, keepers)
+	recorder := instantiate(recorderCode.CodeID)
+	envContract := instantiate(StoreEnvExampleContract(t, ctx, keepers).CodeID)
+	cosmwasmContract := InstantiateHackatomExampleContract(t, ctx, keepers).Contract
+	forward := func(uri string) []byte {
+		return []byte(fmt.Sprintf(`{"uri":%q}`, uri))
+	}
+
+	t.Run("contract store is isolated", func(t *testing.T) {
+		data, err := keepers.ContractKeeper.Execute(ctx, forwarder, creator, forward("wrap://wasmos/"+recorder.String()), "record", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "record", string(data))
+
+		recorderStore := prefix.NewStore(ctx.KVStore(keepers.WasmKeeper.storeKey), types.GetContractStorePrefix(recorder))
+		assert.Equal(t, []byte{0x80}, recorderStore.Get([]byte("record")))
+		forwarderStore := prefix.NewStore(ctx.KVStore(keepers.WasmKeeper.storeKey), types.GetContractStorePrefix(forwarder))
+		assert.Nil(t, forwarderStore.Get([]byte("record")))
+	})
+	t.Run("failed subinvocation is reverted", func(t *testing.T) {
+		catcher := instantiate(StoreCatcherExampleContract(t, ctx, keepers).CodeID)
+		aborter := instantiate(StoreAborterExampleContract(t, ctx, keepers).CodeID)
+
+		// the catcher ignores the error of the aborter, which wrote before it failed
+		data, err := keepers.ContractKeeper.Execute(ctx, catcher, creator, forward("wrap://wasmos/"+aborter.String()), "write", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", string(data))
+		aborterStore := prefix.NewStore(ctx.KVStore(keepers.WasmKeeper.storeKey), types.GetContractStorePrefix(aborter))
+		assert.Nil(t, aborterStore.Get([]byte("written")))
+
+		// the writes of a successful subinvocation are kept
+		_, err = keepers.ContractKeeper.Execute(ctx, catcher, creator, forward("wrap://wasmos/"+recorder.String()), "caught", nil)
+		require.NoError(t, err)
+		recorderStore := prefix.NewStore(ctx.KVStore(keepers.WasmKeeper.storeKey), types.GetContractStorePrefix(recorder))
+		assert.Equal(t, []byte{0x80}, recorderStore.Get([]byte("caught")))
+	})
+	t.Run("callee env", func(t *testing.T) {
+		data, err := keepers.ContractKeeper.Execute(ctx, forwarder, creator, forward("wrap://wasmos/"+envContract.String()), "any", nil)
+		require.NoError(t, err)
+		env, err := msgpack.Decode[polywrapvm.Env](data)
+		require.NoError(t, err)
+		assert.Equal(t, polywrapvm.ContractInfo{Address: envContract.String()}, env.Contract)
+		assert.Equal(t, &polywrapvm.MessageInfo{Sender: forwarder.String(), Funds: []polywrapvm.Coin{}}, env.Info)
+
+		data, err = keepers.WasmKeeper.QuerySmart(ctx, forwarder, forward("wrap://wasmos/"+envContract.String()), "any")
+		require.NoError(t, err)
+		env, err = msgpack.Decode[polywrapvm.Env](data)
+		require.NoError(t, err)
+		assert.Equal(t, polywrapvm.ContractInfo{Address: envContract.String()}, env.Contract)
+		assert.Nil(t, env.Info)
+	})
+	// a wrapper package on the local filesystem of the node
+	wrapperDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(wrapperDir, "wrap.wasm"), helloWorldWasm, 0o644))
+	manifest, err := os.ReadFile("./testdata/hello_world.wrap.info")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(wrapperDir, "wrap.info"), manifest, 0o644))
+
+	specs := map[string]struct {
+		uri    string
+		query  bool
+		expErr string
+	}{
+		"filesystem": {
+			uri: "wrap://fs/" + wrapperDir, expErr: "unresolvable URI",
+		},
+		"write in query": {
+			uri: "wrap://wasmos/" + recorder.String(), query: true, expErr: "read only in queries",
+		},
+		"write in code": {
+			uri: fmt.Sprintf("wrap://wasmos/code/%d", recorderCode.CodeID), expErr: "code subinvocations have no store",
+		},
+		"reentrancy": {
+			uri: "wrap://wasmos/" + forwarder.String(), expErr: "reentrant call",
+		},
+		"cosmwasm contract": {
+			uri: "wrap://wasmos/" + cosmwasmContract.String(), expErr: "not a wrapper",
+		},
+		"unknown contract": {
+			uri: "wrap://wasmos/" + RandomBech32AccountAddress(t), expErr: "not found",
+		},
+		"unknown code": {
+			uri: "wrap://wasmos/code/100", expErr: "not found",
+		},
+		"invalid address": {
+			uri: "wrap://wasmos/foo", expErr: "invalid",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			var err error
+			if spec.query {
+				_, err = keepers.WasmKeeper.QuerySmart(ctx, forwarder, forward(spec.uri), "record")
+			} else {
+				_, err = keepers.ContractKeeper.Execute(ctx, forwarder, creator, forward(spec.uri), "record", nil)
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), spec.expErr)
+		})
+	}
+}
+
+func TestWasmosPinCode(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities, WithVMCacheMetrics(registry))
+	k := keepers.WasmKeeper
+
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+	example := StoreHelloWorldExampleContract(t, ctx, keepers)
+	initMsgBz := HelloWorldInitMsg{name: "Joe"}.GetBytes(t)
+	addr, _, err := keepers.ContractKeeper.Instantiate(ctx, example.CodeID, creator, nil, initMsgBz, "demo contract", nil)
+	require.NoError(t, err)
+
+	// pinned by a governance proposal
+	handler := NewWasmProposalHandler(keepers.WasmKeeper, types.EnableAllProposals)
+	require.NoError(t, handler(ctx, &types.PinCodesProposal{Title: "pin", Description: "pin", CodeIDs: []uint64{example.CodeID}}))
+	assert.True(t, k.IsPinnedCode(ctx, example.CodeID))
+	_, err = keepers.WasmKeeper.QuerySmart(ctx, addr, nil, "sayHello")
+	require.NoError(t, err)
+
+	metrics, err := k.polywrapVm.(*polywrapvm.VM).GetMetrics()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), metrics.ElementsPinnedMemoryCache)
+	assert.Equal(t, uint32(1), metrics.HitsPinnedMemoryCache)
+
+	// reported by the metrics collector
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	var names []string
+	for _, f := range families {
+		names = append(names, f.GetName())
+	}
+	assert.Contains(t, names, "polywrapvm_cache_hits_total")
+	assert.Contains(t, names, "wasmvm_cache_hits_total")
+
+	require.NoError(t, handler(ctx, &types.UnpinCodesProposal{Title: "unpin", Description: "unpin", CodeIDs: []uint64{example.CodeID}}))
+	assert.False(t, k.IsPinnedCode(ctx, example.CodeID))
+	metrics, err = k.polywrapVm.(*polywrapvm.VM).GetMetrics()
+	require.NoError(t, err)
+	assert.Zero(t, metrics.ElementsPinnedMemoryCache)
+}
+
+func TestWasmosDeterministicProfile(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	creator := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100000))
+
+	specs := map[string]struct {
+		imports string
+		body    string
+		expErr  string
+	}{
+		"deterministic": {
+			body: `(call $invoke_result (i32.const 0) (i32.const 1)) (i32.const 1)`,
+		},
+		"floats": {
+			body:   `(i32.trunc_f64_s (f64.const 1.5))`,
+			expErr: "wrapper requires unavailable capabilities: floats",
+		},
+		"foreign import": {
+			imports: `(import "env" "abort" (func $abort))`,
+			body:    `(call $abort) (i32.const 1)`,
+			expErr:  "wrapper requires unavailable capabilities: import:env.abort",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			code, err := wasmtime.Wat2Wasm(`(module
+  (import "wrap" "__wrap_invoke_result" (func $invoke_result (param i32 i32)))
+  (import "env" "memory" (memory 1))
+  ` + spec.imports + `
+  (func (export "_wrap_invoke") (param i32 i32 i32) (result i32) ` + spec.body + `))`)
+			require.NoError(t, err)
+			_, _, err = keepers.ContractKeeper.Create(ctx, creator, code, nil)
+			if spec.expErr != "" {
+				require.ErrorIs(t, err, types.ErrCreateFailed)
+				assert.Contains(t, err.Error(), spec.expErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestWasmosImportSkipsDeterministicProfile(t *testing.T) {
+	// wrappers accepted before the profile was enforced are imported as they are
+	code, err := wasmtime.Wat2Wasm(`(module
+  (import "env" "memory" (memory 1))
+  (func (export "_wrap_invoke") (param i32 i32 i32) (result i32) (i32.trunc_f64_s (f64.const 1.5))))`)
+	require.NoError(t, err)
+	checksum := sha256.Sum256(code)
+	codeInfo := types.CodeInfoFixture(func(info *types.CodeInfo) {
+		info.CodeHash = checksum[:]
+		info.Runtime = types.RuntimePolywrap
+	})
+
+	t.Run("genesis", func(t *testing.T) {
+		ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+		require.NoError(t, keepers.WasmKeeper.importCode(ctx, 1, codeInfo, code))
+		bz, err := keepers.WasmKeeper.GetByteCode(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, code, bz)
+	})
+	t.Run("snapshot", func(t *testing.T) {
+		ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+		compressed, err := ioutils.GzipIt(code)
+		require.NoError(t, err)
+		item := types.SnapshotCode{Runtime: types.RuntimePolywrap, WASMByteCode: compressed}
+		payload, err := item.Marshal()
+		require.NoError(t, err)
+		require.NoError(t, restoreV2(ctx, keepers.WasmKeeper, payload))
+		_, err = keepers.WasmKeeper.polywrapVm.GetCode(checksum[:])
+		require.NoError(t, err)
+	})
+}
+
+func TestWasmosExecuteBatch(t *testing.T) {
+	ctx, keepers := CreateTestInput(t, false, AvailableCapabilities)
+	ctx = types.WithTXCounter(ctx, 1)
+
+	sender := keepers.Faucet.NewFundedRandomAccount(ctx, sdk.NewInt64Coin("denom", 100))
+	recorderCode := StoreRecorderExampleContract(t, ctx, keepers)
+	instantiate := func() sdk.AccAddress {
+		addr, _, err := keepers.ContractKeeper.Instantiate(ctx, recorderCode.CodeID, sender, nil, []byte(`{}`), "recorder", nil)
+		require.NoError(t, err)
+		return addr
+	}
+	vault, token := instantiate(), instantiate()
+	recorded := func(ctx sdk.Context, contract sdk.AccAddress, method string) []byte {
+		return keepers.WasmKeeper.QueryRaw(ctx, contract, []byte(method))
+	}
+	msgServer := NewMsgServerImpl(keepers.ContractKeeper)
+
+	specs := map[string]struct {
+		calls      []types.ContractCall
+		expData    [][]byte
+		expBalance sdk.Coins
+		expErr     string
+	}{
+		"all calls succeed": {
+			calls: []types.ContractCall{
+				{Contract: vault.String(), Method: "setup", Msg: []byte(`{}`)},
+				{Contract: token.String(), Method: "approve", Msg: []byte(`{}`)},
+				{Contract: vault.String(), Method: "deposit", Msg: []byte(`{}`), Funds: sdk.NewCoins(sdk.NewInt64Coin("denom", 60))},
+			},
+			expData:    [][]byte{[]byte("setup"), []byte("approve"), []byte("deposit")},
+			expBalance: sdk.NewCoins(sdk.NewInt64Coin("denom", 40)),
+		},
+		"failing call rolls back the batch": {
+			calls: []types.ContractCall{
+				{Contract: vault.String(), Method: "setup", Msg: []byte(`{}`)},
+				{Contract: vault.String(), Method: "deposit", Msg: []byte(`{}`), Funds: sdk.NewCoins(sdk.NewInt64Coin("denom", 60))},
+				{Contract: token.String(), Method: "approve", Msg: []byte(`{}`), Funds: sdk.NewCoins(sdk.NewInt64Coin("denom", 60))},
+			},
+			expBalance: sdk.NewCoins(sdk.NewInt64Coin("denom", 100)),
+			expErr:     "call 2",
+		},
+		"unknown contract": {
+			calls: []types.ContractCall{
+				{Contract: vault.String(), Method: "setup", Msg: []byte(`{}`)},
+				{Contract: RandomBech32AccountAddress(t), Method: "setup", Msg: []byte(`{}`)},
+			},
+			expBalance: sdk.NewCoins(sdk.NewInt64Coin("denom", 100)),
+			expErr:     "call 1",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := ctx.CacheContext()
+			em := sdk.NewEventManager()
+			ctx = ctx.WithEventManager(em)
+			res, err := msgServer.ExecuteContractBatch(sdk.WrapSDKContext(ctx), &types.MsgExecuteContractBatch{
+				Sender: sender.String(),
+				Calls:  spec.calls,
+			})
+			assert.Equal(t, spec.expBalance, keepers.BankKeeper.GetAllBalances(ctx, sender))
+			if spec.expErr != "" {
+				require.ErrorContains(t, err, spec.expErr)
+				assert.Nil(t, recorded(ctx, vault, "setup"))
+				assert.Nil(t, recorded(ctx, vault, "deposit"))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expData, res.Data)
+			for _, c := range spec.calls {
+				assert.Equal(t, []byte{0x80}, recorded(ctx, sdk.MustAccAddressFromBech32(c.Contract), c.Method))
+			}
+			// the events of every call are emitted
+			var executed []string
+			for _, e := range em.Events() {
+				if e.Type == types.EventTypeExecute {
+					executed = append(executed, string(e.Attributes[0].Value))
+				}
+			}
+			assert.Equal(t, []string{vault.String(), token.String(), vault.String()}, executed)
+		})
+	}
+}
@@ -0,0 +1,390 @@
+package polywrapvm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Method returns the module method with the given name
+func (m Manifest) Method(name string) (*MethodDefinition, bool) {
+	if m.ABI.Module == nil {
+		return nil, false
+	}
+	for i := range m.ABI.Module.Methods {
+		if m.ABI.Module.Methods[i].Name == name {
+			return &m.ABI.Module.Methods[i], true
+		}
+	}
+	return nil, false
+}
+
+// EncodeArgs converts the JSON object of named method arguments into the msgpack map the wrapper
+// decodes. Every argument is coerced into the type declared by the ABI: integers are accepted as JSON
+// numbers or decimal strings, BigInt and BigNumber as decimal strings or numbers and Bytes as base64
+// strings. Unknown methods, unknown arguments and missing required arguments are rejected.
+func (m Manifest) EncodeArgs(method string, msg []byte) ([]byte, error) {
+	def, ok := m.Method(method)
+	if !ok {
+		return nil, fmt.Errorf("unknown method %q", method)
+	}
+	args, err := decodeJSONArgs(msg)
+	if err != nil {
+		return nil, err
+	}
+	c := coercer{manifest: m}
+	encoded, err := c.properties(def.Arguments, args, "args")
+	if err != nil {
+		return nil, err
+	}
+	return encodeMsgpack(encoded), nil
+}
+
+// decodeJSONArgs decodes the JSON object of the method arguments. An empty message or null has no arguments.
+func decodeJSONArgs(msg []byte) (map[string]any, error) {
+	if len(msg) == 0 {
+		return nil, nil
+	}
+	v, err := decodeJSON(msg)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	args, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("arguments must be a JSON object")
+	}
+	return args, nil
+}
+
+func decodeJSON(bz []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid JSON arguments: %w", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("invalid JSON arguments: trailing data")
+	}
+	return v, nil
+}
+
+// coercer converts decoded JSON values into the values encodeMsgpack writes for the ABI types
+type coercer struct {
+	manifest Manifest
+	depth    int
+}
+
+func (c coercer) properties(defs []PropertyDefinition, values map[string]any, path string) (map[string]any, error) {
+	res := make(map[string]any, len(defs))
+	known := make(map[string]bool, len(defs))
+	for _, def := range defs {
+		known[def.Name] = true
+		p := path + "." + def.Name
+		v, ok := values[def.Name]
+		if !ok || v == nil {
+			if def.Required {
+				return nil, fmt.Errorf("%s: required %s is missing", p, def.Type)
+			}
+			continue
+		}
+		coerced, err := c.value(strings.TrimSuffix(def.Type, "!"), v, p)
+		if err != nil {
+			return nil, err
+		}
+		res[def.Name] = coerced
+	}
+	for name := range values {
+		if !known[name] {
+			return nil, fmt.Errorf("%s.%s: unknown property", path, name)
+		}
+	}
+	return res, nil
+}
+
+func (c coercer) value(typ string, v any, path string) (any, error) {
+	if c.depth > maxManifestDepth {
+		return nil, fmt.Errorf("%s: max depth exceeded", path)
+	}
+	c.depth++
+
+	required := strings.HasSuffix(typ, "!")
+	typ = strings.TrimSuffix(typ, "!")
+	if v == nil {
+		if required {
+			return nil, fmt.Errorf("%s: expected %s, got null", path, typ)
+		}
+		return nil, nil
+	}
+
+	switch {
+	case strings.HasPrefix(typ, "[") && strings.HasSuffix(typ, "]"):
+		list, ok := v.([]any)
+		if !ok {
+			return nil, mismatch(path, typ, v)
+		}
+		res := make([]any, len(list))
+		for i, e := range list {
+			var err error
+			if res[i], err = c.value(typ[1:len(typ)-1], e, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	case strings.HasPrefix(typ, "Map<") && strings.HasSuffix(typ, ">"):
+		keyType, valueType, ok := splitMapType(typ[len("Map<") : len(typ)-1])
+		if !ok {
+			return nil, fmt.Errorf("%s: invalid map type %s", path, typ)
+		}
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, mismatch(path, typ, v)
+		}
+		res := make([]mapEntry, 0, len(obj))
+		for _, k := range sortedKeys(obj) {
+			p := fmt.Sprintf("%s[%q]", path, k)
+			key, err := c.mapKey(keyType, k, p)
+			if err != nil {
+				return nil, err
+			}
+			value, err := c.value(valueType, obj[k], p)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, mapEntry{Key: key, Value: value})
+		}
+		return res, nil
+	}
+
+	switch typ {
+	case "String":
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case "Boolean":
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case "UInt", "UInt8", "UInt16", "UInt32", "UInt64":
+		return parseUint(typ, v, path)
+	case "Int", "Int8", "Int16", "Int32", "Int64":
+		return parseInt(typ, v, path)
+	case "BigInt":
+		s, ok := numberString(v)
+		if !ok {
+			break
+		}
+		i, ok := new(big.Int).SetString(s, 10)
+		if !ok {
+			return nil, fmt.Errorf("%s: invalid BigInt %q", path, s)
+		}
+		return i.String(), nil
+	case "BigNumber":
+		s, ok := numberString(v)
+		if !ok {
+			break
+		}
+		if _, ok := new(big.Float).SetString(s); !ok {
+			return nil, fmt.Errorf("%s: invalid BigNumber %q", path, s)
+		}
+		return s, nil
+	case "Bytes":
+		s, ok := v.(string)
+		if !ok {
+			break
+		}
+		bz, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid base64 Bytes: %w", path, err)
+		}
+		return bz, nil
+	case "JSON":
+		bz, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return string(bz), nil
+	default:
+		return c.custom(typ, v, path)
+	}
+	return nil, mismatch(path, typ, v)
+}
+
+// custom coerces objects and enums declared by the ABI. Types unknown to the ABI, e.g. imported ones,
+// are passed through as plain JSON values.
+func (c coercer) custom(typ string, v any, path string) (any, error) {
+	for _, o := range c.manifest.ABI.ObjectTypes {
+		if o.Type != typ {
+			continue
+		}
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil, mismatch(path, typ, v)
+		}
+		return c.properties(o.Properties, obj, path)
+	}
+	for _, e := range c.manifest.ABI.EnumTypes {
+		if e.Type != typ && "Enum_"+e.Type != typ {
+			continue
+		}
+		if s, ok := v.(string); ok {
+			for i, constant := range e.Constants {
+				if constant == s {
+					return int64(i), nil
+				}
+			}
+			return nil, fmt.Errorf("%s: unknown %s constant %q", path, e.Type, s)
+		}
+		i, err := parseInt("Int32", v, path)
+		if err != nil {
+			return nil, mismatch(path, typ, v)
+		}
+		if n := i.(int64); n < 0 || n >= int64(len(e.Constants)) {
+			return nil, fmt.Errorf("%s: %s value %d out of range", path, e.Type, n)
+		}
+		return i, nil
+	}
+	return plain(v)
+}
+
+// mapKey coerces the JSON object key of a Map into its key type
+func (c coercer) mapKey(typ, k, path string) (any, error) {
+	typ = strings.TrimSuffix(typ, "!")
+	switch typ {
+	case "String":
+		return k, nil
+	case "UInt", "UInt8", "UInt16", "UInt32", "UInt64":
+		return parseUint(typ, k, path)
+	case "Int", "Int8", "Int16", "Int32", "Int64":
+		return parseInt(typ, k, path)
+	}
+	return nil, fmt.Errorf("%s: unsupported map key type %s", path, typ)
+}
+
+// plain converts a decoded JSON value without type information
+func plain(v any) (any, error) {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return u, nil
+		}
+		return v.Float64()
+	case []any:
+		res := make([]any, len(v))
+		for i, e := range v {
+			var err error
+			if res[i], err = plain(e); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	case map[string]any:
+		res := make(map[string]any, len(v))
+		for k, e := range v {
+			var err error
+			if res[k], err = plain(e); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+	return v, nil
+}
+
+var intBits = map[string]int{
+	"Int": 32, "Int8": 8, "Int16": 16, "Int32": 32, "Int64": 64,
+	"UInt": 32, "UInt8": 8, "UInt16": 16, "UInt32": 32, "UInt64": 64,
+}
+
+func parseUint(typ string, v any, path string) (any, error) {
+	s, ok := numberString(v)
+	if !ok {
+		return nil, mismatch(path, typ, v)
+	}
+	u, err := strconv.ParseUint(s, 10, intBits[typ])
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid %s %q", path, typ, s)
+	}
+	return u, nil
+}
+
+func parseInt(typ string, v any, path string) (any, error) {
+	s, ok := numberString(v)
+	if !ok {
+		return nil, mismatch(path, typ, v)
+	}
+	i, err := strconv.ParseInt(s, 10, intBits[typ])
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid %s %q", path, typ, s)
+	}
+	return i, nil
+}
+
+// numberString returns JSON numbers and strings in their text form
+func numberString(v any) (string, bool) {
+	switch v := v.(type) {
+	case json.Number:
+		return v.String(), true
+	case string:
+		return v, true
+	}
+	return "", false
+}
+
+// splitMapType splits the `K, V` of a Map<K, V> type at the top level comma
+func splitMapType(s string) (string, string, bool) {
+	depth := 0
+	for i, r := range s {
+		switch r {
+		case '<', '[':
+			depth++
+		case '>', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				k, v := strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:])
+				return k, v, k != "" && v != ""
+			}
+		}
+	}
+	return "", "", false
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func mismatch(path, typ string, v any) error {
+	return fmt.Errorf("%s: expected %s, got %s", path, typ, jsonKind(v))
+}
+
+func jsonKind(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,239 @@
+package polywrapvm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeArgs(t *testing.T) {
+	specs := map[string]struct {
+		argType  string
+		required bool
+		src      string
+		exp      any
+		expErr   bool
+	}{
+		"string": {
+			argType: "String", required: true, src: `"Joe"`, exp: "Joe",
+		},
+		"string from number": {
+			argType: "String", required: true, src: `1`, expErr: true,
+		},
+		"boolean": {
+			argType: "Boolean", required: true, src: `true`, exp: true,
+		},
+		"uint64 from string": {
+			argType: "UInt64", required: true, src: `"18446744073709551615"`, exp: uint64(18446744073709551615),
+		},
+		"uint32 from number": {
+			argType: "UInt32", required: true, src: `7`, exp: uint64(7),
+		},
+		"uint32 overflow": {
+			argType: "UInt32", required: true, src: `4294967296`, expErr: true,
+		},
+		"negative uint": {
+			argType: "UInt", required: true, src: `-1`, expErr: true,
+		},
+		"fractional int": {
+			argType: "Int32", required: true, src: `1.5`, expErr: true,
+		},
+		"int8": {
+			argType: "Int8", required: true, src: `-128`, exp: int64(-128),
+		},
+		"bigint canonical": {
+			argType: "BigInt", required: true, src: `"+0012345678901234567890"`, exp: "12345678901234567890",
+		},
+		"bigint from number": {
+			argType: "BigInt", required: true, src: `42`, exp: "42",
+		},
+		"invalid bigint": {
+			argType: "BigInt", required: true, src: `"1e3"`, expErr: true,
+		},
+		"bignumber": {
+			argType: "BigNumber", required: true, src: `"1.25"`, exp: "1.25",
+		},
+		"bytes": {
+			argType: "Bytes", required: true, src: `"AQI="`, exp: []byte{1, 2},
+		},
+		"invalid bytes": {
+			argType: "Bytes", required: true, src: `"not base64"`, expErr: true,
+		},
+		"json": {
+			argType: "JSON", required: true, src: `{"b":1,"a":[true]}`, exp: `{"a":[true],"b":1}`,
+		},
+		"array": {
+			argType: "[UInt8]", required: true, src: `[1,"2"]`, exp: []any{uint64(1), uint64(2)},
+		},
+		"array with invalid item": {
+			argType: "[UInt8]", required: true, src: `[1,256]`, expErr: true,
+		},
+		"nested array": {
+			argType: "[[String]]", required: true, src: `[["a"],[]]`, exp: []any{[]any{"a"}, []any{}},
+		},
+		"map": {
+			argType: "Map<UInt32, [String]>", required: true, src: `{"2":["b"],"1":[]}`,
+			exp: []mapEntry{{Key: uint64(1), Value: []any{}}, {Key: uint64(2), Value: []any{"b"}}},
+		},
+		"map with invalid key": {
+			argType: "Map<UInt32, String>", required: true, src: `{"a":"b"}`, expErr: true,
+		},
+		"object": {
+			argType: "Person", required: true, src: `{"name":"Joe","friends":["Ann"]}`,
+			exp: map[string]any{"name": "Joe", "friends": []any{"Ann"}},
+		},
+		"object without optional property": {
+			argType: "Person", required: true, src: `{"name":"Joe","friends":null}`,
+			exp: map[string]any{"name": "Joe"},
+		},
+		"object without required property": {
+			argType: "Person", required: true, src: `{"friends":[]}`, expErr: true,
+		},
+		"object with unknown property": {
+			argType: "Person", required: true, src: `{"name":"Joe","age":1}`, expErr: true,
+		},
+		"enum by name": {
+			argType: "Color", required: true, src: `"BLUE"`, exp: int64(1),
+		},
+		"enum by value": {
+			argType: "Color", required: true, src: `0`, exp: int64(0),
+		},
+		"unknown enum constant": {
+			argType: "Color", required: true, src: `"GREEN"`, expErr: true,
+		},
+		"enum value out of range": {
+			argType: "Color", required: true, src: `2`, expErr: true,
+		},
+		"type unknown to the abi": {
+			argType: "Imported_Type", required: true, src: `{"a":[1,-1,1.5]}`,
+			exp: map[string]any{"a": []any{int64(1), int64(-1), 1.5}},
+		},
+		"missing required argument": {
+			argType: "String", required: true, src: `null`, expErr: true,
+		},
+		"missing optional argument": {
+			argType: "String", src: `null`,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			m := argsManifest(t, PropertyDefinition{Name: "arg", Type: spec.argType, Required: spec.required})
+			got, err := m.EncodeArgs("method", []byte(`{"arg":`+spec.src+`}`))
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			exp := map[string]any{}
+			if spec.exp != nil {
+				exp["arg"] = spec.exp
+			}
+			assert.Equal(t, encodeMsgpack(exp), got)
+		})
+	}
+}
+
+func TestEncodeArgsMessage(t *testing.T) {
+	m := argsManifest(t, PropertyDefinition{Name: "name", Type: "String"})
+	specs := map[string]struct {
+		method string
+		src    []byte
+		expErr bool
+	}{
+		"empty message":    {method: "method"},
+		"null message":     {method: "method", src: []byte(`null`)},
+		"empty object":     {method: "method", src: []byte(`{}`)},
+		"unknown method":   {method: "other", src: []byte(`{}`), expErr: true},
+		"unknown argument": {method: "method", src: []byte(`{"other":1}`), expErr: true},
+		"not an object":    {method: "method", src: []byte(`["Joe"]`), expErr: true},
+		"invalid json":     {method: "method", src: []byte(`{`), expErr: true},
+		"trailing data":    {method: "method", src: []byte(`{} {}`), expErr: true},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, err := m.EncodeArgs(spec.method, spec.src)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, encodeMsgpack(map[string]any{}), got)
+		})
+	}
+}
+
+func TestEncodeMsgpack(t *testing.T) {
+	specs := map[string]struct {
+		src any
+		exp []byte
+	}{
+		"nil":             {src: nil, exp: []byte{0xc0}},
+		"false":           {src: false, exp: []byte{0xc2}},
+		"positive fixint": {src: 127, exp: []byte{0x7f}},
+		"negative fixint": {src: -32, exp: []byte{0xe0}},
+		"int8":            {src: -33, exp: []byte{0xd0, 0xdf}},
+		"int16":           {src: 128, exp: []byte{0xd1, 0x00, 0x80}},
+		"int64":           {src: int64(-1 << 40), exp: []byte{0xd3, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		"uint fixint":     {src: uint64(1), exp: []byte{0x01}},
+		"uint8":           {src: uint64(255), exp: []byte{0xcc, 0xff}},
+		"uint32":          {src: uint64(1 << 16), exp: []byte{0xce, 0x00, 0x01, 0x00, 0x00}},
+		"fixstr":          {src: "a", exp: []byte{0xa1, 'a'}},
+		"bin8":            {src: []byte{1}, exp: []byte{0xc4, 0x01, 0x01}},
+		"fixarray":        {src: []any{true}, exp: []byte{0x91, 0xc3}},
+		"sorted map":      {src: map[string]any{"b": 1, "a": 2}, exp: []byte{0x82, 0xa1, 'a', 0x02, 0xa1, 'b', 0x01}},
+		"map entries":     {src: []mapEntry{{Key: uint64(2), Value: "a"}}, exp: []byte{0x81, 0x02, 0xa1, 'a'}},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, encodeMsgpack(spec.src))
+		})
+	}
+}
+
+func TestEncodeMsgpackLengths(t *testing.T) {
+	str := make([]byte, 1<<16)
+	for i := range str {
+		str[i] = 'a'
+	}
+	for _, n := range []int{31, 32, 255, 256, 1 << 16} {
+		for _, v := range []any{string(str[:n]), str[:n], make([]any, n)} {
+			got, err := decodeMsgpack(encodeMsgpack(v))
+			require.NoError(t, err)
+			switch v := v.(type) {
+			case string:
+				assert.Equal(t, v, got)
+			case []byte:
+				assert.Equal(t, v, got)
+			case []any:
+				assert.Len(t, got, n)
+			}
+		}
+	}
+}
+
+func argsManifest(t *testing.T, args ...PropertyDefinition) Manifest {
+	m := Manifest{
+		Version: "0.1",
+		Type:    ManifestType,
+		Name:    "test",
+		ABI: ABI{
+			Version: "0.1",
+			Module: &ModuleDefinition{Methods: []MethodDefinition{{
+				Name:      "method",
+				Arguments: args,
+				Return:    &PropertyDefinition{Name: "method", Type: "String", Required: true},
+			}}},
+			ObjectTypes: []ObjectDefinition{{
+				Type: "Person",
+				Properties: []PropertyDefinition{
+					{Name: "name", Type: "String", Required: true},
+					{Name: "friends", Type: "[String]"},
+				},
+			}},
+			EnumTypes: []EnumDefinition{{Type: "Color", Constants: []string{"RED", "BLUE"}}},
+		},
+	}
+	require.NoError(t, m.ValidateBasic())
+	return m
+}
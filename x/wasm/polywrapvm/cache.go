@@ -0,0 +1,131 @@
+package polywrapvm
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/CosmWasm/wasmvm/types"
+	"github.com/bytecodealliance/wasmtime-go"
+)
+
+// moduleCache keeps compiled wrapper modules in memory. Pinned modules stay cached until they are
+// unpinned, all others are evicted least recently used first once their size exceeds the limit.
+type moduleCache struct {
+	mu sync.Mutex
+	// limit is the maximal size of the unpinned modules in bytes, 0 disables caching them
+	limit uint64
+	// lru holds the unpinned modules, the most recently used first
+	lru     *list.List
+	entries map[string]*list.Element
+	pinned  map[string]*cacheEntry
+	metrics types.Metrics
+}
+
+type cacheEntry struct {
+	key    string
+	module *wasmtime.Module
+	size   uint64
+}
+
+// newModuleCache creates a cache for the unpinned modules of up to limitMiB MiB
+func newModuleCache(limitMiB uint32) *moduleCache {
+	return &moduleCache{
+		limit:   uint64(limitMiB) << 20,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+		pinned:  make(map[string]*cacheEntry),
+	}
+}
+
+// get returns the cached module of the checksum and counts the hit or miss
+func (c *moduleCache) get(checksum []byte) (*wasmtime.Module, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.pinned[string(checksum)]; ok {
+		c.metrics.HitsPinnedMemoryCache++
+		return e.module, true
+	}
+	if el, ok := c.entries[string(checksum)]; ok {
+		c.metrics.HitsMemoryCache++
+		c.lru.MoveToFront(el)
+		return el.Value.(*cacheEntry).module, true
+	}
+	c.metrics.Misses++
+	return nil, false
+}
+
+// add caches the compiled module of the checksum and evicts the least recently used modules that no
+// longer fit. Modules larger than the limit are not cached.
+func (c *moduleCache) add(checksum []byte, module *wasmtime.Module, size uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.insert(&cacheEntry{key: string(checksum), module: module, size: size})
+}
+
+func (c *moduleCache) insert(e *cacheEntry) {
+	if _, ok := c.pinned[e.key]; ok {
+		return
+	}
+	if _, ok := c.entries[e.key]; ok || e.size > c.limit {
+		return
+	}
+	c.entries[e.key] = c.lru.PushFront(e)
+	c.metrics.ElementsMemoryCache++
+	c.metrics.SizeMemoryCache += e.size
+	for c.metrics.SizeMemoryCache > c.limit {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *moduleCache) remove(el *list.Element) {
+	e := c.lru.Remove(el).(*cacheEntry)
+	delete(c.entries, e.key)
+	c.metrics.ElementsMemoryCache--
+	c.metrics.SizeMemoryCache -= e.size
+}
+
+// isPinned returns true when the module of the checksum is pinned
+func (c *moduleCache) isPinned(checksum []byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.pinned[string(checksum)]
+	return ok
+}
+
+// pin keeps the module of the checksum in memory until it is unpinned
+func (c *moduleCache) pin(checksum []byte, module *wasmtime.Module, size uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := string(checksum)
+	if _, ok := c.pinned[key]; ok {
+		return
+	}
+	if el, ok := c.entries[key]; ok {
+		c.remove(el)
+	}
+	c.pinned[key] = &cacheEntry{key: key, module: module, size: size}
+	c.metrics.ElementsPinnedMemoryCache++
+	c.metrics.SizePinnedMemoryCache += size
+}
+
+// unpin moves the pinned module of the checksum back to the least recently used modules
+func (c *moduleCache) unpin(checksum []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.pinned[string(checksum)]
+	if !ok {
+		return
+	}
+	delete(c.pinned, e.key)
+	c.metrics.ElementsPinnedMemoryCache--
+	c.metrics.SizePinnedMemoryCache -= e.size
+	c.insert(e)
+}
+
+// getMetrics returns a snapshot of the cache metrics
+func (c *moduleCache) getMetrics() *types.Metrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m := c.metrics
+	return &m
+}
@@ -0,0 +1,88 @@
+package polywrapvm
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/wasmvm/types"
+	"github.com/bytecodealliance/wasmtime-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleCache(t *testing.T) {
+	code, err := wasmtime.Wat2Wasm(`(module)`)
+	require.NoError(t, err)
+	module, _, err := newRuntime(32).compile(code)
+	require.NoError(t, err)
+	const mib = 1 << 20
+
+	c := newModuleCache(2)
+	_, ok := c.get([]byte("a"))
+	assert.False(t, ok)
+	c.add([]byte("a"), module, mib)
+	c.add([]byte("b"), module, mib)
+	// a is used more recently than b
+	_, ok = c.get([]byte("a"))
+	assert.True(t, ok)
+
+	// b is evicted
+	c.add([]byte("c"), module, mib)
+	_, ok = c.get([]byte("b"))
+	assert.False(t, ok)
+	_, ok = c.get([]byte("a"))
+	assert.True(t, ok)
+
+	// modules larger than the cache are not cached
+	c.add([]byte("d"), module, 3*mib)
+	_, ok = c.get([]byte("d"))
+	assert.False(t, ok)
+
+	// pinned modules are not evicted and not counted towards the limit
+	c.pin([]byte("a"), module, mib)
+	c.pin([]byte("e"), module, 4*mib)
+	c.add([]byte("f"), module, mib)
+	c.add([]byte("g"), module, mib)
+	for _, key := range []string{"a", "e", "f", "g"} {
+		_, ok = c.get([]byte(key))
+		assert.True(t, ok, key)
+	}
+	assert.True(t, c.isPinned([]byte("e")))
+
+	// unpinned modules are cached until evicted
+	c.unpin([]byte("a"))
+	c.unpin([]byte("e"))
+	assert.False(t, c.isPinned([]byte("a")))
+	_, ok = c.get([]byte("a"))
+	assert.True(t, ok)
+	_, ok = c.get([]byte("e"))
+	assert.False(t, ok)
+
+	assert.Equal(t, &types.Metrics{
+		HitsPinnedMemoryCache: 2,
+		HitsMemoryCache:       5,
+		Misses:                4,
+		ElementsMemoryCache:   2,
+		SizeMemoryCache:       2 * mib,
+	}, c.getMetrics())
+}
+
+func TestModuleCacheDisabled(t *testing.T) {
+	code, err := wasmtime.Wat2Wasm(`(module)`)
+	require.NoError(t, err)
+	module, size, err := newRuntime(32).compile(code)
+	require.NoError(t, err)
+	assert.NotZero(t, size)
+
+	c := newModuleCache(0)
+	c.add([]byte("a"), module, size)
+	_, ok := c.get([]byte("a"))
+	assert.False(t, ok)
+
+	// pinning works without a memory cache
+	c.pin([]byte("a"), module, size)
+	_, ok = c.get([]byte("a"))
+	assert.True(t, ok)
+	c.unpin([]byte("a"))
+	_, ok = c.get([]byte("a"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,66 @@
+package polywrapvm
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+const (
+	wasmExportSectionID = 7
+	wasmExportKindFunc  = 0
+	wrapInvokeExport    = "_wrap_invoke"
+)
+
+var wasmHeader = []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+
+// IsWrapper returns true when the wasm code exports the `_wrap_invoke` function every Polywrap wrapper has.
+// Only the export section is parsed, so the check is cheap and does not compile the code.
+func IsWrapper(code []byte) bool {
+	if !bytes.HasPrefix(code, wasmHeader) {
+		return false
+	}
+	r := bytes.NewReader(code[len(wasmHeader):])
+	for r.Len() > 0 {
+		id, err := r.ReadByte()
+		if err != nil {
+			return false
+		}
+		size, err := binary.ReadUvarint(r)
+		if err != nil || size > uint64(r.Len()) {
+			return false
+		}
+		section := make([]byte, size)
+		_, _ = r.Read(section)
+		if id == wasmExportSectionID {
+			return hasFuncExport(section, wrapInvokeExport)
+		}
+	}
+	return false
+}
+
+func hasFuncExport(section []byte, name string) bool {
+	r := bytes.NewReader(section)
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return false
+	}
+	for i := uint64(0); i < count; i++ {
+		nameLen, err := binary.ReadUvarint(r)
+		if err != nil || nameLen > uint64(r.Len()) {
+			return false
+		}
+		exportName := make([]byte, nameLen)
+		_, _ = r.Read(exportName)
+		kind, err := r.ReadByte()
+		if err != nil {
+			return false
+		}
+		if _, err := binary.ReadUvarint(r); err != nil {
+			return false
+		}
+		if kind == wasmExportKindFunc && string(exportName) == name {
+			return true
+		}
+	}
+	return false
+}
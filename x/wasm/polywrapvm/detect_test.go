@@ -0,0 +1,56 @@
+package polywrapvm
+
+import (
+	"os"
+	"testing"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsWrapper(t *testing.T) {
+	wrapper, err := os.ReadFile("../keeper/testdata/hello_world.wasm")
+	require.NoError(t, err)
+	cosmwasm, err := os.ReadFile("../keeper/testdata/hackatom.wasm")
+	require.NoError(t, err)
+	wat := func(src string) []byte {
+		code, err := wasmtime.Wat2Wasm(src)
+		require.NoError(t, err)
+		return code
+	}
+
+	specs := map[string]struct {
+		code []byte
+		exp  bool
+	}{
+		"wrapper": {
+			code: wrapper,
+			exp:  true,
+		},
+		"cosmwasm contract": {
+			code: cosmwasm,
+		},
+		"minimal wrapper": {
+			code: wat(`(module (func (export "_wrap_invoke") (param i32 i32 i32) (result i32) i32.const 1))`),
+			exp:  true,
+		},
+		"_wrap_invoke is not a function": {
+			code: wat(`(module (global (export "_wrap_invoke") i32 (i32.const 0)))`),
+		},
+		"no exports": {
+			code: wat(`(module)`),
+		},
+		"truncated": {
+			code: wrapper[:len(wasmHeader)+3],
+		},
+		"not wasm": {
+			code: []byte("foo"),
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, spec.exp, IsWrapper(spec.code))
+		})
+	}
+}
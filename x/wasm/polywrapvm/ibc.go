@@ -0,0 +1,302 @@
+package polywrapvm
+
+import (
+	"bytes"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	"github.com/CosmWasm/wasmvm/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/polywrap/go-client/msgpack"
+)
+
+// IBC entry points of a wrapper. A wrapper owns an IBC port when its manifest declares all of them.
+const (
+	methodIBCChannelOpen    = "ibcChannelOpen"
+	methodIBCChannelConnect = "ibcChannelConnect"
+	methodIBCChannelClose   = "ibcChannelClose"
+	methodIBCPacketReceive  = "ibcPacketReceive"
+	methodIBCPacketAck      = "ibcPacketAck"
+	methodIBCPacketTimeout  = "ibcPacketTimeout"
+)
+
+var ibcMethods = []string{
+	methodIBCChannelOpen,
+	methodIBCChannelConnect,
+	methodIBCChannelClose,
+	methodIBCPacketReceive,
+	methodIBCPacketAck,
+	methodIBCPacketTimeout,
+}
+
+// hasIBCEntryPoints returns true when the manifest declares every IBC entry point
+func (m Manifest) hasIBCEntryPoints() bool {
+	for _, name := range ibcMethods {
+		if _, ok := m.Method(name); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+type IBCEndpoint struct {
+	PortId    string
+	ChannelId string
+}
+
+type IBCChannel struct {
+	Endpoint             IBCEndpoint
+	CounterpartyEndpoint IBCEndpoint
+	// Order is "ORDER_UNORDERED" or "ORDER_ORDERED"
+	Order        string
+	Version      string
+	ConnectionId string
+}
+
+// IBCChannelOpenMsg is the argument of "ibcChannelOpen". CounterpartyVersion is nil for the
+// `Channel Open Init` step and set for `Channel Open Try`.
+type IBCChannelOpenMsg struct {
+	Channel             IBCChannel
+	CounterpartyVersion *string
+}
+
+// IBCChannelConnectMsg is the argument of "ibcChannelConnect". CounterpartyVersion is set for the
+// `Channel Open Ack` step and nil for `Channel Open Confirm`.
+type IBCChannelConnectMsg struct {
+	Channel             IBCChannel
+	CounterpartyVersion *string
+}
+
+// IBCChannelCloseMsg is the argument of "ibcChannelClose". Confirm is false for the
+// `Channel Close Init` step and true for `Channel Close Confirm`.
+type IBCChannelCloseMsg struct {
+	Channel IBCChannel
+	Confirm bool
+}
+
+type IBCPacket struct {
+	Data     []byte
+	Src      IBCEndpoint
+	Dest     IBCEndpoint
+	Sequence uint64
+	Timeout  IBCTimeout
+}
+
+// IBCTimeout holds the block height and the timestamp after which the packet times out. At least
+// one of them is set.
+type IBCTimeout struct {
+	Block *IBCTimeoutBlock
+	// Timestamp in nanoseconds since unix epoch
+	Timestamp *uint64
+}
+
+type IBCTimeoutBlock struct {
+	Revision uint64
+	Height   uint64
+}
+
+type IBCPacketReceiveMsg struct {
+	Packet IBCPacket
+	// Relayer is the bech32 address of the relayer submitting the packet
+	Relayer string
+}
+
+type IBCPacketAckMsg struct {
+	Acknowledgement []byte
+	OriginalPacket  IBCPacket
+	Relayer         string
+}
+
+type IBCPacketTimeoutMsg struct {
+	Packet  IBCPacket
+	Relayer string
+}
+
+// ibcArgs are the arguments of the IBC entry points, the message is passed as "msg"
+type ibcArgs[T any] struct {
+	Msg T
+}
+
+func newIBCChannel(c types.IBCChannel) IBCChannel {
+	return IBCChannel{
+		Endpoint:             newIBCEndpoint(c.Endpoint),
+		CounterpartyEndpoint: newIBCEndpoint(c.CounterpartyEndpoint),
+		Order:                c.Order,
+		Version:              c.Version,
+		ConnectionId:         c.ConnectionID,
+	}
+}
+
+func newIBCEndpoint(e types.IBCEndpoint) IBCEndpoint {
+	return IBCEndpoint{PortId: e.PortID, ChannelId: e.ChannelID}
+}
+
+func newIBCPacket(p types.IBCPacket) IBCPacket {
+	res := IBCPacket{
+		Data:     p.Data,
+		Src:      newIBCEndpoint(p.Src),
+		Dest:     newIBCEndpoint(p.Dest),
+		Sequence: p.Sequence,
+	}
+	if b := p.Timeout.Block; b != nil && !b.IsZero() {
+		res.Timeout.Block = &IBCTimeoutBlock{Revision: b.Revision, Height: b.Height}
+	}
+	if t := p.Timeout.Timestamp; t != 0 {
+		res.Timeout.Timestamp = &t
+	}
+	return res
+}
+
+// NewIBCChannelOpenMsg converts the wasmvm message into the wrapper schema
+func NewIBCChannelOpenMsg(msg types.IBCChannelOpenMsg) IBCChannelOpenMsg {
+	res := IBCChannelOpenMsg{Channel: newIBCChannel(msg.GetChannel())}
+	if version, ok := msg.GetCounterVersion(); ok {
+		res.CounterpartyVersion = &version
+	}
+	return res
+}
+
+// NewIBCChannelConnectMsg converts the wasmvm message into the wrapper schema
+func NewIBCChannelConnectMsg(msg types.IBCChannelConnectMsg) IBCChannelConnectMsg {
+	res := IBCChannelConnectMsg{Channel: newIBCChannel(msg.GetChannel())}
+	if version, ok := msg.GetCounterVersion(); ok {
+		res.CounterpartyVersion = &version
+	}
+	return res
+}
+
+// NewIBCChannelCloseMsg converts the wasmvm message into the wrapper schema
+func NewIBCChannelCloseMsg(msg types.IBCChannelCloseMsg) IBCChannelCloseMsg {
+	return IBCChannelCloseMsg{
+		Channel: newIBCChannel(msg.GetChannel()),
+		Confirm: msg.CloseConfirm != nil,
+	}
+}
+
+// NewIBCPacketReceiveMsg converts the wasmvm message into the wrapper schema
+func NewIBCPacketReceiveMsg(msg types.IBCPacketReceiveMsg) IBCPacketReceiveMsg {
+	return IBCPacketReceiveMsg{Packet: newIBCPacket(msg.Packet), Relayer: msg.Relayer}
+}
+
+// NewIBCPacketAckMsg converts the wasmvm message into the wrapper schema
+func NewIBCPacketAckMsg(msg types.IBCPacketAckMsg) IBCPacketAckMsg {
+	return IBCPacketAckMsg{
+		Acknowledgement: msg.Acknowledgement.Data,
+		OriginalPacket:  newIBCPacket(msg.OriginalPacket),
+		Relayer:         msg.Relayer,
+	}
+}
+
+// NewIBCPacketTimeoutMsg converts the wasmvm message into the wrapper schema
+func NewIBCPacketTimeoutMsg(msg types.IBCPacketTimeoutMsg) IBCPacketTimeoutMsg {
+	return IBCPacketTimeoutMsg{Packet: newIBCPacket(msg.Packet), Relayer: msg.Relayer}
+}
+
+// IBCChannelOpen invokes "ibcChannelOpen" in the channel handshake. The wrapper returns the accepted
+// channel version, nil or an empty version accept the proposed one. An error rejects the channel.
+// The open response has no events, so events emitted through the cosmos plugin are dropped.
+func (vm *VM) IBCChannelOpen(checksum wasmvm.Checksum, env types.Env, msg types.IBCChannelOpenMsg, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, _ wasmvm.GasMeter, gasLimit uint64, _ types.UFraction) (*types.IBC3ChannelOpenResponse, uint64, error) {
+	data, gasUsed, err := invokeIBC(vm, checksum, methodIBCChannelOpen, NewIBCChannelOpenMsg(msg), newFrame(env, nil, store, goapi, querier), gasLimit)
+	if err != nil {
+		return nil, gasUsed, err
+	}
+	res, err := decodeChannelVersion(data)
+	if err != nil {
+		return nil, gasUsed, sdkerrors.Wrap(err, "unable to decode ibc channel open result")
+	}
+	return res, gasUsed, nil
+}
+
+// IBCChannelConnect invokes "ibcChannelConnect" once the channel is established
+func (vm *VM) IBCChannelConnect(checksum wasmvm.Checksum, env types.Env, msg types.IBCChannelConnectMsg, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, _ wasmvm.GasMeter, gasLimit uint64, _ types.UFraction) (*types.IBCBasicResponse, uint64, error) {
+	call := newFrame(env, nil, store, goapi, querier)
+	data, gasUsed, err := invokeIBC(vm, checksum, methodIBCChannelConnect, NewIBCChannelConnectMsg(msg), call, gasLimit)
+	return decodeBasicResponse(data, call.events, gasUsed, err, "ibc channel connect")
+}
+
+// IBCChannelClose invokes "ibcChannelClose" when the channel is closed
+func (vm *VM) IBCChannelClose(checksum wasmvm.Checksum, env types.Env, msg types.IBCChannelCloseMsg, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, _ wasmvm.GasMeter, gasLimit uint64, _ types.UFraction) (*types.IBCBasicResponse, uint64, error) {
+	call := newFrame(env, nil, store, goapi, querier)
+	data, gasUsed, err := invokeIBC(vm, checksum, methodIBCChannelClose, NewIBCChannelCloseMsg(msg), call, gasLimit)
+	return decodeBasicResponse(data, call.events, gasUsed, err, "ibc channel close")
+}
+
+// IBCPacketReceive invokes "ibcPacketReceive" with a packet sent to the contract port. The data of
+// the wrapper response is returned as the acknowledgement of the packet.
+func (vm *VM) IBCPacketReceive(checksum wasmvm.Checksum, env types.Env, msg types.IBCPacketReceiveMsg, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, _ wasmvm.GasMeter, gasLimit uint64, _ types.UFraction) (*types.IBCReceiveResult, uint64, error) {
+	call := newFrame(env, nil, store, goapi, querier)
+	data, gasUsed, err := invokeIBC(vm, checksum, methodIBCPacketReceive, NewIBCPacketReceiveMsg(msg), call, gasLimit)
+	if err != nil {
+		return nil, gasUsed, err
+	}
+	res, err := decodeResponse(data)
+	if err != nil {
+		return nil, gasUsed, sdkerrors.Wrap(err, "unable to decode ibc packet receive result")
+	}
+	call.events.addTo(res)
+	return &types.IBCReceiveResult{Ok: &types.IBCReceiveResponse{
+		Acknowledgement: res.Data,
+		Messages:        res.Messages,
+		Attributes:      res.Attributes,
+		Events:          res.Events,
+	}}, gasUsed, nil
+}
+
+// IBCPacketAck invokes "ibcPacketAck" with the acknowledgement of a packet sent by the contract
+func (vm *VM) IBCPacketAck(checksum wasmvm.Checksum, env types.Env, msg types.IBCPacketAckMsg, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, _ wasmvm.GasMeter, gasLimit uint64, _ types.UFraction) (*types.IBCBasicResponse, uint64, error) {
+	call := newFrame(env, nil, store, goapi, querier)
+	data, gasUsed, err := invokeIBC(vm, checksum, methodIBCPacketAck, NewIBCPacketAckMsg(msg), call, gasLimit)
+	return decodeBasicResponse(data, call.events, gasUsed, err, "ibc packet ack")
+}
+
+// IBCPacketTimeout invokes "ibcPacketTimeout" for a packet sent by the contract that timed out
+func (vm *VM) IBCPacketTimeout(checksum wasmvm.Checksum, env types.Env, msg types.IBCPacketTimeoutMsg, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, _ wasmvm.GasMeter, gasLimit uint64, _ types.UFraction) (*types.IBCBasicResponse, uint64, error) {
+	call := newFrame(env, nil, store, goapi, querier)
+	data, gasUsed, err := invokeIBC(vm, checksum, methodIBCPacketTimeout, NewIBCPacketTimeoutMsg(msg), call, gasLimit)
+	return decodeBasicResponse(data, call.events, gasUsed, err, "ibc packet timeout")
+}
+
+// invokeIBC invokes the IBC entry point with the message as "msg" argument
+func invokeIBC[T any](vm *VM, checksum wasmvm.Checksum, method string, msg T, call frame, gasLimit uint64) ([]byte, uint64, error) {
+	module, err := vm.module(checksum)
+	if err != nil {
+		return nil, 0, err
+	}
+	args, err := msgpack.Encode(ibcArgs[T]{Msg: msg})
+	if err != nil {
+		return nil, 0, sdkerrors.Wrapf(err, "unable to encode %s message", method)
+	}
+	return vm.invoke(module, method, args, call, gasLimit)
+}
+
+// decodeBasicResponse converts the wrapper response with the events of the invocation into the IBC basic
+// response. The response data has no meaning for these entry points and is dropped.
+func decodeBasicResponse(data []byte, events *eventLog, gasUsed uint64, err error, step string) (*types.IBCBasicResponse, uint64, error) {
+	if err != nil {
+		return nil, gasUsed, err
+	}
+	res, err := decodeResponse(data)
+	if err != nil {
+		return nil, gasUsed, sdkerrors.Wrapf(err, "unable to decode %s result", step)
+	}
+	events.addTo(res)
+	return &types.IBCBasicResponse{
+		Messages:   res.Messages,
+		Attributes: res.Attributes,
+		Events:     res.Events,
+	}, gasUsed, nil
+}
+
+// decodeChannelVersion decodes the optional String result of "ibcChannelOpen"
+func decodeChannelVersion(data []byte) (*types.IBC3ChannelOpenResponse, error) {
+	if len(data) == 0 || bytes.Equal(data, []byte{0xc0}) {
+		return nil, nil
+	}
+	version, err := decode[string](data)
+	if err != nil {
+		return nil, err
+	}
+	if version == "" {
+		return nil, nil
+	}
+	return &types.IBC3ChannelOpenResponse{Version: version}, nil
+}
@@ -0,0 +1,127 @@
+package polywrapvm
+
+import (
+	"os"
+	"testing"
+
+	"github.com/CosmWasm/wasmvm/types"
+	"github.com/bytecodealliance/wasmtime-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVMAnalyzeCode(t *testing.T) {
+	wat, err := os.ReadFile("../keeper/testdata/ibc_recorder.wat")
+	require.NoError(t, err)
+	ibcCode, err := wasmtime.Wat2Wasm(string(wat))
+	require.NoError(t, err)
+	ibcManifest, err := os.ReadFile("../keeper/testdata/ibc_recorder.wrap.info")
+	require.NoError(t, err)
+	helloWorldCode, err := os.ReadFile("../keeper/testdata/hello_world.wasm")
+	require.NoError(t, err)
+	helloWorldManifest, err := os.ReadFile("../keeper/testdata/hello_world.wrap.info")
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		code     []byte
+		manifest []byte
+		expIBC   bool
+	}{
+		"all ibc methods":     {code: ibcCode, manifest: ibcManifest, expIBC: true},
+		"without ibc methods": {code: helloWorldCode, manifest: helloWorldManifest},
+		"without manifest":    {code: ibcCode},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			vm, err := NewVM(t.TempDir(), "", 32, 0)
+			require.NoError(t, err)
+			checksum, err := vm.Create(spec.code)
+			require.NoError(t, err)
+			report, err := vm.WithManifest(spec.manifest).AnalyzeCode(checksum)
+			require.NoError(t, err)
+			assert.Equal(t, spec.expIBC, report.HasIBCEntryPoints)
+		})
+	}
+}
+
+func TestManifestHasIBCEntryPoints(t *testing.T) {
+	m := Manifest{ABI: ABI{Module: &ModuleDefinition{}}}
+	for _, name := range ibcMethods {
+		assert.False(t, m.hasIBCEntryPoints())
+		m.ABI.Module.Methods = append(m.ABI.Module.Methods, MethodDefinition{Name: name})
+	}
+	assert.True(t, m.hasIBCEntryPoints())
+}
+
+func TestNewIBCMsgs(t *testing.T) {
+	channel := types.IBCChannel{
+		Endpoint:             types.IBCEndpoint{PortID: "wasm.cosmos1contract", ChannelID: "channel-0"},
+		CounterpartyEndpoint: types.IBCEndpoint{PortID: "transfer", ChannelID: "channel-1"},
+		Order:                types.Unordered,
+		Version:              "v1",
+		ConnectionID:         "connection-0",
+	}
+	expChannel := IBCChannel{
+		Endpoint:             IBCEndpoint{PortId: "wasm.cosmos1contract", ChannelId: "channel-0"},
+		CounterpartyEndpoint: IBCEndpoint{PortId: "transfer", ChannelId: "channel-1"},
+		Order:                "ORDER_UNORDERED",
+		Version:              "v1",
+		ConnectionId:         "connection-0",
+	}
+	version := "v2"
+
+	assert.Equal(t, IBCChannelOpenMsg{Channel: expChannel}, NewIBCChannelOpenMsg(types.IBCChannelOpenMsg{OpenInit: &types.IBCOpenInit{Channel: channel}}))
+	assert.Equal(t, IBCChannelOpenMsg{Channel: expChannel, CounterpartyVersion: &version}, NewIBCChannelOpenMsg(types.IBCChannelOpenMsg{OpenTry: &types.IBCOpenTry{Channel: channel, CounterpartyVersion: version}}))
+	assert.Equal(t, IBCChannelConnectMsg{Channel: expChannel, CounterpartyVersion: &version}, NewIBCChannelConnectMsg(types.IBCChannelConnectMsg{OpenAck: &types.IBCOpenAck{Channel: channel, CounterpartyVersion: version}}))
+	assert.Equal(t, IBCChannelConnectMsg{Channel: expChannel}, NewIBCChannelConnectMsg(types.IBCChannelConnectMsg{OpenConfirm: &types.IBCOpenConfirm{Channel: channel}}))
+	assert.Equal(t, IBCChannelCloseMsg{Channel: expChannel}, NewIBCChannelCloseMsg(types.IBCChannelCloseMsg{CloseInit: &types.IBCCloseInit{Channel: channel}}))
+	assert.Equal(t, IBCChannelCloseMsg{Channel: expChannel, Confirm: true}, NewIBCChannelCloseMsg(types.IBCChannelCloseMsg{CloseConfirm: &types.IBCCloseConfirm{Channel: channel}}))
+
+	packet := types.IBCPacket{
+		Data:     []byte("data"),
+		Src:      channel.Endpoint,
+		Dest:     channel.CounterpartyEndpoint,
+		Sequence: 1,
+		Timeout:  types.IBCTimeout{Block: &types.IBCTimeoutBlock{Revision: 1, Height: 100}},
+	}
+	expPacket := IBCPacket{
+		Data:     []byte("data"),
+		Src:      expChannel.Endpoint,
+		Dest:     expChannel.CounterpartyEndpoint,
+		Sequence: 1,
+		Timeout:  IBCTimeout{Block: &IBCTimeoutBlock{Revision: 1, Height: 100}},
+	}
+	assert.Equal(t, IBCPacketReceiveMsg{Packet: expPacket, Relayer: "cosmos1relayer"}, NewIBCPacketReceiveMsg(types.IBCPacketReceiveMsg{Packet: packet, Relayer: "cosmos1relayer"}))
+	assert.Equal(t, IBCPacketAckMsg{Acknowledgement: []byte("ack"), OriginalPacket: expPacket, Relayer: "cosmos1relayer"}, NewIBCPacketAckMsg(types.IBCPacketAckMsg{Acknowledgement: types.IBCAcknowledgement{Data: []byte("ack")}, OriginalPacket: packet, Relayer: "cosmos1relayer"}))
+
+	// zero timeouts are not set
+	packet.Timeout = types.IBCTimeout{Block: &types.IBCTimeoutBlock{}, Timestamp: 1_000}
+	timestamp := uint64(1_000)
+	expPacket.Timeout = IBCTimeout{Timestamp: &timestamp}
+	assert.Equal(t, IBCPacketTimeoutMsg{Packet: expPacket, Relayer: "cosmos1relayer"}, NewIBCPacketTimeoutMsg(types.IBCPacketTimeoutMsg{Packet: packet, Relayer: "cosmos1relayer"}))
+}
+
+func TestDecodeChannelVersion(t *testing.T) {
+	specs := map[string]struct {
+		data   []byte
+		exp    *types.IBC3ChannelOpenResponse
+		expErr bool
+	}{
+		"version":       {data: encodeMsgpack("v2"), exp: &types.IBC3ChannelOpenResponse{Version: "v2"}},
+		"nil":           {data: encodeMsgpack(nil)},
+		"empty version": {data: encodeMsgpack("")},
+		"no result":     {},
+		"not a string":  {data: encodeMsgpack(uint64(1)), expErr: true},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			res, err := decodeChannelVersion(spec.data)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, res)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package polywrapvm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/CosmWasm/wasmvm/types"
+	"github.com/bytecodealliance/wasmtime-go"
+	"github.com/polywrap/go-client/wasm/uri"
+)
+
+// hostImports are the functions of the "wrap" host module defined by defineImports
+var hostImports = map[string]bool{
+	"__wrap_load_env":             true,
+	"__wrap_invoke_args":          true,
+	"__wrap_invoke_result":        true,
+	"__wrap_invoke_error":         true,
+	"__wrap_abort":                true,
+	"__wrap_subinvoke":            true,
+	"__wrap_subinvoke_result_len": true,
+	"__wrap_subinvoke_result":     true,
+	"__wrap_subinvoke_error_len":  true,
+	"__wrap_subinvoke_error":      true,
+}
+
+// defineImports registers the polywrap "wrap" host module and the shared memory on the linker
+func defineImports(linker *wasmtime.Linker, store *wasmtime.Store, memory *wasmtime.Memory, state *invocationState, invoker Subinvoker) error {
+	read := func(ptr, length int32) ([]byte, *wasmtime.Trap) {
+		mem := memory.UnsafeData(store)
+		if ptr < 0 || length < 0 || int64(ptr)+int64(length) > int64(len(mem)) {
+			return nil, wasmtime.NewTrap("memory access out of bounds")
+		}
+		return append([]byte(nil), mem[ptr:ptr+length]...), nil
+	}
+	write := func(ptr int32, data []byte) *wasmtime.Trap {
+		mem := memory.UnsafeData(store)
+		if ptr < 0 || int64(ptr)+int64(len(data)) > int64(len(mem)) {
+			return wasmtime.NewTrap("memory access out of bounds")
+		}
+		copy(mem[ptr:], data)
+		return nil
+	}
+
+	imports := map[string]interface{}{
+		"__wrap_load_env": func(ptr int32) *wasmtime.Trap {
+			return write(ptr, state.env)
+		},
+		"__wrap_invoke_args": func(methodPtr, argsPtr int32) *wasmtime.Trap {
+			if trap := write(methodPtr, state.method); trap != nil {
+				return trap
+			}
+			return write(argsPtr, state.args)
+		},
+		"__wrap_invoke_result": func(ptr, length int32) *wasmtime.Trap {
+			var trap *wasmtime.Trap
+			state.result, trap = read(ptr, length)
+			return trap
+		},
+		"__wrap_invoke_error": func(ptr, length int32) *wasmtime.Trap {
+			var trap *wasmtime.Trap
+			state.invokeError, trap = read(ptr, length)
+			return trap
+		},
+		"__wrap_abort": func(msgPtr, msgLen, filePtr, fileLen, line, column int32) *wasmtime.Trap {
+			msg, trap := read(msgPtr, msgLen)
+			if trap != nil {
+				return trap
+			}
+			file, trap := read(filePtr, fileLen)
+			if trap != nil {
+				return trap
+			}
+			return wasmtime.NewTrap(fmt.Sprintf("__wrap_abort: %s\nFile: %s\nLocation: [%d,%d]", msg, file, line, column))
+		},
+		"__wrap_subinvoke": func(uriPtr, uriLen, methodPtr, methodLen, argsPtr, argsLen int32) (int32, *wasmtime.Trap) {
+			rawURI, trap := read(uriPtr, uriLen)
+			if trap != nil {
+				return 0, trap
+			}
+			method, trap := read(methodPtr, methodLen)
+			if trap != nil {
+				return 0, trap
+			}
+			args, trap := read(argsPtr, argsLen)
+			if trap != nil {
+				return 0, trap
+			}
+			state.subinvokeResult, state.subinvokeError = nil, nil
+			if metered, ok := invoker.(meteredSubinvoker); ok {
+				res, err := invokeMetered(store, metered, string(rawURI), string(method), args)
+				if err == errOutOfFuel {
+					return 0, wasmtime.NewTrap(err.Error())
+				}
+				if err != nil {
+					state.subinvokeError = []byte(err.Error())
+					return 0, nil
+				}
+				state.subinvokeResult = res
+				return 1, nil
+			}
+			wrapURI, err := uri.New(string(rawURI))
+			if err != nil {
+				state.subinvokeError = []byte(err.Error())
+				return 0, nil
+			}
+			res, err := invoker.Invoke(*wrapURI, string(method), args, nil)
+			if err != nil {
+				state.subinvokeError = []byte(err.Error())
+				return 0, nil
+			}
+			state.subinvokeResult = res
+			return 1, nil
+		},
+		"__wrap_subinvoke_result_len": func() int32 {
+			return int32(len(state.subinvokeResult))
+		},
+		"__wrap_subinvoke_result": func(ptr int32) *wasmtime.Trap {
+			return write(ptr, state.subinvokeResult)
+		},
+		"__wrap_subinvoke_error_len": func() int32 {
+			return int32(len(state.subinvokeError))
+		},
+		"__wrap_subinvoke_error": func(ptr int32) *wasmtime.Trap {
+			return write(ptr, state.subinvokeError)
+		},
+	}
+	for name, fn := range imports {
+		if err := linker.FuncWrap("wrap", name, fn); err != nil {
+			return err
+		}
+	}
+	return linker.Define("env", "memory", memory)
+}
+
+// meteredSubinvoker is a Subinvoker that runs subinvocations with the remaining gas of the caller
+type meteredSubinvoker interface {
+	InvokeMetered(uri, method string, args []byte, gasLimit uint64) ([]byte, uint64, error)
+}
+
+var errOutOfFuel = errors.New("out of gas in subinvocation")
+
+// invokeMetered passes the remaining fuel of the caller as gas limit to the subinvocation and
+// consumes the fuel of the gas used afterwards. A subinvocation running out of gas aborts the caller.
+func invokeMetered(store *wasmtime.Store, invoker meteredSubinvoker, rawURI, method string, args []byte) ([]byte, error) {
+	remaining, err := store.ConsumeFuel(0)
+	if err != nil {
+		return nil, err
+	}
+	res, gasUsed, err := invoker.InvokeMetered(rawURI, method, args, remaining*gasPerInstruction)
+	fuel := (gasUsed + gasPerInstruction - 1) / gasPerInstruction
+	var outOfGas types.OutOfGasError
+	if fuel >= remaining || errors.As(err, &outOfGas) {
+		_, _ = store.ConsumeFuel(remaining)
+		return nil, errOutOfFuel
+	}
+	if _, fuelErr := store.ConsumeFuel(fuel); fuelErr != nil {
+		return nil, fuelErr
+	}
+	return res, err
+}
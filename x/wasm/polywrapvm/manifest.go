@@ -0,0 +1,340 @@
+package polywrapvm
+
+import (
+	"fmt"
+)
+
+// ManifestType is the only wrap.info type that can be stored on chain
+const ManifestType = "wasm"
+
+// supportedManifestVersions are the wrap.info and ABI versions the chain understands
+var supportedManifestVersions = map[string]bool{"0.1": true, "0.1.0": true}
+
+// Manifest is the decoded wrap.info of a wrapper. Only the parts of the ABI the chain uses are kept.
+type Manifest struct {
+	Version string `json:"version"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	ABI     ABI    `json:"abi"`
+}
+
+// ABI describes the module methods and the custom types they use
+type ABI struct {
+	Version     string             `json:"version"`
+	Module      *ModuleDefinition  `json:"moduleType,omitempty"`
+	ObjectTypes []ObjectDefinition `json:"objectTypes,omitempty"`
+	EnumTypes   []EnumDefinition   `json:"enumTypes,omitempty"`
+}
+
+// ModuleDefinition lists the methods a wrapper can be invoked with
+type ModuleDefinition struct {
+	Methods []MethodDefinition `json:"methods"`
+}
+
+// MethodDefinition is a wrapper method with its named arguments
+type MethodDefinition struct {
+	Name      string               `json:"name"`
+	Arguments []PropertyDefinition `json:"arguments,omitempty"`
+	Return    *PropertyDefinition  `json:"return,omitempty"`
+}
+
+// PropertyDefinition is a method argument, return value or object property. Type is the GraphQL
+// type name used by the wrapper schema, e.g. `String`, `[UInt32]` or `Map<String, Int>`.
+type PropertyDefinition struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+}
+
+// ObjectDefinition is a custom object type of the wrapper schema
+type ObjectDefinition struct {
+	Type       string               `json:"type"`
+	Properties []PropertyDefinition `json:"properties,omitempty"`
+}
+
+// EnumDefinition is a custom enum type of the wrapper schema
+type EnumDefinition struct {
+	Type      string   `json:"type"`
+	Constants []string `json:"constants"`
+}
+
+// DecodeManifest decodes a msgpack encoded wrap.info and validates it
+func DecodeManifest(bz []byte) (*Manifest, error) {
+	if len(bz) == 0 {
+		return nil, fmt.Errorf("empty manifest")
+	}
+	v, err := decodeMsgpack(bz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest encoding: %w", err)
+	}
+	root, err := asMap(v, "manifest")
+	if err != nil {
+		return nil, err
+	}
+	m, err := manifestFromMap(root)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ValidateBasic checks the manifest version and type and the module and method definitions
+func (m Manifest) ValidateBasic() error {
+	if !supportedManifestVersions[m.Version] {
+		return fmt.Errorf("unsupported manifest version %q", m.Version)
+	}
+	if m.Type != ManifestType {
+		return fmt.Errorf("manifest type %q is not a %s wrapper", m.Type, ManifestType)
+	}
+	if m.Name == "" {
+		return fmt.Errorf("empty manifest name")
+	}
+	if !supportedManifestVersions[m.ABI.Version] {
+		return fmt.Errorf("unsupported abi version %q", m.ABI.Version)
+	}
+	if m.ABI.Module == nil || len(m.ABI.Module.Methods) == 0 {
+		return fmt.Errorf("abi has no module methods")
+	}
+	methods := make(map[string]bool, len(m.ABI.Module.Methods))
+	for _, method := range m.ABI.Module.Methods {
+		if method.Name == "" {
+			return fmt.Errorf("empty method name")
+		}
+		if methods[method.Name] {
+			return fmt.Errorf("duplicate method %q", method.Name)
+		}
+		methods[method.Name] = true
+		if err := validateProperties(method.Arguments); err != nil {
+			return fmt.Errorf("method %q: %w", method.Name, err)
+		}
+		if method.Return == nil || method.Return.Type == "" {
+			return fmt.Errorf("method %q: missing return type", method.Name)
+		}
+	}
+	types := make(map[string]bool, len(m.ABI.ObjectTypes)+len(m.ABI.EnumTypes))
+	for _, o := range m.ABI.ObjectTypes {
+		if o.Type == "" || types[o.Type] {
+			return fmt.Errorf("invalid or duplicate object type %q", o.Type)
+		}
+		types[o.Type] = true
+		if err := validateProperties(o.Properties); err != nil {
+			return fmt.Errorf("object %q: %w", o.Type, err)
+		}
+	}
+	for _, e := range m.ABI.EnumTypes {
+		if e.Type == "" || types[e.Type] {
+			return fmt.Errorf("invalid or duplicate enum type %q", e.Type)
+		}
+		types[e.Type] = true
+		if len(e.Constants) == 0 {
+			return fmt.Errorf("enum %q has no constants", e.Type)
+		}
+	}
+	return nil
+}
+
+func validateProperties(props []PropertyDefinition) error {
+	names := make(map[string]bool, len(props))
+	for _, p := range props {
+		if p.Name == "" {
+			return fmt.Errorf("empty property name")
+		}
+		if names[p.Name] {
+			return fmt.Errorf("duplicate property %q", p.Name)
+		}
+		names[p.Name] = true
+		if p.Type == "" {
+			return fmt.Errorf("property %q: empty type", p.Name)
+		}
+	}
+	return nil
+}
+
+func manifestFromMap(root map[string]any) (*Manifest, error) {
+	var (
+		m   Manifest
+		err error
+	)
+	if m.Version, err = optString(root, "version"); err != nil {
+		return nil, err
+	}
+	if m.Type, err = optString(root, "type"); err != nil {
+		return nil, err
+	}
+	if m.Name, err = optString(root, "name"); err != nil {
+		return nil, err
+	}
+	abi, err := optMap(root, "abi")
+	if err != nil || abi == nil {
+		return &m, err
+	}
+	if m.ABI.Version, err = optString(abi, "version"); err != nil {
+		return nil, err
+	}
+
+	module, err := optMap(abi, "moduleType")
+	if err != nil {
+		return nil, err
+	}
+	if module != nil {
+		m.ABI.Module = &ModuleDefinition{}
+		err = forEachMap(module, "methods", func(method map[string]any) error {
+			name, err := optString(method, "name")
+			if err != nil {
+				return err
+			}
+			def := MethodDefinition{Name: name}
+			if def.Arguments, err = properties(method, "arguments"); err != nil {
+				return err
+			}
+			ret, err := optMap(method, "return")
+			if err != nil {
+				return err
+			}
+			if ret != nil {
+				p, err := property(ret)
+				if err != nil {
+					return err
+				}
+				def.Return = &p
+			}
+			m.ABI.Module.Methods = append(m.ABI.Module.Methods, def)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = forEachMap(abi, "objectTypes", func(object map[string]any) error {
+		name, err := optString(object, "type")
+		if err != nil {
+			return err
+		}
+		props, err := properties(object, "properties")
+		if err != nil {
+			return err
+		}
+		m.ABI.ObjectTypes = append(m.ABI.ObjectTypes, ObjectDefinition{Type: name, Properties: props})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = forEachMap(abi, "enumTypes", func(enum map[string]any) error {
+		name, err := optString(enum, "type")
+		if err != nil {
+			return err
+		}
+		def := EnumDefinition{Type: name}
+		constants, err := optList(enum, "constants")
+		if err != nil {
+			return err
+		}
+		for _, c := range constants {
+			s, ok := c.(string)
+			if !ok {
+				return fmt.Errorf("enum %q: constant must be a string", def.Type)
+			}
+			def.Constants = append(def.Constants, s)
+		}
+		m.ABI.EnumTypes = append(m.ABI.EnumTypes, def)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func properties(m map[string]any, key string) ([]PropertyDefinition, error) {
+	var res []PropertyDefinition
+	err := forEachMap(m, key, func(prop map[string]any) error {
+		p, err := property(prop)
+		if err != nil {
+			return err
+		}
+		res = append(res, p)
+		return nil
+	})
+	return res, err
+}
+
+func property(m map[string]any) (PropertyDefinition, error) {
+	var (
+		p   PropertyDefinition
+		err error
+	)
+	if p.Name, err = optString(m, "name"); err != nil {
+		return p, err
+	}
+	if p.Type, err = optString(m, "type"); err != nil {
+		return p, err
+	}
+	switch v := m["required"].(type) {
+	case nil:
+	case bool:
+		p.Required = v
+	default:
+		return p, fmt.Errorf("%q: required must be a bool", p.Name)
+	}
+	return p, nil
+}
+
+func forEachMap(m map[string]any, key string, cb func(map[string]any) error) error {
+	list, err := optList(m, key)
+	if err != nil {
+		return err
+	}
+	for _, v := range list {
+		item, err := asMap(v, key)
+		if err != nil {
+			return err
+		}
+		if err := cb(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func asMap(v any, name string) (map[string]any, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a map", name)
+	}
+	return m, nil
+}
+
+func optMap(m map[string]any, key string) (map[string]any, error) {
+	if m[key] == nil {
+		return nil, nil
+	}
+	return asMap(m[key], key)
+}
+
+func optList(m map[string]any, key string) ([]any, error) {
+	if m[key] == nil {
+		return nil, nil
+	}
+	l, ok := m[key].([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be an array", key)
+	}
+	return l, nil
+}
+
+func optString(m map[string]any, key string) (string, error) {
+	if m[key] == nil {
+		return "", nil
+	}
+	s, ok := m[key].(string)
+	if !ok {
+		return "", fmt.Errorf("%s must be a string", key)
+	}
+	return s, nil
+}
@@ -0,0 +1,251 @@
+package polywrapvm
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeManifest(t *testing.T) {
+	specs := map[string]struct {
+		mutate func(m map[string]any)
+		expErr bool
+	}{
+		"valid": {
+			mutate: func(m map[string]any) {},
+		},
+		"semver version": {
+			mutate: func(m map[string]any) { m["version"] = "0.1.0" },
+		},
+		"unknown keys ignored": {
+			mutate: func(m map[string]any) {
+				m["extra"] = []any{1, -1, 1.5, nil, []byte{1}}
+				abi(m)["importedModuleTypes"] = []any{}
+			},
+		},
+		"unsupported version": {
+			mutate: func(m map[string]any) { m["version"] = "0.2" },
+			expErr: true,
+		},
+		"not a wasm wrapper": {
+			mutate: func(m map[string]any) { m["type"] = "interface" },
+			expErr: true,
+		},
+		"missing name": {
+			mutate: func(m map[string]any) { delete(m, "name") },
+			expErr: true,
+		},
+		"missing abi": {
+			mutate: func(m map[string]any) { delete(m, "abi") },
+			expErr: true,
+		},
+		"unsupported abi version": {
+			mutate: func(m map[string]any) { abi(m)["version"] = "1" },
+			expErr: true,
+		},
+		"missing module": {
+			mutate: func(m map[string]any) { delete(abi(m), "moduleType") },
+			expErr: true,
+		},
+		"no methods": {
+			mutate: func(m map[string]any) { abi(m)["moduleType"].(map[string]any)["methods"] = []any{} },
+			expErr: true,
+		},
+		"duplicate method": {
+			mutate: func(m map[string]any) {
+				module := abi(m)["moduleType"].(map[string]any)
+				module["methods"] = append(module["methods"].([]any), testMethod("sayHello", "String"))
+			},
+			expErr: true,
+		},
+		"duplicate argument": {
+			mutate: func(m map[string]any) {
+				method := abi(m)["moduleType"].(map[string]any)["methods"].([]any)[0].(map[string]any)
+				method["arguments"] = append(method["arguments"].([]any), testProperty("newName", "Int", false))
+			},
+			expErr: true,
+		},
+		"argument without type": {
+			mutate: func(m map[string]any) {
+				method := abi(m)["moduleType"].(map[string]any)["methods"].([]any)[0].(map[string]any)
+				method["arguments"] = []any{testProperty("name", "", true)}
+			},
+			expErr: true,
+		},
+		"missing return": {
+			mutate: func(m map[string]any) {
+				delete(abi(m)["moduleType"].(map[string]any)["methods"].([]any)[0].(map[string]any), "return")
+			},
+			expErr: true,
+		},
+		"name is not a string": {
+			mutate: func(m map[string]any) { m["name"] = 1 },
+			expErr: true,
+		},
+		"methods is not an array": {
+			mutate: func(m map[string]any) { abi(m)["moduleType"].(map[string]any)["methods"] = "sayHello" },
+			expErr: true,
+		},
+		"duplicate custom type": {
+			mutate: func(m map[string]any) {
+				abi(m)["enumTypes"] = []any{map[string]any{"type": "Person", "constants": []any{"A"}}}
+			},
+			expErr: true,
+		},
+		"enum without constants": {
+			mutate: func(m map[string]any) {
+				abi(m)["enumTypes"] = []any{map[string]any{"type": "Color"}}
+			},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			m := testManifest()
+			spec.mutate(m)
+			got, err := DecodeManifest(encodeMsgpack(m))
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "hello-world", got.Name)
+			require.NotNil(t, got.ABI.Module)
+			assert.Equal(t, []MethodDefinition{
+				{
+					Name:      "updateName",
+					Arguments: []PropertyDefinition{{Name: "newName", Type: "String", Required: true}},
+					Return:    &PropertyDefinition{Name: "updateName", Type: "String", Required: true},
+				},
+				{
+					Name:   "sayHello",
+					Return: &PropertyDefinition{Name: "sayHello", Type: "String", Required: true},
+				},
+			}, got.ABI.Module.Methods)
+			assert.Equal(t, []ObjectDefinition{{
+				Type: "Person",
+				Properties: []PropertyDefinition{
+					{Name: "name", Type: "String", Required: true},
+					{Name: "friends", Type: "[String]"},
+				},
+			}}, got.ABI.ObjectTypes)
+		})
+	}
+}
+
+func TestDecodeManifestMalformed(t *testing.T) {
+	valid := encodeMsgpack(testManifest())
+	specs := map[string][]byte{
+		"empty":              nil,
+		"not a map":          encodeMsgpack("manifest"),
+		"truncated":          valid[:len(valid)/2],
+		"trailing bytes":     append(append([]byte{}, valid...), 0xc0),
+		"non string map key": {0x81, 0x01, 0x01},
+		"huge array length":  {0xdd, 0xff, 0xff, 0xff, 0xff},
+		"unsupported format": {0xc1},
+	}
+	for name, bz := range specs {
+		t.Run(name, func(t *testing.T) {
+			_, err := DecodeManifest(bz)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDecodeMsgpack(t *testing.T) {
+	specs := map[string]struct {
+		src []byte
+		exp any
+	}{
+		"positive fixint": {src: []byte{0x7f}, exp: int64(127)},
+		"negative fixint": {src: []byte{0xff}, exp: int64(-1)},
+		"uint16":          {src: []byte{0xcd, 0x01, 0x00}, exp: uint64(256)},
+		"int8":            {src: []byte{0xd0, 0x80}, exp: int64(-128)},
+		"int32":           {src: []byte{0xd2, 0xff, 0xff, 0xff, 0xfe}, exp: int64(-2)},
+		"float64":         {src: encodeMsgpack(1.5), exp: 1.5},
+		"str8":            {src: []byte{0xd9, 0x01, 'a'}, exp: "a"},
+		"bin8":            {src: []byte{0xc4, 0x02, 0x01, 0x02}, exp: []byte{1, 2}},
+		"array16":         {src: []byte{0xdc, 0x00, 0x01, 0xc3}, exp: []any{true}},
+		"map16":           {src: []byte{0xde, 0x00, 0x01, 0xa1, 'k', 0xc0}, exp: map[string]any{"k": nil}},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, err := decodeMsgpack(spec.src)
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestDecodeMsgpackMaxDepth(t *testing.T) {
+	var v any = "leaf"
+	for i := 0; i < maxManifestDepth+1; i++ {
+		v = []any{v}
+	}
+	_, err := decodeMsgpack(encodeMsgpack(v))
+	require.Error(t, err)
+}
+
+// TestHelloWorldManifest ensures the keeper testdata manifest matches the hello world wrapper
+func TestHelloWorldManifest(t *testing.T) {
+	bz, err := os.ReadFile("../keeper/testdata/hello_world.wrap.info")
+	require.NoError(t, err)
+	m, err := DecodeManifest(bz)
+	require.NoError(t, err)
+	var methods []string
+	for _, method := range m.ABI.Module.Methods {
+		methods = append(methods, method.Name)
+	}
+	assert.Equal(t, []string{"init", "updateName", "sayHello"}, methods)
+}
+
+func testManifest() map[string]any {
+	updateName := testMethod("updateName", "String")
+	updateName["arguments"] = []any{testProperty("newName", "String", true)}
+	return map[string]any{
+		"version": "0.1",
+		"type":    "wasm",
+		"name":    "hello-world",
+		"abi": map[string]any{
+			"version": "0.1",
+			"moduleType": map[string]any{
+				"type":    "Module",
+				"kind":    128,
+				"methods": []any{updateName, testMethod("sayHello", "String")},
+			},
+			"objectTypes": []any{map[string]any{
+				"type": "Person",
+				"kind": 1,
+				"properties": []any{
+					testProperty("name", "String", true),
+					testProperty("friends", "[String]", false),
+				},
+			}},
+		},
+	}
+}
+
+func abi(m map[string]any) map[string]any {
+	return m["abi"].(map[string]any)
+}
+
+func testMethod(name, returnType string) map[string]any {
+	return map[string]any{
+		"type":     "Method",
+		"kind":     64,
+		"name":     name,
+		"required": true,
+		"return":   testProperty(name, returnType, true),
+	}
+}
+
+func testProperty(name, typ string, required bool) map[string]any {
+	return map[string]any{
+		"type":     typ,
+		"kind":     34,
+		"name":     name,
+		"required": required,
+	}
+}
@@ -0,0 +1,301 @@
+package polywrapvm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// maxManifestDepth limits the nesting of msgpack containers in a manifest
+const maxManifestDepth = 32
+
+// manifestReader decodes msgpack into generic values: nil, bool, int64, uint64, float64, string,
+// []byte, []any and map[string]any. Unlike the client msgpack decoder it accepts maps with optional
+// and unknown keys, which wrap.info manifests are full of.
+type manifestReader struct {
+	data []byte
+	pos  int
+}
+
+func decodeMsgpack(data []byte) (any, error) {
+	r := &manifestReader{data: data}
+	v, err := r.readValue(0)
+	if err != nil {
+		return nil, err
+	}
+	if r.pos != len(r.data) {
+		return nil, fmt.Errorf("%d trailing bytes", len(r.data)-r.pos)
+	}
+	return v, nil
+}
+
+func (r *manifestReader) readValue(depth int) (any, error) {
+	if depth > maxManifestDepth {
+		return nil, fmt.Errorf("exceeds max depth %d", maxManifestDepth)
+	}
+	b, err := r.next(1)
+	if err != nil {
+		return nil, err
+	}
+	f := b[0]
+	switch {
+	case f <= 0x7f:
+		return int64(f), nil
+	case f >= 0xe0:
+		return int64(int8(f)), nil
+	case f&0xf0 == 0x80:
+		return r.readMap(int(f&0x0f), depth)
+	case f&0xf0 == 0x90:
+		return r.readArray(int(f&0x0f), depth)
+	case f&0xe0 == 0xa0:
+		return r.readString(int(f & 0x1f))
+	}
+	switch f {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := r.readLength(f - 0xc4)
+		if err != nil {
+			return nil, err
+		}
+		bz, err := r.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, bz...), nil
+	case 0xca:
+		bz, err := r.next(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(bz))), nil
+	case 0xcb:
+		bz, err := r.next(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(bz)), nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		bz, err := r.next(1 << (f - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		return readUint(bz), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		bz, err := r.next(1 << (f - 0xd0))
+		if err != nil {
+			return nil, err
+		}
+		// sign extend from the encoded width
+		shift := 64 - 8*len(bz)
+		return int64(readUint(bz)<<shift) >> shift, nil
+	case 0xd9, 0xda, 0xdb:
+		n, err := r.readLength(f - 0xd9)
+		if err != nil {
+			return nil, err
+		}
+		return r.readString(n)
+	case 0xdc, 0xdd:
+		n, err := r.readLength(f - 0xdc + 1)
+		if err != nil {
+			return nil, err
+		}
+		return r.readArray(n, depth)
+	case 0xde, 0xdf:
+		n, err := r.readLength(f - 0xde + 1)
+		if err != nil {
+			return nil, err
+		}
+		return r.readMap(n, depth)
+	}
+	return nil, fmt.Errorf("unsupported msgpack format 0x%x", f)
+}
+
+// readLength reads a big endian length of 1, 2 or 4 bytes for size 0, 1 or 2
+func (r *manifestReader) readLength(size byte) (int, error) {
+	bz, err := r.next(1 << size)
+	if err != nil {
+		return 0, err
+	}
+	return int(readUint(bz)), nil
+}
+
+func (r *manifestReader) readString(n int) (string, error) {
+	bz, err := r.next(n)
+	if err != nil {
+		return "", err
+	}
+	return string(bz), nil
+}
+
+func (r *manifestReader) readArray(n, depth int) ([]any, error) {
+	// every element takes at least one byte
+	if n > len(r.data)-r.pos {
+		return nil, fmt.Errorf("array length %d exceeds input", n)
+	}
+	res := make([]any, n)
+	for i := range res {
+		v, err := r.readValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = v
+	}
+	return res, nil
+}
+
+func (r *manifestReader) readMap(n, depth int) (map[string]any, error) {
+	if n > len(r.data)-r.pos {
+		return nil, fmt.Errorf("map length %d exceeds input", n)
+	}
+	res := make(map[string]any, n)
+	for i := 0; i < n; i++ {
+		k, err := r.readValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		key, ok := k.(string)
+		if !ok {
+			return nil, fmt.Errorf("map key must be a string, got %T", k)
+		}
+		v, err := r.readValue(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		res[key] = v
+	}
+	return res, nil
+}
+
+func (r *manifestReader) next(n int) ([]byte, error) {
+	if n < 0 || n > len(r.data)-r.pos {
+		return nil, fmt.Errorf("unexpected end of input")
+	}
+	bz := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return bz, nil
+}
+
+func readUint(bz []byte) uint64 {
+	var v uint64
+	for _, b := range bz {
+		v = v<<8 | uint64(b)
+	}
+	return v
+}
+
+// mapEntry is a key value pair of a msgpack map with keys other than strings
+type mapEntry struct {
+	Key, Value any
+}
+
+// encodeMsgpack encodes nil, bool, int, int64, uint64, float64, string, []byte, []any, map[string]any and
+// []mapEntry. Signed and unsigned integers are written in the smallest format of their own family, as the
+// wrapper decoders read unsigned integers from unsigned formats only. map[string]any keys are sorted, so
+// the encoding is deterministic.
+func encodeMsgpack(v any) []byte {
+	return appendMsgpack(nil, v)
+}
+
+func appendMsgpack(bz []byte, v any) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(bz, 0xc0)
+	case bool:
+		if v {
+			return append(bz, 0xc3)
+		}
+		return append(bz, 0xc2)
+	case int:
+		return appendInt(bz, int64(v))
+	case int64:
+		return appendInt(bz, v)
+	case uint64:
+		return appendUint(bz, v)
+	case float64:
+		bz = append(bz, 0xcb)
+		return binary.BigEndian.AppendUint64(bz, math.Float64bits(v))
+	case string:
+		bz = appendLength(bz, len(v), 0xa0, 32, 0xd9)
+		return append(bz, v...)
+	case []byte:
+		bz = appendLength(bz, len(v), 0, 0, 0xc4)
+		return append(bz, v...)
+	case []any:
+		bz = appendLength(bz, len(v), 0x90, 16, 0xdc)
+		for _, e := range v {
+			bz = appendMsgpack(bz, e)
+		}
+		return bz
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		bz = appendLength(bz, len(v), 0x80, 16, 0xde)
+		for _, k := range keys {
+			bz = appendMsgpack(bz, k)
+			bz = appendMsgpack(bz, v[k])
+		}
+		return bz
+	case []mapEntry:
+		bz = appendLength(bz, len(v), 0x80, 16, 0xde)
+		for _, e := range v {
+			bz = appendMsgpack(bz, e.Key)
+			bz = appendMsgpack(bz, e.Value)
+		}
+		return bz
+	}
+	panic(fmt.Sprintf("unsupported msgpack type %T", v))
+}
+
+func appendInt(bz []byte, v int64) []byte {
+	switch {
+	case v >= 0 && v <= math.MaxInt8, v < 0 && v >= -32:
+		return append(bz, byte(v))
+	case v >= math.MinInt8 && v <= math.MaxInt8:
+		return append(bz, 0xd0, byte(v))
+	case v >= math.MinInt16 && v <= math.MaxInt16:
+		return binary.BigEndian.AppendUint16(append(bz, 0xd1), uint16(v))
+	case v >= math.MinInt32 && v <= math.MaxInt32:
+		return binary.BigEndian.AppendUint32(append(bz, 0xd2), uint32(v))
+	}
+	return binary.BigEndian.AppendUint64(append(bz, 0xd3), uint64(v))
+}
+
+func appendUint(bz []byte, v uint64) []byte {
+	switch {
+	case v <= math.MaxInt8:
+		return append(bz, byte(v))
+	case v <= math.MaxUint8:
+		return append(bz, 0xcc, byte(v))
+	case v <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(bz, 0xcd), uint16(v))
+	case v <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(bz, 0xce), uint32(v))
+	}
+	return binary.BigEndian.AppendUint64(append(bz, 0xcf), v)
+}
+
+// appendLength writes the fix format header for lengths below fixMax, otherwise the 8 bit (str, bin)
+// or 16 bit (array, map) length format followed by the 16 and 32 bit formats.
+func appendLength(bz []byte, n int, fix byte, fixMax int, format byte) []byte {
+	switch {
+	case n < fixMax:
+		return append(bz, fix|byte(n))
+	case (format == 0xd9 || format == 0xc4) && n <= math.MaxUint8:
+		return append(bz, format, byte(n))
+	case format == 0xd9 || format == 0xc4:
+		format++
+	}
+	if n <= math.MaxUint16 {
+		return binary.BigEndian.AppendUint16(append(bz, format), uint16(n))
+	}
+	return binary.BigEndian.AppendUint32(append(bz, format+1), uint32(n))
+}
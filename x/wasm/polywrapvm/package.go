@@ -0,0 +1,29 @@
+package polywrapvm
+
+import (
+	"fmt"
+
+	"github.com/ConsiderItDone/wasmos/x/wasm/ioutils"
+)
+
+const (
+	wrapFile     = "wrap.wasm"
+	manifestFile = "wrap.info"
+)
+
+// UnpackWrapper splits a tar wrapper package into the wasm module and its msgpack encoded wrap.info.
+// The package contains exactly these two files, as written by the polywrap build.
+func UnpackWrapper(pkg []byte, limit uint64) (code, manifest []byte, err error) {
+	if !ioutils.IsTar(pkg) {
+		return nil, nil, fmt.Errorf("wrapper package is not a tar archive")
+	}
+	files, err := ioutils.Untar(pkg, limit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid wrapper package: %w", err)
+	}
+	code, manifest = files[wrapFile], files[manifestFile]
+	if len(code) == 0 || len(manifest) == 0 || len(files) != 2 {
+		return nil, nil, fmt.Errorf("wrapper package must contain only %s and %s", wrapFile, manifestFile)
+	}
+	return code, manifest, nil
+}
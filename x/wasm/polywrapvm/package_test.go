@@ -0,0 +1,77 @@
+package polywrapvm
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackWrapper(t *testing.T) {
+	code, err := os.ReadFile("../keeper/testdata/hello_world.wasm")
+	require.NoError(t, err)
+	manifest, err := os.ReadFile("../keeper/testdata/hello_world.wrap.info")
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		files  map[string][]byte
+		expErr bool
+	}{
+		"wrapper package": {
+			files: map[string][]byte{"wrap.wasm": code, "wrap.info": manifest},
+		},
+		"relative paths": {
+			files: map[string][]byte{"./wrap.wasm": code, "./wrap.info": manifest},
+		},
+		"missing manifest": {
+			files:  map[string][]byte{"wrap.wasm": code},
+			expErr: true,
+		},
+		"missing code": {
+			files:  map[string][]byte{"wrap.info": manifest},
+			expErr: true,
+		},
+		"additional file": {
+			files:  map[string][]byte{"wrap.wasm": code, "wrap.info": manifest, "schema.graphql": []byte("type Module")},
+			expErr: true,
+		},
+		"files in sub directory": {
+			files:  map[string][]byte{"build/wrap.wasm": code, "build/wrap.info": manifest},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			gotCode, gotManifest, err := UnpackWrapper(tarFiles(t, spec.files), 1<<20)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, code, gotCode)
+			assert.Equal(t, manifest, gotManifest)
+		})
+	}
+
+	// not a tar
+	_, _, err = UnpackWrapper(code, 1<<20)
+	require.Error(t, err)
+	// exceeds limit
+	_, _, err = UnpackWrapper(tarFiles(t, map[string][]byte{"wrap.wasm": code, "wrap.info": manifest}), uint64(len(code)))
+	require.Error(t, err)
+}
+
+func tarFiles(t *testing.T, files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}))
+		_, err := tw.Write(content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
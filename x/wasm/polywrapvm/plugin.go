@@ -1,15 +1,30 @@
 package polywrapvm
 
 import (
+	"errors"
 	"fmt"
 	wasmvm "github.com/CosmWasm/wasmvm"
+	"github.com/CosmWasm/wasmvm/types"
 	"github.com/polywrap/go-client/msgpack"
+	dbm "github.com/tendermint/tm-db"
 )
 
 type CosmosPlugin struct {
-	store wasmvm.KVStore
+	store    wasmvm.KVStore
+	api      wasmvm.GoAPI
+	querier  wasmvm.Querier
+	gasLimit uint64
+	gasUsed  uint64
+	// queryGas is the gas of chain queries and proto bridge calls, it counts towards the gas limit but is
+	// charged by the querier
+	queryGas uint64
+	// readOnly is set for queries, which can not execute messages or emit events
+	readOnly bool
+	events   *eventLog
 }
 
+var errAPINotAvailable = errors.New("api not available")
+
 type DbSetArgType struct {
 	Key   []byte
 	Value []byte
@@ -18,8 +33,51 @@ type DbGetArgType struct {
 	Key []byte
 }
 
-func NewCosmosPlugin() *CosmosPlugin {
-	return &CosmosPlugin{}
+// DbRangeArgType selects the keys in [Start, End) in ascending or descending order. Nil bounds are open,
+// a zero Limit returns all keys of the range.
+type DbRangeArgType struct {
+	Start      []byte
+	End        []byte
+	Descending bool
+	Limit      uint32
+}
+
+type KV struct {
+	Key   []byte
+	Value []byte
+}
+
+// NewCosmosPlugin creates the plugin for a single invocation. Chain queries are executed by the querier
+// with the gas limit of the invocation, address conversions by the api.
+func NewCosmosPlugin(store wasmvm.KVStore, api wasmvm.GoAPI, querier wasmvm.Querier, gasLimit uint64) *CosmosPlugin {
+	return &CosmosPlugin{store: store, api: api, querier: querier, gasLimit: gasLimit, events: &eventLog{}}
+}
+
+// GasUsed returns the wasmvm gas charged by the plugin for address conversions and signature verifications
+func (cp *CosmosPlugin) GasUsed() uint64 {
+	return cp.gasUsed
+}
+
+// consumeGas charges the plugin gas and fails with types.OutOfGasError once the invocation gas limit is exceeded
+func (cp *CosmosPlugin) consumeGas(gas uint64) error {
+	cp.gasUsed += gas
+	if cp.gasConsumed() > cp.gasLimit {
+		return types.OutOfGasError{}
+	}
+	return nil
+}
+
+// gasConsumed returns the plugin and the query gas of the invocation
+func (cp *CosmosPlugin) gasConsumed() uint64 {
+	return cp.gasUsed + cp.queryGas
+}
+
+// gasRemaining returns the gas left of the invocation gas limit
+func (cp *CosmosPlugin) gasRemaining() uint64 {
+	if consumed := cp.gasConsumed(); consumed < cp.gasLimit {
+		return cp.gasLimit - consumed
+	}
+	return 0
 }
 
 func (cp *CosmosPlugin) DbSet(args DbSetArgType) bool {
@@ -37,8 +95,30 @@ func (cp *CosmosPlugin) DbRemove(args DbGetArgType) bool {
 	return true
 }
 
-func (cp *CosmosPlugin) SetStore(store wasmvm.KVStore) {
-	cp.store = store
+func (cp *CosmosPlugin) DbRange(args DbRangeArgType) []KV {
+	start, end := args.Start, args.End
+	if len(start) == 0 {
+		start = nil
+	}
+	if len(end) == 0 {
+		end = nil
+	}
+	var iter dbm.Iterator
+	if args.Descending {
+		iter = cp.store.ReverseIterator(start, end)
+	} else {
+		iter = cp.store.Iterator(start, end)
+	}
+	defer iter.Close()
+
+	res := make([]KV, 0)
+	for ; iter.Valid(); iter.Next() {
+		if args.Limit != 0 && uint32(len(res)) == args.Limit {
+			break
+		}
+		res = append(res, KV{Key: iter.Key(), Value: iter.Value()})
+	}
+	return res
 }
 
 func (cp *CosmosPlugin) EncodeArgs(method string, args []byte) (any, error) {
@@ -47,6 +127,38 @@ func (cp *CosmosPlugin) EncodeArgs(method string, args []byte) (any, error) {
 		return msgpack.Decode[DbSetArgType](args)
 	case "DbGet", "DbHas", "DbRemove":
 		return msgpack.Decode[DbGetArgType](args)
+	case "DbRange":
+		return msgpack.Decode[DbRangeArgType](args)
+	case "Query":
+		return msgpack.Decode[QueryArgType](args)
+	case "QueryBalance":
+		return msgpack.Decode[QueryBalanceArgType](args)
+	case "QueryAllBalances":
+		return msgpack.Decode[QueryAllBalancesArgType](args)
+	case "QueryAllDelegations":
+		return msgpack.Decode[QueryAllDelegationsArgType](args)
+	case "QueryAllValidators":
+		return msgpack.Decode[QueryAllValidatorsArgType](args)
+	case "QuerySmart":
+		return msgpack.Decode[QuerySmartArgType](args)
+	case "QueryRaw":
+		return msgpack.Decode[QueryRawArgType](args)
+	case "AddrValidate", "AddrCanonicalize":
+		return msgpack.Decode[AddrArgType](args)
+	case "AddrHumanize":
+		return msgpack.Decode[AddrHumanizeArgType](args)
+	case "InvokeMsg":
+		return msgpack.Decode[InvokeMsgArgType](args)
+	case "QueryGrpc":
+		return msgpack.Decode[QueryGrpcArgType](args)
+	case "EmitEvent":
+		return msgpack.Decode[EmitEventArgType](args)
+	case "AddAttribute":
+		return msgpack.Decode[AddAttributeArgType](args)
+	case "Secp256k1Verify":
+		return msgpack.Decode[Secp256k1VerifyArgType](args)
+	case "Ed25519Verify":
+		return msgpack.Decode[Ed25519VerifyArgType](args)
 	default:
 		return nil, fmt.Errorf("unknown method: %s", method)
 	}
@@ -0,0 +1,121 @@
+package polywrapvm
+
+import (
+	"crypto/ed25519"
+	"math/big"
+
+	"github.com/CosmWasm/wasmvm/types"
+	"github.com/btcsuite/btcd/btcec"
+)
+
+const (
+	// secp256k1VerifyCost is the wasmvm gas charged for a secp256k1 signature verification, same as in CosmWasm
+	secp256k1VerifyCost uint64 = 154_000_000
+	// ed25519VerifyCost is the wasmvm gas charged for an ed25519 signature verification, same as in CosmWasm
+	ed25519VerifyCost uint64 = 63_000_000
+)
+
+var secp256k1HalfN = new(big.Int).Rsh(btcec.S256().N, 1)
+
+type AddrArgType struct {
+	Addr string
+}
+
+type AddrHumanizeArgType struct {
+	Canonical []byte
+}
+
+// Secp256k1VerifyArgType contains the sha256 hash of the message, the 64 byte R || S signature and a
+// compressed or uncompressed public key.
+type Secp256k1VerifyArgType struct {
+	MessageHash []byte
+	Signature   []byte
+	PublicKey   []byte
+}
+
+type Ed25519VerifyArgType struct {
+	Message   []byte
+	Signature []byte
+	PublicKey []byte
+}
+
+// AddrValidate returns true when the address is a valid bech32 address in its normalized form
+func (cp *CosmosPlugin) AddrValidate(args AddrArgType) (bool, error) {
+	canonical, err := cp.addrCanonicalize(args.Addr)
+	if err != nil {
+		if _, ok := err.(types.OutOfGasError); ok {
+			return false, err
+		}
+		return false, nil
+	}
+	human, err := cp.addrHumanize(canonical)
+	if err != nil {
+		if _, ok := err.(types.OutOfGasError); ok {
+			return false, err
+		}
+		return false, nil
+	}
+	return human == args.Addr, nil
+}
+
+func (cp *CosmosPlugin) AddrCanonicalize(args AddrArgType) ([]byte, error) {
+	return cp.addrCanonicalize(args.Addr)
+}
+
+func (cp *CosmosPlugin) AddrHumanize(args AddrHumanizeArgType) (string, error) {
+	return cp.addrHumanize(args.Canonical)
+}
+
+// Secp256k1Verify verifies the signature of the message hash. Signatures not in lower-S form are rejected.
+func (cp *CosmosPlugin) Secp256k1Verify(args Secp256k1VerifyArgType) (bool, error) {
+	if err := cp.consumeGas(secp256k1VerifyCost); err != nil {
+		return false, err
+	}
+	if len(args.MessageHash) != 32 || len(args.Signature) != 64 {
+		return false, nil
+	}
+	pubKey, err := btcec.ParsePubKey(args.PublicKey, btcec.S256())
+	if err != nil {
+		return false, nil
+	}
+	signature := &btcec.Signature{
+		R: new(big.Int).SetBytes(args.Signature[:32]),
+		S: new(big.Int).SetBytes(args.Signature[32:]),
+	}
+	if signature.S.Cmp(secp256k1HalfN) > 0 {
+		return false, nil
+	}
+	return signature.Verify(args.MessageHash, pubKey), nil
+}
+
+func (cp *CosmosPlugin) Ed25519Verify(args Ed25519VerifyArgType) (bool, error) {
+	if err := cp.consumeGas(ed25519VerifyCost); err != nil {
+		return false, err
+	}
+	if len(args.PublicKey) != ed25519.PublicKeySize || len(args.Signature) != ed25519.SignatureSize {
+		return false, nil
+	}
+	return ed25519.Verify(args.PublicKey, args.Message, args.Signature), nil
+}
+
+func (cp *CosmosPlugin) addrCanonicalize(human string) ([]byte, error) {
+	if cp.api.CanonicalAddress == nil {
+		return nil, errAPINotAvailable
+	}
+	canonical, cost, err := cp.api.CanonicalAddress(human)
+	if gasErr := cp.consumeGas(cost); gasErr != nil {
+		return nil, gasErr
+	}
+	return canonical, err
+}
+
+func (cp *CosmosPlugin) addrHumanize(canonical []byte) (string, error) {
+	if cp.api.HumanAddress == nil {
+		return "", errAPINotAvailable
+	}
+	human, cost, err := cp.api.HumanAddress(canonical)
+	if gasErr := cp.consumeGas(cost); gasErr != nil {
+		return "", gasErr
+	}
+	return human, err
+}
@@ -0,0 +1,100 @@
+package polywrapvm
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ServiceBridge is implemented by the queriers passed to the VM to give wrappers access to the Msg and
+// gRPC Query services of the chain by protobuf type URL and query path. Without it InvokeMsg and
+// QueryGrpc fail.
+type ServiceBridge interface {
+	// InvokeMsg executes the protobuf JSON encoded Cosmos SDK message with the type URL on behalf of the
+	// contract and returns the protobuf JSON encoded Msg service response
+	InvokeMsg(typeURL string, msg []byte, gasLimit uint64) ([]byte, error)
+	// QueryGrpc sends the protobuf JSON encoded request to the gRPC query path and returns the protobuf
+	// JSON encoded response
+	QueryGrpc(path string, request []byte, gasLimit uint64) ([]byte, error)
+}
+
+var errReadOnlyBridge = errors.New("messages can not be executed in queries")
+
+// InvokeMsgArgType contains a Cosmos SDK message and its protobuf type URL, e.g. /cosmos.bank.v1beta1.MsgSend,
+// like a protobuf Any. Value is the protobuf JSON encoded message or a msgpack map with the same fields.
+type InvokeMsgArgType struct {
+	TypeUrl string
+	Value   []byte
+}
+
+// QueryGrpcArgType contains the gRPC query path, e.g. /cosmos.bank.v1beta1.Query/Balance, and the protobuf
+// JSON encoded request or a msgpack map with the same fields
+type QueryGrpcArgType struct {
+	Path    string
+	Request []byte
+}
+
+// InvokeMsg executes the message on behalf of the contract and returns the JSON encoded Msg service
+// response. The message is executed right away, its state changes are reverted when the invocation fails.
+// It is limited to the remaining gas of the invocation, the same as a chain query.
+func (cp *CosmosPlugin) InvokeMsg(args InvokeMsgArgType) ([]byte, error) {
+	if cp.readOnly {
+		return nil, errReadOnlyBridge
+	}
+	bridge, err := cp.bridge()
+	if err != nil {
+		return nil, err
+	}
+	msg, err := bridgeJSON(args.Value)
+	if err != nil {
+		return nil, fmt.Errorf("value: %w", err)
+	}
+	return cp.meteredQuery(func(gasLimit uint64) ([]byte, error) {
+		return bridge.InvokeMsg(args.TypeUrl, msg, gasLimit)
+	})
+}
+
+// QueryGrpc runs the gRPC query and returns the JSON encoded response
+func (cp *CosmosPlugin) QueryGrpc(args QueryGrpcArgType) ([]byte, error) {
+	bridge, err := cp.bridge()
+	if err != nil {
+		return nil, err
+	}
+	request, err := bridgeJSON(args.Request)
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+	return cp.meteredQuery(func(gasLimit uint64) ([]byte, error) {
+		return bridge.QueryGrpc(args.Path, request, gasLimit)
+	})
+}
+
+func (cp *CosmosPlugin) bridge() (ServiceBridge, error) {
+	bridge, ok := cp.querier.(ServiceBridge)
+	if !ok {
+		return nil, errors.New("service bridge not available")
+	}
+	return bridge, nil
+}
+
+// bridgeJSON returns the JSON object of a message or request. JSON is passed on as it is, a msgpack map is
+// converted into JSON with Bytes as base64 strings, like protobuf JSON expects them. Empty input is an
+// empty object.
+func bridgeJSON(bz []byte) ([]byte, error) {
+	trimmed := bytes.TrimSpace(bz)
+	switch {
+	case len(trimmed) == 0:
+		return []byte("{}"), nil
+	case trimmed[0] == '{':
+		return bz, nil
+	}
+	v, err := decodeMsgpack(bz)
+	if err != nil {
+		return nil, fmt.Errorf("neither a JSON object nor msgpack: %w", err)
+	}
+	if _, ok := v.(map[string]any); !ok {
+		return nil, errors.New("must be a JSON object or msgpack map")
+	}
+	return json.Marshal(v)
+}
@@ -0,0 +1,180 @@
+package polywrapvm
+
+import (
+	"errors"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/polywrap/go-client/msgpack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockBridge struct {
+	mockQuerier
+	invokeMsg func(typeURL string, msg []byte, gasLimit uint64) ([]byte, error)
+	queryGrpc func(path string, request []byte, gasLimit uint64) ([]byte, error)
+}
+
+func (m mockBridge) InvokeMsg(typeURL string, msg []byte, gasLimit uint64) ([]byte, error) {
+	return m.invokeMsg(typeURL, msg, gasLimit)
+}
+
+func (m mockBridge) QueryGrpc(path string, request []byte, gasLimit uint64) ([]byte, error) {
+	return m.queryGrpc(path, request, gasLimit)
+}
+
+func TestCosmosPluginInvokeMsg(t *testing.T) {
+	var (
+		gotTypeURL  string
+		gotMsg      []byte
+		gotGasLimit uint64
+	)
+	bridge := mockBridge{invokeMsg: func(typeURL string, msg []byte, gasLimit uint64) ([]byte, error) {
+		gotTypeURL, gotMsg, gotGasLimit = typeURL, msg, gasLimit
+		if typeURL == "/unknown" {
+			return nil, errors.New("unknown")
+		}
+		return []byte(`{}`), nil
+	}}
+	const msgSend = "/cosmos.bank.v1beta1.MsgSend"
+
+	specs := map[string]struct {
+		args   InvokeMsgArgType
+		expMsg string
+		expErr bool
+	}{
+		"json": {
+			args:   InvokeMsgArgType{TypeUrl: msgSend, Value: []byte(`{"from_address":"a","amount":[{"denom":"stake","amount":"1"}]}`)},
+			expMsg: `{"from_address":"a","amount":[{"denom":"stake","amount":"1"}]}`,
+		},
+		"msgpack": {
+			args: InvokeMsgArgType{TypeUrl: msgSend, Value: encodeMsgpack(map[string]any{
+				"from_address": "a",
+				"amount":       []any{map[string]any{"denom": "stake", "amount": "1"}},
+				"data":         []byte("raw"),
+			})},
+			expMsg: `{"from_address":"a","amount":[{"denom":"stake","amount":"1"}],"data":"cmF3"}`,
+		},
+		"empty": {
+			args:   InvokeMsgArgType{TypeUrl: msgSend},
+			expMsg: `{}`,
+		},
+		"msgpack not a map": {
+			args:   InvokeMsgArgType{TypeUrl: msgSend, Value: encodeMsgpack([]any{"a"})},
+			expErr: true,
+		},
+		"invalid encoding": {
+			args:   InvokeMsgArgType{TypeUrl: msgSend, Value: []byte(`["a"]`)},
+			expErr: true,
+		},
+		"bridge error": {
+			args:   InvokeMsgArgType{TypeUrl: "/unknown", Value: []byte(`{}`)},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := msgpack.Encode(spec.args)
+			require.NoError(t, err)
+
+			plugin := NewCosmosPlugin(nil, wasmvm.GoAPI{}, bridge, 1000)
+			args, err := plugin.EncodeArgs("InvokeMsg", encoded)
+			require.NoError(t, err)
+			res, gotErr := plugin.InvokeMsg(args.(InvokeMsgArgType))
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, []byte(`{}`), res)
+			assert.Equal(t, spec.args.TypeUrl, gotTypeURL)
+			assert.JSONEq(t, spec.expMsg, string(gotMsg))
+			assert.Equal(t, uint64(1000), gotGasLimit)
+		})
+	}
+
+	t.Run("rejected in queries", func(t *testing.T) {
+		plugin := NewCosmosPlugin(nil, wasmvm.GoAPI{}, bridge, 1000)
+		plugin.readOnly = true
+		_, err := plugin.InvokeMsg(InvokeMsgArgType{TypeUrl: msgSend, Value: []byte(`{}`)})
+		assert.ErrorIs(t, err, errReadOnlyBridge)
+	})
+	t.Run("no bridge", func(t *testing.T) {
+		plugin := NewCosmosPlugin(nil, wasmvm.GoAPI{}, mockQuerier(nil), 1000)
+		_, err := plugin.InvokeMsg(InvokeMsgArgType{TypeUrl: msgSend, Value: []byte(`{}`)})
+		assert.Error(t, err)
+	})
+}
+
+func TestCosmosPluginQueryGrpc(t *testing.T) {
+	var (
+		gotPath    string
+		gotRequest []byte
+	)
+	bridge := mockBridge{queryGrpc: func(path string, request []byte, gasLimit uint64) ([]byte, error) {
+		gotPath, gotRequest = path, request
+		return []byte(`{"balance":{"denom":"stake","amount":"1"}}`), nil
+	}}
+	// queries can use the bridge
+	plugin := NewCosmosPlugin(nil, wasmvm.GoAPI{}, bridge, 1000)
+	plugin.readOnly = true
+
+	encoded, err := msgpack.Encode(QueryGrpcArgType{
+		Path:    "/cosmos.bank.v1beta1.Query/Balance",
+		Request: encodeMsgpack(map[string]any{"address": "a", "denom": "stake"}),
+	})
+	require.NoError(t, err)
+	args, err := plugin.EncodeArgs("QueryGrpc", encoded)
+	require.NoError(t, err)
+	res, err := plugin.QueryGrpc(args.(QueryGrpcArgType))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"balance":{"denom":"stake","amount":"1"}}`, string(res))
+	assert.Equal(t, "/cosmos.bank.v1beta1.Query/Balance", gotPath)
+	assert.JSONEq(t, `{"address":"a","denom":"stake"}`, string(gotRequest))
+
+	_, err = NewCosmosPlugin(nil, wasmvm.GoAPI{}, mockQuerier(nil), 1000).QueryGrpc(QueryGrpcArgType{Path: "/any"})
+	assert.Error(t, err)
+}
+
+// gasBridge consumes the gas of a gasQuerier for every message and query
+type gasBridge struct {
+	*gasQuerier
+	gasLimits []uint64
+}
+
+func (m *gasBridge) InvokeMsg(_ string, _ []byte, gasLimit uint64) ([]byte, error) {
+	m.consumed += m.gasPerQuery
+	m.gasLimits = append(m.gasLimits, gasLimit)
+	return []byte(`{}`), nil
+}
+
+func (m *gasBridge) QueryGrpc(_ string, _ []byte, gasLimit uint64) ([]byte, error) {
+	m.consumed += m.gasPerQuery
+	m.gasLimits = append(m.gasLimits, gasLimit)
+	return []byte(`{}`), nil
+}
+
+func TestCosmosPluginBridgeGas(t *testing.T) {
+	bridge := &gasBridge{gasQuerier: &gasQuerier{gasPerQuery: 10}}
+	plugin := NewCosmosPlugin(nil, wasmvm.GoAPI{}, bridge, 1000)
+
+	// messages and queries get the gas left after the previous ones
+	_, err := plugin.InvokeMsg(InvokeMsgArgType{TypeUrl: "/cosmos.bank.v1beta1.MsgSend"})
+	require.NoError(t, err)
+	_, err = plugin.QueryGrpc(QueryGrpcArgType{Path: "/cosmos.bank.v1beta1.Query/Balance"})
+	require.NoError(t, err)
+	_, err = plugin.InvokeMsg(InvokeMsgArgType{TypeUrl: "/cosmos.bank.v1beta1.MsgSend"})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1000, 800, 600}, bridge.gasLimits)
+	assert.Equal(t, uint64(0), plugin.GasUsed())
+
+	_, err = plugin.QueryGrpc(QueryGrpcArgType{Path: "/cosmos.bank.v1beta1.Query/Balance"})
+	require.NoError(t, err)
+	_, err = plugin.QueryGrpc(QueryGrpcArgType{Path: "/cosmos.bank.v1beta1.Query/Balance"})
+	require.NoError(t, err)
+	_, err = plugin.InvokeMsg(InvokeMsgArgType{TypeUrl: "/cosmos.bank.v1beta1.MsgSend"})
+	assert.ErrorIs(t, err, wasmvmtypes.OutOfGasError{})
+	assert.Equal(t, []uint64{1000, 800, 600, 400, 200}, bridge.gasLimits)
+}
@@ -0,0 +1,110 @@
+package polywrapvm
+
+import (
+	"errors"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	"github.com/CosmWasm/wasmvm/types"
+
+	wasmtypes "github.com/ConsiderItDone/wasmos/x/wasm/types"
+)
+
+// eventDataCost is the wasmvm gas charged per byte of event type, attribute key and value the cosmos plugin
+// keeps until the invocation returns, 1 SDK gas with the default gas multiplier. The emitted events are
+// charged like the events of the response on top.
+const eventDataCost uint64 = 140_000_000
+
+// EventEmitter is implemented by the queriers of wrap://wasmos contract subinvocations. The events the
+// callee emits through the cosmos plugin are passed to it once the subinvocation succeeds, to be
+// emitted for the callee contract.
+type EventEmitter interface {
+	EmitEvents(attrs []types.EventAttribute, events types.Events) error
+}
+
+var errReadOnlyEvents = errors.New("events can not be emitted in queries")
+
+// EmitEventArgType is a custom event, it is emitted as "wasm-<Type>" with the contract address added
+type EmitEventArgType struct {
+	Type       string
+	Attributes []EventAttribute
+}
+
+// AddAttributeArgType is an attribute of the "wasm" event of the contract
+type AddAttributeArgType struct {
+	Key   string
+	Value string
+}
+
+// eventLog collects the events emitted through the cosmos plugin during an invocation
+type eventLog struct {
+	attributes []types.EventAttribute
+	events     types.Events
+}
+
+// EmitEvent adds a custom event to the events of the invocation. The event is validated when it is added, so
+// an invalid event fails the call that emits it.
+func (cp *CosmosPlugin) EmitEvent(args EmitEventArgType) (bool, error) {
+	if cp.readOnly {
+		return false, errReadOnlyEvents
+	}
+	if err := wasmtypes.ValidateEventType(args.Type); err != nil {
+		return false, err
+	}
+	size := len(args.Type)
+	attrs := make(types.EventAttributes, len(args.Attributes))
+	for i, a := range args.Attributes {
+		if err := wasmtypes.ValidateEventAttribute(a.Key, a.Value); err != nil {
+			return false, err
+		}
+		size += len(a.Key) + len(a.Value)
+		attrs[i] = types.EventAttribute{Key: a.Key, Value: a.Value}
+	}
+	if err := cp.consumeGas(uint64(size) * eventDataCost); err != nil {
+		return false, err
+	}
+	cp.events.events = append(cp.events.events, types.Event{Type: args.Type, Attributes: attrs})
+	return true, nil
+}
+
+// AddAttribute adds an attribute to the "wasm" event of the invocation
+func (cp *CosmosPlugin) AddAttribute(args AddAttributeArgType) (bool, error) {
+	if cp.readOnly {
+		return false, errReadOnlyEvents
+	}
+	if err := wasmtypes.ValidateEventAttribute(args.Key, args.Value); err != nil {
+		return false, err
+	}
+	if err := cp.consumeGas(uint64(len(args.Key)+len(args.Value)) * eventDataCost); err != nil {
+		return false, err
+	}
+	cp.events.attributes = append(cp.events.attributes, types.EventAttribute{Key: args.Key, Value: args.Value})
+	return true, nil
+}
+
+// addTo puts the collected events in front of the events returned by the wrapper
+func (l *eventLog) addTo(res *types.Response) {
+	if len(l.attributes) != 0 {
+		res.Attributes = append(append([]types.EventAttribute{}, l.attributes...), res.Attributes...)
+	}
+	if len(l.events) != 0 {
+		res.Events = append(append([]types.Event{}, l.events...), res.Events...)
+	}
+}
+
+// append adds the events of a code subinvocation
+func (l *eventLog) append(other *eventLog) {
+	l.attributes = append(l.attributes, other.attributes...)
+	l.events = append(l.events, other.events...)
+}
+
+// emit passes the collected events of a contract subinvocation to the querier of the callee
+func (l *eventLog) emit(querier wasmvm.Querier) error {
+	if len(l.attributes) == 0 && len(l.events) == 0 {
+		return nil
+	}
+	emitter, ok := querier.(EventEmitter)
+	if !ok {
+		return errors.New("events of subinvocations not supported")
+	}
+	return emitter.EmitEvents(l.attributes, l.events)
+}
@@ -0,0 +1,136 @@
+package polywrapvm
+
+import (
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	"github.com/CosmWasm/wasmvm/types"
+	"github.com/polywrap/go-client/msgpack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wasmtypes "github.com/ConsiderItDone/wasmos/x/wasm/types"
+)
+
+func TestCosmosPluginEmitEvent(t *testing.T) {
+	plugin := NewCosmosPlugin(nil, wasmvm.GoAPI{}, mockQuerier(nil), 100*eventDataCost)
+
+	encoded, err := msgpack.Encode(EmitEventArgType{
+		Type:       "transfer",
+		Attributes: []EventAttribute{{Key: "amount", Value: "10"}},
+	})
+	require.NoError(t, err)
+	args, err := plugin.EncodeArgs("EmitEvent", encoded)
+	require.NoError(t, err)
+	ok, err := plugin.EmitEvent(args.(EmitEventArgType))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	encoded, err = msgpack.Encode(AddAttributeArgType{Key: "action", Value: "emit"})
+	require.NoError(t, err)
+	args, err = plugin.EncodeArgs("AddAttribute", encoded)
+	require.NoError(t, err)
+	ok, err = plugin.AddAttribute(args.(AddAttributeArgType))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	// every byte of type, keys and values is charged
+	assert.Equal(t, uint64(len("transfer")+len("amount")+len("10")+len("action")+len("emit"))*eventDataCost, plugin.GasUsed())
+
+	// the emitted events come first
+	res := &types.Response{
+		Attributes: []types.EventAttribute{{Key: "result", Value: "1"}},
+		Events:     []types.Event{{Type: "result", Attributes: types.EventAttributes{}}},
+	}
+	plugin.events.addTo(res)
+	assert.Equal(t, []types.EventAttribute{{Key: "action", Value: "emit"}, {Key: "result", Value: "1"}}, res.Attributes)
+	assert.Equal(t, []types.Event{
+		{Type: "transfer", Attributes: types.EventAttributes{{Key: "amount", Value: "10"}}},
+		{Type: "result", Attributes: types.EventAttributes{}},
+	}, res.Events)
+
+	// no events are added to a response without them
+	res = &types.Response{}
+	NewCosmosPlugin(nil, wasmvm.GoAPI{}, mockQuerier(nil), 1000).events.addTo(res)
+	assert.Nil(t, res.Attributes)
+	assert.Nil(t, res.Events)
+
+	t.Run("invalid events", func(t *testing.T) {
+		specs := map[string]struct {
+			event     *EmitEventArgType
+			attribute *AddAttributeArgType
+		}{
+			"short type":          {event: &EmitEventArgType{Type: " ab "}},
+			"empty event key":     {event: &EmitEventArgType{Type: "transfer", Attributes: []EventAttribute{{Key: " ", Value: "10"}}}},
+			"reserved event key":  {event: &EmitEventArgType{Type: "transfer", Attributes: []EventAttribute{{Key: "_contract_address", Value: "10"}}}},
+			"empty event value":   {event: &EmitEventArgType{Type: "transfer", Attributes: []EventAttribute{{Key: "amount"}}}},
+			"empty attribute key": {attribute: &AddAttributeArgType{Value: "emit"}},
+			"reserved attribute":  {attribute: &AddAttributeArgType{Key: "_action", Value: "emit"}},
+			"empty attribute":     {attribute: &AddAttributeArgType{Key: "action", Value: " "}},
+		}
+		for name, spec := range specs {
+			t.Run(name, func(t *testing.T) {
+				plugin := NewCosmosPlugin(nil, wasmvm.GoAPI{}, mockQuerier(nil), 100*eventDataCost)
+				var err error
+				if spec.event != nil {
+					_, err = plugin.EmitEvent(*spec.event)
+				} else {
+					_, err = plugin.AddAttribute(*spec.attribute)
+				}
+				assert.ErrorIs(t, err, wasmtypes.ErrInvalidEvent)
+				assert.Empty(t, plugin.events.events)
+				assert.Empty(t, plugin.events.attributes)
+				assert.Zero(t, plugin.GasUsed())
+			})
+		}
+	})
+
+	t.Run("out of gas", func(t *testing.T) {
+		plugin := NewCosmosPlugin(nil, wasmvm.GoAPI{}, mockQuerier(nil), 10*eventDataCost)
+		_, err := plugin.EmitEvent(EmitEventArgType{Type: "transfer", Attributes: []EventAttribute{{Key: "amount", Value: "10"}}})
+		assert.ErrorIs(t, err, types.OutOfGasError{})
+		_, err = plugin.AddAttribute(AddAttributeArgType{Key: "action", Value: "emitted"})
+		assert.ErrorIs(t, err, types.OutOfGasError{})
+		assert.Empty(t, plugin.events.events)
+		assert.Empty(t, plugin.events.attributes)
+	})
+
+	t.Run("rejected in queries", func(t *testing.T) {
+		plugin := NewCosmosPlugin(nil, wasmvm.GoAPI{}, mockQuerier(nil), 1000)
+		plugin.readOnly = true
+		_, err := plugin.EmitEvent(EmitEventArgType{Type: "transfer"})
+		assert.ErrorIs(t, err, errReadOnlyEvents)
+		_, err = plugin.AddAttribute(AddAttributeArgType{Key: "action", Value: "emit"})
+		assert.ErrorIs(t, err, errReadOnlyEvents)
+		assert.Empty(t, plugin.events.events)
+		assert.Empty(t, plugin.events.attributes)
+	})
+}
+
+type mockEventEmitter struct {
+	mockQuerier
+	emit func(attrs []types.EventAttribute, events types.Events) error
+}
+
+func (m mockEventEmitter) EmitEvents(attrs []types.EventAttribute, events types.Events) error {
+	return m.emit(attrs, events)
+}
+
+func TestEventLogEmit(t *testing.T) {
+	log := &eventLog{
+		attributes: []types.EventAttribute{{Key: "action", Value: "emit"}},
+		events:     types.Events{{Type: "transfer"}},
+	}
+	var gotAttrs []types.EventAttribute
+	var gotEvents types.Events
+	emitter := mockEventEmitter{emit: func(attrs []types.EventAttribute, events types.Events) error {
+		gotAttrs, gotEvents = attrs, events
+		return nil
+	}}
+	require.NoError(t, log.emit(emitter))
+	assert.Equal(t, log.attributes, gotAttrs)
+	assert.Equal(t, log.events, gotEvents)
+
+	// queriers without emitter only work without events
+	assert.Error(t, log.emit(mockQuerier(nil)))
+	assert.NoError(t, (&eventLog{}).emit(mockQuerier(nil)))
+}
@@ -0,0 +1,196 @@
+package polywrapvm
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/CosmWasm/wasmvm/types"
+
+	wasmtypes "github.com/ConsiderItDone/wasmos/x/wasm/types"
+)
+
+// QueryArgType contains a json encoded CosmWasm QueryRequest, e.g. {"bank":{"balance":{...}}}
+type QueryArgType struct {
+	Request string
+}
+
+type QueryBalanceArgType struct {
+	Address string
+	Denom   string
+}
+
+type QueryAllBalancesArgType struct {
+	Address string
+}
+
+type QueryAllDelegationsArgType struct {
+	Delegator string
+}
+
+type QueryAllValidatorsArgType struct{}
+
+// QuerySmartArgType queries the Method of the contract with the json encoded Msg. An empty method
+// sends a plain CosmWasm smart query.
+type QuerySmartArgType struct {
+	ContractAddr string
+	Method       string
+	Msg          string
+}
+
+type QueryRawArgType struct {
+	ContractAddr string
+	Key          []byte
+}
+
+type Delegation struct {
+	Delegator string
+	Validator string
+	Amount    Coin
+}
+
+type Validator struct {
+	Address       string
+	Commission    string
+	MaxCommission string
+	MaxChangeRate string
+}
+
+// Query executes the json encoded query request and returns the json encoded response
+func (cp *CosmosPlugin) Query(args QueryArgType) ([]byte, error) {
+	var request types.QueryRequest
+	if err := json.Unmarshal([]byte(args.Request), &request); err != nil {
+		return nil, err
+	}
+	return cp.query(request)
+}
+
+func (cp *CosmosPlugin) QueryBalance(args QueryBalanceArgType) (Coin, error) {
+	var res types.BalanceResponse
+	err := cp.queryJSON(types.QueryRequest{Bank: &types.BankQuery{
+		Balance: &types.BalanceQuery{Address: args.Address, Denom: args.Denom},
+	}}, &res)
+	if err != nil {
+		return Coin{}, err
+	}
+	return Coin{Denom: res.Amount.Denom, Amount: res.Amount.Amount}, nil
+}
+
+func (cp *CosmosPlugin) QueryAllBalances(args QueryAllBalancesArgType) ([]Coin, error) {
+	var res types.AllBalancesResponse
+	err := cp.queryJSON(types.QueryRequest{Bank: &types.BankQuery{
+		AllBalances: &types.AllBalancesQuery{Address: args.Address},
+	}}, &res)
+	if err != nil {
+		return nil, err
+	}
+	coins := make([]Coin, len(res.Amount))
+	for i, c := range res.Amount {
+		coins[i] = Coin{Denom: c.Denom, Amount: c.Amount}
+	}
+	return coins, nil
+}
+
+func (cp *CosmosPlugin) QueryAllDelegations(args QueryAllDelegationsArgType) ([]Delegation, error) {
+	var res types.AllDelegationsResponse
+	err := cp.queryJSON(types.QueryRequest{Staking: &types.StakingQuery{
+		AllDelegations: &types.AllDelegationsQuery{Delegator: args.Delegator},
+	}}, &res)
+	if err != nil {
+		return nil, err
+	}
+	delegations := make([]Delegation, len(res.Delegations))
+	for i, d := range res.Delegations {
+		delegations[i] = Delegation{
+			Delegator: d.Delegator,
+			Validator: d.Validator,
+			Amount:    Coin{Denom: d.Amount.Denom, Amount: d.Amount.Amount},
+		}
+	}
+	return delegations, nil
+}
+
+func (cp *CosmosPlugin) QueryAllValidators(_ QueryAllValidatorsArgType) ([]Validator, error) {
+	var res types.AllValidatorsResponse
+	err := cp.queryJSON(types.QueryRequest{Staking: &types.StakingQuery{
+		AllValidators: &types.AllValidatorsQuery{},
+	}}, &res)
+	if err != nil {
+		return nil, err
+	}
+	validators := make([]Validator, len(res.Validators))
+	for i, v := range res.Validators {
+		validators[i] = Validator{
+			Address:       v.Address,
+			Commission:    v.Commission,
+			MaxCommission: v.MaxCommission,
+			MaxChangeRate: v.MaxChangeRate,
+		}
+	}
+	return validators, nil
+}
+
+// QuerySmart queries another contract and returns the query result data
+func (cp *CosmosPlugin) QuerySmart(args QuerySmartArgType) ([]byte, error) {
+	msg := wasmtypes.RawContractMessage(args.Msg)
+	if args.Method != "" {
+		var err error
+		if msg, err = wasmtypes.NewMethodEnvelope(args.Method, msg); err != nil {
+			return nil, err
+		}
+	}
+	return cp.query(types.QueryRequest{Wasm: &types.WasmQuery{
+		Smart: &types.SmartQuery{ContractAddr: args.ContractAddr, Msg: msg},
+	}})
+}
+
+// QueryRaw returns the value stored under the key of another contract
+func (cp *CosmosPlugin) QueryRaw(args QueryRawArgType) ([]byte, error) {
+	return cp.query(types.QueryRequest{Wasm: &types.WasmQuery{
+		Raw: &types.RawQuery{ContractAddr: args.ContractAddr, Key: args.Key},
+	}})
+}
+
+// QueryGasConverter is implemented by queriers that consume SDK gas, to convert it into wasmvm gas. The gas
+// consumed by other queriers is taken as wasmvm gas.
+type QueryGasConverter interface {
+	ToWasmVMGas(source uint64) uint64
+}
+
+// query executes the request with the remaining gas of the invocation
+func (cp *CosmosPlugin) query(request types.QueryRequest) ([]byte, error) {
+	if cp.querier == nil {
+		return nil, errors.New("querier not available")
+	}
+	return cp.meteredQuery(func(gasLimit uint64) ([]byte, error) {
+		return cp.querier.Query(request, gasLimit)
+	})
+}
+
+// meteredQuery runs the query with the remaining gas of the invocation and adds the gas it consumed to the
+// query gas before the next query starts. Query gas is charged by the querier, the plugin only keeps it
+// from exceeding the invocation gas limit.
+func (cp *CosmosPlugin) meteredQuery(query func(gasLimit uint64) ([]byte, error)) ([]byte, error) {
+	gasLimit := cp.gasRemaining()
+	if gasLimit == 0 {
+		return nil, types.OutOfGasError{}
+	}
+	before := cp.querier.GasConsumed()
+	res, err := query(gasLimit)
+	used := cp.querier.GasConsumed() - before
+	if converter, ok := cp.querier.(QueryGasConverter); ok {
+		used = converter.ToWasmVMGas(used)
+	}
+	cp.queryGas += used
+	if cp.gasConsumed() > cp.gasLimit {
+		return nil, types.OutOfGasError{}
+	}
+	return res, err
+}
+
+func (cp *CosmosPlugin) queryJSON(request types.QueryRequest, response interface{}) error {
+	bz, err := cp.query(request)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bz, response)
+}
@@ -0,0 +1,301 @@
+package polywrapvm
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+	"github.com/btcsuite/btcd/btcec"
+
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/polywrap/go-client/msgpack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestCosmosPluginDbRange(t *testing.T) {
+	store := &dbadapter.Store{DB: dbm.NewMemDB()}
+	for _, k := range []string{"a", "b", "c", "d"} {
+		store.Set([]byte(k), []byte("value "+k))
+	}
+	kv := func(k string) KV {
+		return KV{Key: []byte(k), Value: []byte("value " + k)}
+	}
+
+	specs := map[string]struct {
+		args DbRangeArgType
+		exp  []KV
+	}{
+		"all ascending": {
+			args: DbRangeArgType{},
+			exp:  []KV{kv("a"), kv("b"), kv("c"), kv("d")},
+		},
+		"all descending": {
+			args: DbRangeArgType{Descending: true},
+			exp:  []KV{kv("d"), kv("c"), kv("b"), kv("a")},
+		},
+		"start inclusive, end exclusive": {
+			args: DbRangeArgType{Start: []byte("b"), End: []byte("d")},
+			exp:  []KV{kv("b"), kv("c")},
+		},
+		"bounds descending": {
+			args: DbRangeArgType{Start: []byte("b"), End: []byte("d"), Descending: true},
+			exp:  []KV{kv("c"), kv("b")},
+		},
+		"limit": {
+			args: DbRangeArgType{Start: []byte("b"), Limit: 2},
+			exp:  []KV{kv("b"), kv("c")},
+		},
+		"limit descending": {
+			args: DbRangeArgType{Descending: true, Limit: 1},
+			exp:  []KV{kv("d")},
+		},
+		"empty range": {
+			args: DbRangeArgType{Start: []byte("x")},
+			exp:  []KV{},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := msgpack.Encode(spec.args)
+			require.NoError(t, err)
+
+			plugin := NewCosmosPlugin(store, wasmvm.GoAPI{}, nil, 0)
+			args, err := plugin.EncodeArgs("DbRange", encoded)
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, plugin.DbRange(args.(DbRangeArgType)))
+		})
+	}
+}
+
+func TestCosmosPluginQuery(t *testing.T) {
+	var gotReq wasmvmtypes.QueryRequest
+	var gotGasLimit uint64
+	querier := mockQuerier(func(request wasmvmtypes.QueryRequest, gasLimit uint64) ([]byte, error) {
+		gotReq, gotGasLimit = request, gasLimit
+		switch {
+		case request.Bank != nil && request.Bank.Balance != nil:
+			return json.Marshal(wasmvmtypes.BalanceResponse{Amount: wasmvmtypes.NewCoin(100, request.Bank.Balance.Denom)})
+		case request.Bank != nil && request.Bank.AllBalances != nil:
+			return json.Marshal(wasmvmtypes.AllBalancesResponse{Amount: wasmvmtypes.Coins{wasmvmtypes.NewCoin(1, "a"), wasmvmtypes.NewCoin(2, "b")}})
+		case request.Wasm != nil:
+			return []byte("result"), nil
+		}
+		return nil, errors.New("unsupported")
+	})
+	plugin := NewCosmosPlugin(nil, wasmvm.GoAPI{}, querier, 1000)
+
+	coin, err := plugin.QueryBalance(QueryBalanceArgType{Address: "addr", Denom: "denom"})
+	require.NoError(t, err)
+	assert.Equal(t, Coin{Denom: "denom", Amount: "100"}, coin)
+	assert.Equal(t, "addr", gotReq.Bank.Balance.Address)
+	assert.Equal(t, uint64(1000), gotGasLimit)
+
+	coins, err := plugin.QueryAllBalances(QueryAllBalancesArgType{Address: "addr"})
+	require.NoError(t, err)
+	assert.Equal(t, []Coin{{Denom: "a", Amount: "1"}, {Denom: "b", Amount: "2"}}, coins)
+
+	res, err := plugin.QuerySmart(QuerySmartArgType{ContractAddr: "contract", Method: "sayHello", Msg: `{"foo":"bar"}`})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("result"), res)
+	assert.JSONEq(t, `{"wasmos":{"method":"sayHello","msg":{"foo":"bar"}}}`, string(gotReq.Wasm.Smart.Msg))
+
+	_, err = plugin.QuerySmart(QuerySmartArgType{ContractAddr: "contract", Msg: `{"foo":"bar"}`})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"foo":"bar"}`, string(gotReq.Wasm.Smart.Msg))
+
+	res, err = plugin.QueryRaw(QueryRawArgType{ContractAddr: "contract", Key: []byte("key")})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("result"), res)
+	assert.Equal(t, []byte("key"), gotReq.Wasm.Raw.Key)
+
+	_, err = plugin.Query(QueryArgType{Request: `{"staking":{"all_validators":{}}}`})
+	require.Error(t, err)
+	assert.NotNil(t, gotReq.Staking.AllValidators)
+
+	_, err = NewCosmosPlugin(nil, wasmvm.GoAPI{}, nil, 0).QueryBalance(QueryBalanceArgType{Address: "addr", Denom: "denom"})
+	require.Error(t, err)
+}
+
+func TestCosmosPluginQueryGas(t *testing.T) {
+	var gotGasLimits []uint64
+	querier := &gasQuerier{gasPerQuery: 10, mockQuerier: func(request wasmvmtypes.QueryRequest, gasLimit uint64) ([]byte, error) {
+		gotGasLimits = append(gotGasLimits, gasLimit)
+		return []byte("result"), nil
+	}}
+	plugin := NewCosmosPlugin(nil, wasmvm.GoAPI{}, querier, 1000)
+	require.NoError(t, plugin.consumeGas(100))
+
+	// every query gets the gas left after the previous ones, converted to wasmvm gas
+	for i := 0; i < 4; i++ {
+		_, err := plugin.QueryRaw(QueryRawArgType{ContractAddr: "contract", Key: []byte("key")})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []uint64{900, 700, 500, 300}, gotGasLimits)
+	// query gas is charged by the querier
+	assert.Equal(t, uint64(100), plugin.GasUsed())
+
+	// the query exceeding the limit fails, no further query is sent
+	_, err := plugin.QueryRaw(QueryRawArgType{ContractAddr: "contract", Key: []byte("key")})
+	assert.ErrorIs(t, err, wasmvmtypes.OutOfGasError{})
+	_, err = plugin.QueryRaw(QueryRawArgType{ContractAddr: "contract", Key: []byte("key")})
+	assert.ErrorIs(t, err, wasmvmtypes.OutOfGasError{})
+	assert.Equal(t, []uint64{900, 700, 500, 300, 100}, gotGasLimits)
+}
+
+func TestCosmosPluginAddr(t *testing.T) {
+	api := wasmvm.GoAPI{
+		HumanAddress: func(canon []byte) (string, uint64, error) {
+			if len(canon) == 0 {
+				return "", 5, errors.New("empty address")
+			}
+			return "human" + string(canon), 5, nil
+		},
+		CanonicalAddress: func(human string) ([]byte, uint64, error) {
+			if len(human) <= len("human") || human[:len("human")] != "human" {
+				return nil, 4, errors.New("invalid address")
+			}
+			return []byte(human[len("human"):]), 4, nil
+		},
+	}
+	plugin := NewCosmosPlugin(nil, api, nil, 100)
+
+	canonical, err := plugin.AddrCanonicalize(AddrArgType{Addr: "humanfoo"})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("foo"), canonical)
+	human, err := plugin.AddrHumanize(AddrHumanizeArgType{Canonical: []byte("foo")})
+	require.NoError(t, err)
+	assert.Equal(t, "humanfoo", human)
+	_, err = plugin.AddrCanonicalize(AddrArgType{Addr: "foo"})
+	require.Error(t, err)
+
+	valid, err := plugin.AddrValidate(AddrArgType{Addr: "humanfoo"})
+	require.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = plugin.AddrValidate(AddrArgType{Addr: "foo"})
+	require.NoError(t, err)
+	assert.False(t, valid)
+	assert.Equal(t, uint64(4+5+4+4+5+4), plugin.GasUsed())
+
+	// out of gas
+	plugin = NewCosmosPlugin(nil, api, nil, 8)
+	_, err = plugin.AddrValidate(AddrArgType{Addr: "humanfoo"})
+	assert.ErrorIs(t, err, wasmvmtypes.OutOfGasError{})
+
+	// no api
+	_, err = NewCosmosPlugin(nil, wasmvm.GoAPI{}, nil, 100).AddrCanonicalize(AddrArgType{Addr: "humanfoo"})
+	require.Error(t, err)
+}
+
+func TestCosmosPluginSecp256k1Verify(t *testing.T) {
+	privKey, err := btcec.NewPrivateKey(btcec.S256())
+	require.NoError(t, err)
+	hash := sha256.Sum256([]byte("message"))
+	sig, err := privKey.Sign(hash[:])
+	require.NoError(t, err)
+	sigBz := append(padTo32(sig.R), padTo32(sig.S)...)
+	highS := append(padTo32(sig.R), padTo32(new(big.Int).Sub(btcec.S256().N, sig.S))...)
+	otherHash := sha256.Sum256([]byte("other"))
+
+	specs := map[string]struct {
+		args Secp256k1VerifyArgType
+		exp  bool
+	}{
+		"compressed public key": {
+			args: Secp256k1VerifyArgType{MessageHash: hash[:], Signature: sigBz, PublicKey: privKey.PubKey().SerializeCompressed()},
+			exp:  true,
+		},
+		"uncompressed public key": {
+			args: Secp256k1VerifyArgType{MessageHash: hash[:], Signature: sigBz, PublicKey: privKey.PubKey().SerializeUncompressed()},
+			exp:  true,
+		},
+		"other message": {
+			args: Secp256k1VerifyArgType{MessageHash: otherHash[:], Signature: sigBz, PublicKey: privKey.PubKey().SerializeCompressed()},
+		},
+		"high s": {
+			args: Secp256k1VerifyArgType{MessageHash: hash[:], Signature: highS, PublicKey: privKey.PubKey().SerializeCompressed()},
+		},
+		"invalid public key": {
+			args: Secp256k1VerifyArgType{MessageHash: hash[:], Signature: sigBz, PublicKey: []byte("invalid")},
+		},
+		"invalid hash length": {
+			args: Secp256k1VerifyArgType{MessageHash: []byte("message"), Signature: sigBz, PublicKey: privKey.PubKey().SerializeCompressed()},
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			plugin := NewCosmosPlugin(nil, wasmvm.GoAPI{}, nil, secp256k1VerifyCost)
+			ok, err := plugin.Secp256k1Verify(spec.args)
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, ok)
+			assert.Equal(t, secp256k1VerifyCost, plugin.GasUsed())
+		})
+	}
+
+	// out of gas
+	plugin := NewCosmosPlugin(nil, wasmvm.GoAPI{}, nil, secp256k1VerifyCost-1)
+	_, err = plugin.Secp256k1Verify(specs["compressed public key"].args)
+	assert.ErrorIs(t, err, wasmvmtypes.OutOfGasError{})
+}
+
+func TestCosmosPluginEd25519Verify(t *testing.T) {
+	pubKey, privKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	sig := ed25519.Sign(privKey, []byte("message"))
+
+	plugin := NewCosmosPlugin(nil, wasmvm.GoAPI{}, nil, 3*ed25519VerifyCost)
+	ok, err := plugin.Ed25519Verify(Ed25519VerifyArgType{Message: []byte("message"), Signature: sig, PublicKey: pubKey})
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = plugin.Ed25519Verify(Ed25519VerifyArgType{Message: []byte("other"), Signature: sig, PublicKey: pubKey})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = plugin.Ed25519Verify(Ed25519VerifyArgType{Message: []byte("message"), Signature: sig, PublicKey: []byte("invalid")})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 3*ed25519VerifyCost, plugin.GasUsed())
+
+	_, err = plugin.Ed25519Verify(Ed25519VerifyArgType{Message: []byte("message"), Signature: sig, PublicKey: pubKey})
+	assert.ErrorIs(t, err, wasmvmtypes.OutOfGasError{})
+}
+
+func padTo32(i *big.Int) []byte {
+	bz := make([]byte, 32)
+	return i.FillBytes(bz)
+}
+
+type mockQuerier func(request wasmvmtypes.QueryRequest, gasLimit uint64) ([]byte, error)
+
+func (m mockQuerier) Query(request wasmvmtypes.QueryRequest, gasLimit uint64) ([]byte, error) {
+	return m(request, gasLimit)
+}
+
+func (m mockQuerier) GasConsumed() uint64 {
+	return 0
+}
+
+// gasQuerier consumes the same SDK gas for every query and converts it into 20 times the wasmvm gas
+type gasQuerier struct {
+	mockQuerier
+	gasPerQuery uint64
+	consumed    uint64
+}
+
+func (m *gasQuerier) Query(request wasmvmtypes.QueryRequest, gasLimit uint64) ([]byte, error) {
+	m.consumed += m.gasPerQuery
+	return m.mockQuerier(request, gasLimit)
+}
+
+func (m *gasQuerier) GasConsumed() uint64 {
+	return m.consumed
+}
+
+func (m *gasQuerier) ToWasmVMGas(source uint64) uint64 {
+	return source * 20
+}
@@ -0,0 +1,454 @@
+package polywrapvm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Capabilities required by wrappers that use wasm features outside the deterministic profile. Validators
+// must produce bit identical results, so nodes should never list them as available.
+const (
+	// CapabilityFloats is required by float types and instructions, whose NaN results are not deterministic
+	CapabilityFloats = "floats"
+	// CapabilitySIMD is required by v128 types and SIMD instructions
+	CapabilitySIMD = "simd"
+	// CapabilityThreads is required by shared memories and atomic instructions
+	CapabilityThreads = "threads"
+	// CapabilityBulkMemory is required by the bulk memory instructions and passive data segments
+	CapabilityBulkMemory = "bulk_memory"
+	// capabilityImportPrefix prefixes the `module.name` of every import not provided by the runtime
+	capabilityImportPrefix = "import:"
+)
+
+const (
+	wasmTypeSectionID   = 1
+	wasmImportSectionID = 2
+	wasmMemorySectionID = 5
+	wasmGlobalSectionID = 6
+	wasmCodeSectionID   = 10
+	wasmDataSectionID   = 11
+
+	wasmImportKindMemory = 2
+	wasmPageSize         = 64 << 10
+)
+
+var errInvalidWasm = errors.New("invalid wasm code")
+
+// analyzeCode statically checks the wrapper code against the deterministic profile. It returns the
+// capabilities the code requires, sorted by name. Memories that can not be capped at memoryLimitPages
+// are rejected.
+func analyzeCode(code []byte, memoryLimitPages uint32) ([]string, error) {
+	if !bytes.HasPrefix(code, wasmHeader) {
+		return nil, errInvalidWasm
+	}
+	required := make(map[string]bool)
+	r := bytes.NewReader(code[len(wasmHeader):])
+	for r.Len() > 0 {
+		id, err := r.ReadByte()
+		if err != nil {
+			return nil, errInvalidWasm
+		}
+		size, err := binary.ReadUvarint(r)
+		if err != nil || size > uint64(r.Len()) {
+			return nil, errInvalidWasm
+		}
+		section := make([]byte, size)
+		_, _ = r.Read(section)
+		s := bytes.NewReader(section)
+		switch id {
+		case wasmTypeSectionID:
+			err = analyzeTypes(s, required)
+		case wasmImportSectionID:
+			err = analyzeImports(s, required, memoryLimitPages)
+		case wasmMemorySectionID:
+			// only the imported memory is capped at runtime
+			err = forEach(s, func() error {
+				return errors.New("wrappers must import their memory from env.memory")
+			})
+		case wasmGlobalSectionID:
+			err = forEach(s, func() error {
+				if err := analyzeValueType(s, required); err != nil {
+					return err
+				}
+				if _, err := s.ReadByte(); err != nil {
+					return err
+				}
+				return analyzeExpr(s, required)
+			})
+		case wasmCodeSectionID:
+			err = forEach(s, func() error {
+				size, err := binary.ReadUvarint(s)
+				if err != nil || size > uint64(s.Len()) {
+					return errInvalidWasm
+				}
+				body := make([]byte, size)
+				_, _ = s.Read(body)
+				return analyzeFunc(bytes.NewReader(body), required)
+			})
+		case wasmDataSectionID:
+			err = forEach(s, func() error {
+				return analyzeDataSegment(s, required)
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	capabilities := make([]string, 0, len(required))
+	for c := range required {
+		capabilities = append(capabilities, c)
+	}
+	sort.Strings(capabilities)
+	return capabilities, nil
+}
+
+// unavailableCapabilities returns the required capabilities missing in the comma separated list of
+// available capabilities
+func unavailableCapabilities(required []string, available string) []string {
+	availableSet := make(map[string]bool)
+	for _, c := range strings.Split(available, ",") {
+		availableSet[strings.TrimSpace(c)] = true
+	}
+	var missing []string
+	for _, c := range required {
+		if !availableSet[c] {
+			missing = append(missing, c)
+		}
+	}
+	return missing
+}
+
+// forEach calls cb for every element of a wasm vector
+func forEach(r *bytes.Reader, cb func() error) error {
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return errInvalidWasm
+	}
+	for i := uint64(0); i < count; i++ {
+		if err := cb(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func analyzeTypes(r *bytes.Reader, required map[string]bool) error {
+	return forEach(r, func() error {
+		if form, err := r.ReadByte(); err != nil || form != 0x60 {
+			return errInvalidWasm
+		}
+		// params and results
+		for i := 0; i < 2; i++ {
+			if err := forEach(r, func() error { return analyzeValueType(r, required) }); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func analyzeValueType(r *bytes.Reader, required map[string]bool) error {
+	t, err := r.ReadByte()
+	if err != nil {
+		return errInvalidWasm
+	}
+	requireValueType(t, required)
+	return nil
+}
+
+func requireValueType(t byte, required map[string]bool) {
+	switch t {
+	case 0x7d, 0x7c: // f32, f64
+		required[CapabilityFloats] = true
+	case 0x7b: // v128
+		required[CapabilitySIMD] = true
+	}
+}
+
+func analyzeImports(r *bytes.Reader, required map[string]bool, memoryLimitPages uint32) error {
+	return forEach(r, func() error {
+		module, err := readName(r)
+		if err != nil {
+			return err
+		}
+		name, err := readName(r)
+		if err != nil {
+			return err
+		}
+		kind, err := r.ReadByte()
+		if err != nil {
+			return errInvalidWasm
+		}
+		if !isHostImport(module, name, kind) {
+			required[capabilityImportPrefix+module+"."+name] = true
+		}
+		switch kind {
+		case 0: // func
+			_, err = binary.ReadUvarint(r)
+		case 1: // table
+			if _, err := r.ReadByte(); err != nil {
+				return errInvalidWasm
+			}
+			_, _, err = readLimits(r)
+		case wasmImportKindMemory:
+			return analyzeMemory(r, required, memoryLimitPages)
+		case 3: // global
+			if err := analyzeValueType(r, required); err != nil {
+				return err
+			}
+			_, err = r.ReadByte()
+		default:
+			return errInvalidWasm
+		}
+		if err != nil {
+			return errInvalidWasm
+		}
+		return nil
+	})
+}
+
+// isHostImport returns true for the imports the runtime defines: the wrap host functions and the
+// env memory
+func isHostImport(module, name string, kind byte) bool {
+	if module == "env" && name == "memory" {
+		return kind == wasmImportKindMemory
+	}
+	return module == "wrap" && kind == 0 && hostImports[name]
+}
+
+func analyzeMemory(r *bytes.Reader, required map[string]bool, memoryLimitPages uint32) error {
+	flags, min, err := readLimits(r)
+	if err != nil {
+		return err
+	}
+	if flags&0x02 != 0 {
+		required[CapabilityThreads] = true
+	}
+	if min > uint64(memoryLimitPages) {
+		return fmt.Errorf("initial memory of %d pages exceeds the limit of %d pages", min, memoryLimitPages)
+	}
+	return nil
+}
+
+// readLimits returns the flags and the minimum of wasm limits
+func readLimits(r *bytes.Reader) (byte, uint64, error) {
+	flags, err := r.ReadByte()
+	if err != nil || flags > 0x03 {
+		return 0, 0, errInvalidWasm
+	}
+	min, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, 0, errInvalidWasm
+	}
+	if flags&0x01 != 0 {
+		if _, err := binary.ReadUvarint(r); err != nil {
+			return 0, 0, errInvalidWasm
+		}
+	}
+	return flags, min, nil
+}
+
+func analyzeDataSegment(r *bytes.Reader, required map[string]bool) error {
+	mode, err := binary.ReadUvarint(r)
+	if err != nil {
+		return errInvalidWasm
+	}
+	switch mode {
+	case 0: // active
+		err = analyzeExpr(r, required)
+	case 1: // passive, only used by memory.init
+		required[CapabilityBulkMemory] = true
+	case 2: // active with memory index
+		if _, err := binary.ReadUvarint(r); err != nil {
+			return errInvalidWasm
+		}
+		err = analyzeExpr(r, required)
+	default:
+		return errInvalidWasm
+	}
+	if err != nil {
+		return err
+	}
+	size, err := binary.ReadUvarint(r)
+	if err != nil || size > uint64(r.Len()) {
+		return errInvalidWasm
+	}
+	_, err = r.Seek(int64(size), 1)
+	return err
+}
+
+func readName(r *bytes.Reader) (string, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil || size > uint64(r.Len()) {
+		return "", errInvalidWasm
+	}
+	name := make([]byte, size)
+	_, _ = r.Read(name)
+	return string(name), nil
+}
+
+// analyzeFunc analyzes the locals and instructions of a function body
+func analyzeFunc(r *bytes.Reader, required map[string]bool) error {
+	err := forEach(r, func() error {
+		if _, err := binary.ReadUvarint(r); err != nil {
+			return errInvalidWasm
+		}
+		return analyzeValueType(r, required)
+	})
+	if err != nil {
+		return err
+	}
+	for r.Len() > 0 {
+		done, err := analyzeInstruction(r, required)
+		if err != nil || done {
+			return err
+		}
+	}
+	return nil
+}
+
+// analyzeExpr analyzes a constant expression up to its end
+func analyzeExpr(r *bytes.Reader, required map[string]bool) error {
+	for {
+		op, err := r.ReadByte()
+		if err != nil {
+			return errInvalidWasm
+		}
+		if op == 0x0b {
+			return nil
+		}
+		_ = r.UnreadByte()
+		done, err := analyzeInstruction(r, required)
+		if err != nil {
+			return err
+		}
+		if done {
+			return errInvalidWasm
+		}
+	}
+}
+
+// analyzeInstruction reads a single instruction with its immediates and records the capabilities it
+// requires. SIMD and atomic instructions can not be decoded without their whole opcode tables, so the
+// remaining instructions of the function are skipped after them and done is returned.
+func analyzeInstruction(r *bytes.Reader, required map[string]bool) (done bool, err error) {
+	op, err := r.ReadByte()
+	if err != nil {
+		return false, errInvalidWasm
+	}
+	switch {
+	case op <= 0x01, op == 0x05, op == 0x0b, op == 0x0f, op == 0x1a, op == 0x1b, op == 0xd1:
+		// no immediates
+	case op >= 0x02 && op <= 0x04: // block, loop, if
+		err = readBlockType(r, required)
+	case op == 0x0c, op == 0x0d, op == 0x10, op >= 0x20 && op <= 0x26, op == 0xd2:
+		err = skipUvarints(r, 1)
+	case op == 0x0e: // br_table
+		err = forEach(r, func() error { return skipUvarints(r, 1) })
+		if err == nil {
+			err = skipUvarints(r, 1)
+		}
+	case op == 0x11: // call_indirect
+		err = skipUvarints(r, 2)
+	case op == 0x1c: // select with types
+		err = forEach(r, func() error { return analyzeValueType(r, required) })
+	case op >= 0x28 && op <= 0x3e: // loads and stores
+		if op == 0x2a || op == 0x2b || op == 0x38 || op == 0x39 {
+			required[CapabilityFloats] = true
+		}
+		err = skipUvarints(r, 2)
+	case op == 0x3f, op == 0x40: // memory.size, memory.grow
+		err = skipUvarints(r, 1)
+	case op == 0x41, op == 0x42: // i32.const, i64.const
+		err = skipUvarints(r, 1)
+	case op == 0x43:
+		required[CapabilityFloats] = true
+		_, err = r.Seek(4, 1)
+	case op == 0x44:
+		required[CapabilityFloats] = true
+		_, err = r.Seek(8, 1)
+	case op >= 0x45 && op <= 0x5a, op >= 0x67 && op <= 0x8a, op == 0xa7, op == 0xac, op == 0xad, op >= 0xc0 && op <= 0xc4:
+		// integer instructions
+	case op >= 0x5b && op <= 0x66, op >= 0x8b && op <= 0xbf:
+		required[CapabilityFloats] = true
+	case op == 0xd0: // ref.null
+		_, err = r.ReadByte()
+	case op == 0xfc:
+		err = analyzeMiscInstruction(r, required)
+	case op == 0xfd:
+		required[CapabilitySIMD] = true
+		return true, nil
+	case op == 0xfe:
+		required[CapabilityThreads] = true
+		return true, nil
+	default:
+		return false, fmt.Errorf("%w: unsupported opcode 0x%02x", errInvalidWasm, op)
+	}
+	if err != nil && !errors.Is(err, errInvalidWasm) {
+		err = errInvalidWasm
+	}
+	return false, err
+}
+
+func analyzeMiscInstruction(r *bytes.Reader, required map[string]bool) error {
+	op, err := binary.ReadUvarint(r)
+	if err != nil {
+		return err
+	}
+	switch {
+	case op <= 7: // saturating float truncation
+		required[CapabilityFloats] = true
+		return nil
+	case op == 8: // memory.init
+		required[CapabilityBulkMemory] = true
+		return skipUvarints(r, 2)
+	case op == 9, op == 11: // data.drop, memory.fill
+		required[CapabilityBulkMemory] = true
+		return skipUvarints(r, 1)
+	case op == 10, op == 12, op == 14: // memory.copy, table.init, table.copy
+		required[CapabilityBulkMemory] = true
+		return skipUvarints(r, 2)
+	case op == 13: // elem.drop
+		required[CapabilityBulkMemory] = true
+		return skipUvarints(r, 1)
+	case op <= 17: // table.grow, table.size, table.fill
+		return skipUvarints(r, 1)
+	}
+	return fmt.Errorf("%w: unsupported opcode 0xfc %d", errInvalidWasm, op)
+}
+
+func readBlockType(r *bytes.Reader, required map[string]bool) error {
+	t, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	switch t {
+	case 0x40, 0x7f, 0x7e, 0x7d, 0x7c, 0x7b, 0x70, 0x6f:
+		requireValueType(t, required)
+		return nil
+	}
+	// type index as signed LEB128
+	_ = r.UnreadByte()
+	return skipUvarints(r, 1)
+}
+
+// skipUvarints skips n LEB128 encoded integers. Signed and unsigned integers share the encoding of
+// their continuation bits.
+func skipUvarints(r *bytes.Reader, n int) error {
+	for i := 0; i < n; i++ {
+		for {
+			b, err := r.ReadByte()
+			if err != nil {
+				return err
+			}
+			if b&0x80 == 0 {
+				break
+			}
+		}
+	}
+	return nil
+}
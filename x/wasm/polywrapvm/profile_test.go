@@ -0,0 +1,164 @@
+package polywrapvm
+
+import (
+	"os"
+	"testing"
+
+	"github.com/bytecodealliance/wasmtime-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// watWrapper is a wrapper module with the given imports and invoke function body
+func watWrapper(t *testing.T, imports, body string) []byte {
+	code, err := wasmtime.Wat2Wasm(`(module
+  (import "wrap" "__wrap_invoke_result" (func $invoke_result (param i32 i32)))
+  ` + imports + `
+  (func (export "_wrap_invoke") (param i32 i32 i32) (result i32)
+    ` + body + `))`)
+	require.NoError(t, err)
+	return code
+}
+
+func TestAnalyzeCode(t *testing.T) {
+	const memory = `(import "env" "memory" (memory 1))`
+	helloWorld, err := os.ReadFile("../keeper/testdata/hello_world.wasm")
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		code   []byte
+		exp    []string
+		expErr string
+	}{
+		"hello world": {
+			code: helloWorld,
+			exp:  []string{},
+		},
+		"integers only": {
+			code: watWrapper(t, memory, `(i32.add (i32.const 1) (i32.wrap_i64 (i64.extend_i32_s (i32.load offset=8 (i32.const 0)))))`),
+			exp:  []string{},
+		},
+		"float instruction": {
+			code: watWrapper(t, memory, `(i32.trunc_f64_s (f64.const 1.5))`),
+			exp:  []string{CapabilityFloats},
+		},
+		"float local": {
+			code: watWrapper(t, memory, `(local f32) (i32.const 1)`),
+			exp:  []string{CapabilityFloats},
+		},
+		"float global": {
+			code: watWrapper(t, memory+`(global $g f64 (f64.const 0))`, `(i32.const 1)`),
+			exp:  []string{CapabilityFloats},
+		},
+		"saturating truncation": {
+			code: watWrapper(t, memory, `(i32.trunc_sat_f32_s (f32.const 1))`),
+			exp:  []string{CapabilityFloats},
+		},
+		"simd": {
+			code: watWrapper(t, memory, `(i32x4.extract_lane 0 (v128.const i32x4 1 2 3 4))`),
+			exp:  []string{CapabilitySIMD},
+		},
+		"shared memory": {
+			code: watWrapper(t, `(import "env" "memory" (memory 1 2 shared))`, `(i32.const 1)`),
+			exp:  []string{CapabilityThreads},
+		},
+		"atomics": {
+			code: watWrapper(t, memory, `(i32.atomic.load (i32.const 0))`),
+			exp:  []string{CapabilityThreads},
+		},
+		"bulk memory": {
+			code: watWrapper(t, memory, `(memory.copy (i32.const 0) (i32.const 8) (i32.const 8)) (i32.const 1)`),
+			exp:  []string{CapabilityBulkMemory},
+		},
+		"passive data": {
+			code: watWrapper(t, memory+`(data "passive")`, `(i32.const 1)`),
+			exp:  []string{CapabilityBulkMemory},
+		},
+		"imports outside the runtime": {
+			code: watWrapper(t, memory+`
+  (import "env" "abort" (func (param i32)))
+  (import "wrap" "__wrap_unknown" (func))
+  (import "wrap" "__wrap_abort" (global i32))`, `(i32.const 1)`),
+			exp: []string{"import:env.abort", "import:wrap.__wrap_abort", "import:wrap.__wrap_unknown"},
+		},
+		"all at once": {
+			code: watWrapper(t, memory+`(import "wasi_snapshot_preview1" "fd_write" (func (param i32 i32 i32 i32) (result i32)))`,
+				`(memory.fill (i32.const 0) (i32.const 0) (i32.const 8)) (drop (f32.const 1)) (i32.const 1)`),
+			exp: []string{CapabilityBulkMemory, CapabilityFloats, "import:wasi_snapshot_preview1.fd_write"},
+		},
+		"memory above limit": {
+			code:   watWrapper(t, `(import "env" "memory" (memory 513))`, `(i32.const 1)`),
+			expErr: "initial memory of 513 pages exceeds the limit of 512 pages",
+		},
+		"own memory": {
+			code:   watWrapper(t, `(memory 1)`, `(i32.const 1)`),
+			expErr: "wrappers must import their memory",
+		},
+		"not wasm": {
+			code:   []byte("wrapper"),
+			expErr: "invalid wasm code",
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			capabilities, err := analyzeCode(spec.code, 512)
+			if spec.expErr != "" {
+				require.ErrorContains(t, err, spec.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, capabilities)
+		})
+	}
+}
+
+func TestUnavailableCapabilities(t *testing.T) {
+	assert.Nil(t, unavailableCapabilities(nil, ""))
+	assert.Equal(t, []string{CapabilityFloats}, unavailableCapabilities([]string{CapabilityFloats}, ""))
+	assert.Equal(t, []string{CapabilitySIMD}, unavailableCapabilities([]string{CapabilityFloats, CapabilitySIMD}, "iterator, floats"))
+}
+
+func TestVMValidateCode(t *testing.T) {
+	floats := watWrapper(t, `(import "env" "memory" (memory 1))`, `(i32.trunc_f64_s (f64.const 1.5))`)
+
+	vm, err := NewVM(t.TempDir(), "iterator,staking", 32, 0)
+	require.NoError(t, err)
+	require.EqualError(t, vm.ValidateCode(floats), "wrapper requires unavailable capabilities: floats")
+	notWrapper, err := wasmtime.Wat2Wasm(`(module)`)
+	require.NoError(t, err)
+	require.EqualError(t, vm.ValidateCode(notWrapper), "wasm code is not a wrapper: missing _wrap_invoke export")
+	// code accepted before is stored without the check
+	checksum, err := vm.Create(floats)
+	require.NoError(t, err)
+	report, err := vm.AnalyzeCode(checksum)
+	require.NoError(t, err)
+	assert.Equal(t, CapabilityFloats, report.RequiredCapabilities)
+
+	vm, err = NewVM(t.TempDir(), "iterator,floats", 32, 0)
+	require.NoError(t, err)
+	require.NoError(t, vm.ValidateCode(floats))
+}
+
+func TestRuntimeMemoryLimit(t *testing.T) {
+	// grows the memory by the page count in the first byte of the args and returns the result as
+	// msgpack int8
+	code := watWrapper(t, `
+  (import "wrap" "__wrap_invoke_args" (func $invoke_args (param i32 i32)))
+  (import "env" "memory" (memory 1))`, `
+    (call $invoke_args (i32.const 0) (i32.const 16))
+    (i32.store8 (i32.const 32) (i32.const 0xd0))
+    (i32.store8 (i32.const 33) (memory.grow (i32.load8_u (i32.const 16))))
+    (call $invoke_result (i32.const 32) (i32.const 2))
+    (i32.const 1)`)
+	r := newRuntime(1)
+	module, _, err := r.compile(code)
+	require.NoError(t, err)
+
+	// 1 MiB are 16 pages
+	data, _, err := r.invoke(module, "grow", []byte{15}, nil, nil, 1_000_000_000)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xd0, 1}, data)
+	data, _, err = r.invoke(module, "grow", []byte{16}, nil, nil, 1_000_000_000)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xd0, 0xff}, data)
+}
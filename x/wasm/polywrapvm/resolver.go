@@ -0,0 +1,26 @@
+package polywrapvm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/polywrap/go-client/wasm"
+	"github.com/polywrap/go-client/wasm/uri"
+)
+
+var _ wasm.Resolver = sandboxResolver{}
+
+// sandboxResolver resolves the URIs wrappers invoke through the polywrap client. It only serves the
+// packages registered with the VM, i.e. the cosmos plugin. Wrappers stored on chain are invoked through
+// wrap://wasmos and every other URI is rejected, so no wrapper loads anything from the local filesystem
+// or network and resolution is the same on every node.
+type sandboxResolver struct {
+	packages map[string]wasm.Package
+}
+
+func (r sandboxResolver) TryResolveUri(u uri.URI, _ wasm.Loader, _ context.Context) (any, error) {
+	if p, ok := r.packages[u.String()]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("unresolvable URI %s: wrappers can only invoke %s and wrap://%s URIs", u.String(), cosmosPluginURI, WasmosAuthority)
+}
@@ -0,0 +1,55 @@
+package polywrapvm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	"github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestSandboxResolver(t *testing.T) {
+	code, err := os.ReadFile("../keeper/testdata/hello_world.wasm")
+	require.NoError(t, err)
+	manifest, err := os.ReadFile("../keeper/testdata/hello_world.wrap.info")
+	require.NoError(t, err)
+	vm, err := NewVM(t.TempDir(), "", 32, 0)
+	require.NoError(t, err)
+	checksum, err := vm.Create(code)
+	require.NoError(t, err)
+
+	// a wrapper package on the local filesystem
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, wrapFile), code, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, manifestFile), manifest, 0o644))
+
+	store := &dbadapter.Store{DB: dbm.NewMemDB()}
+	store.Set([]byte("name"), []byte("Joe"))
+	call := newFrame(types.Env{Contract: types.ContractInfo{Address: "cosmos1caller"}}, nil, store, wasmvm.GoAPI{}, mockResolver{})
+	plugin := NewCosmosPlugin(store, call.goapi, call.querier, 1_000_000_000)
+	s := &subinvoker{vm: vm, call: call, plugin: plugin, client: vm.newClient(plugin)}
+
+	// the cosmos plugin resolves
+	_, _, err = s.InvokeMetered(cosmosPluginURI, "DbGet", encodeMsgpack(map[string]any{"key": []byte("name")}), 1_000_000_000)
+	require.NoError(t, err)
+
+	specs := map[string]string{
+		"filesystem":        "wrap://fs/" + dir,
+		"file":              "wrap://file/" + dir,
+		"wrapper directory": "wrap://fs/" + vm.getWasmFileDir(checksum),
+		"ens":               "wrap://ens/wrapper.eth",
+		"ipfs":              "wrap://ipfs/QmHash",
+		"http":              "wrap://http/example.com",
+	}
+	for name, rawURI := range specs {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := s.InvokeMetered(rawURI, "sayHello", encodeMsgpack(map[string]any{}), 1_000_000_000)
+			assert.ErrorContains(t, err, "unresolvable URI")
+		})
+	}
+}
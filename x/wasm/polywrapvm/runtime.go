@@ -0,0 +1,156 @@
+package polywrapvm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/CosmWasm/wasmvm/types"
+	"github.com/bytecodealliance/wasmtime-go"
+	"github.com/polywrap/go-client/wasm/uri"
+)
+
+// gasPerInstruction is the wasmvm gas charged for a single unit of fuel. wasmtime consumes
+// one unit of fuel per executed wasm operator, which matches the flat per-operator cost
+// of the CosmWasm singlepass metering so both runtimes share DefaultGasMultiplier.
+const gasPerInstruction = 150
+
+// Subinvoker dispatches a wrapper subinvocation to the resolved wrap:// URI.
+type Subinvoker interface {
+	Invoke(uri uri.URI, method string, args []byte, env []byte) ([]byte, error)
+}
+
+// runtime executes wrapper modules on a fuel metered wasmtime engine.
+type runtime struct {
+	engine *wasmtime.Engine
+	// memoryLimit is the maximal size of the wrapper memory in wasm pages
+	memoryLimit uint32
+}
+
+// newRuntime creates a runtime limiting the wrapper memory to memoryLimit MiB
+func newRuntime(memoryLimit uint32) *runtime {
+	config := wasmtime.NewConfig()
+	config.SetConsumeFuel(true)
+	return &runtime{
+		engine:      wasmtime.NewEngineWithConfig(config),
+		memoryLimit: uint32(uint64(memoryLimit) << 20 / wasmPageSize),
+	}
+}
+
+// invocationState holds the buffers exchanged with the wrapper through the wrap host imports.
+type invocationState struct {
+	method []byte
+	args   []byte
+	env    []byte
+
+	result          []byte
+	invokeError     []byte
+	subinvokeResult []byte
+	subinvokeError  []byte
+}
+
+// compile compiles the wrapper code for the engine. The returned size is the size of the compiled
+// module in bytes.
+func (r *runtime) compile(code []byte) (*wasmtime.Module, uint64, error) {
+	module, err := wasmtime.NewModule(r.engine, code)
+	if err != nil {
+		return nil, 0, err
+	}
+	compiled, err := module.Serialize()
+	if err != nil {
+		return nil, 0, err
+	}
+	return module, uint64(len(compiled)), nil
+}
+
+// invoke calls the wrapper method through the `_wrap_invoke` export. Execution aborts with
+// types.OutOfGasError when gasLimit is reached. The returned gas is the wasmvm gas actually used.
+func (r *runtime) invoke(module *wasmtime.Module, method string, args, env []byte, invoker Subinvoker, gasLimit uint64) (data []byte, gasUsed uint64, err error) {
+	store := wasmtime.NewStore(r.engine)
+	// writes to a read only store panic in the plugin and abort the invocation
+	defer func() {
+		if p := recover(); p != nil {
+			if p != errReadOnlyStore && p != errNoStore {
+				panic(p)
+			}
+			data, gasUsed, err = nil, gasConsumed(store), p.(error)
+		}
+	}()
+	fuelLimit := gasLimit / gasPerInstruction
+	if fuelLimit > math.MaxInt64 {
+		fuelLimit = math.MaxInt64
+	}
+	if err := store.AddFuel(fuelLimit); err != nil {
+		return nil, 0, err
+	}
+
+	state := &invocationState{
+		method: []byte(method),
+		args:   args,
+		env:    env,
+	}
+	memory, err := newImportedMemory(store, module, r.memoryLimit)
+	if err != nil {
+		return nil, 0, err
+	}
+	linker := wasmtime.NewLinker(r.engine)
+	if err := defineImports(linker, store, memory, state, invoker); err != nil {
+		return nil, 0, err
+	}
+	instance, err := linker.Instantiate(store, module)
+	if err != nil {
+		return nil, gasConsumed(store), err
+	}
+
+	export := instance.GetExport(store, "_wrap_invoke")
+	if export == nil || export.Func() == nil {
+		return nil, gasConsumed(store), errors.New("missing _wrap_invoke export")
+	}
+	ok, err := export.Func().Call(store, int32(len(state.method)), int32(len(state.args)), int32(len(state.env)))
+	gasUsed = gasConsumed(store)
+	// fuel is only checked at function entries and loop headers, so execution can overrun slightly
+	if gasUsed > gasLimit {
+		return nil, gasLimit, types.OutOfGasError{}
+	}
+	if err != nil {
+		if remaining, fuelErr := store.ConsumeFuel(0); fuelErr == nil && remaining == 0 {
+			return nil, gasLimit, types.OutOfGasError{}
+		}
+		return nil, gasUsed, err
+	}
+	if state.invokeError != nil {
+		return nil, gasUsed, fmt.Errorf("wrapper error: %s", state.invokeError)
+	}
+	if success, _ := ok.(int32); success == 0 {
+		return nil, gasUsed, errors.New("wrapper invocation failed")
+	}
+	return state.result, gasUsed, nil
+}
+
+func gasConsumed(store *wasmtime.Store) uint64 {
+	fuel, _ := store.FuelConsumed()
+	return fuel * gasPerInstruction
+}
+
+// newImportedMemory creates the "env" "memory" every wrapper module imports. The memory can not grow
+// beyond limit pages, memory.grow fails for wrappers exceeding it.
+func newImportedMemory(store *wasmtime.Store, module *wasmtime.Module, limit uint32) (*wasmtime.Memory, error) {
+	for _, imp := range module.Imports() {
+		if imp.Module() != "env" || imp.Name() == nil || *imp.Name() != "memory" {
+			continue
+		}
+		memoryType := imp.Type().MemoryType()
+		if memoryType == nil {
+			break
+		}
+		if memoryType.Minimum() > uint64(limit) {
+			return nil, fmt.Errorf("initial memory of %d pages exceeds the limit of %d pages", memoryType.Minimum(), limit)
+		}
+		max := uint64(limit)
+		if hasMax, declared := memoryType.Maximum(); hasMax && declared < max {
+			max = declared
+		}
+		return wasmtime.NewMemory(store, wasmtime.NewMemoryType(uint32(memoryType.Minimum()), true, uint32(max)))
+	}
+	return nil, errors.New("wrapper must import memory from env.memory")
+}
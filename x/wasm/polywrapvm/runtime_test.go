@@ -0,0 +1,58 @@
+package polywrapvm
+
+import (
+	"os"
+	"testing"
+
+	"github.com/CosmWasm/wasmvm/types"
+	"github.com/polywrap/go-client/msgpack"
+	"github.com/polywrap/go-client/wasm/uri"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSubinvoker struct {
+	InvokeFn func(uri uri.URI, method string, args []byte, env []byte) ([]byte, error)
+}
+
+func (m mockSubinvoker) Invoke(uri uri.URI, method string, args []byte, env []byte) ([]byte, error) {
+	return m.InvokeFn(uri, method, args, env)
+}
+
+func TestRuntimeInvokeGasMetering(t *testing.T) {
+	code, err := os.ReadFile("../keeper/testdata/hello_world.wasm")
+	require.NoError(t, err)
+
+	name, err := msgpack.Encode([]byte("Joe"))
+	require.NoError(t, err)
+	invoker := mockSubinvoker{InvokeFn: func(_ uri.URI, method string, _ []byte, _ []byte) ([]byte, error) {
+		require.Equal(t, "DbGet", method)
+		return name, nil
+	}}
+	args, err := msgpack.Encode(map[string]interface{}{})
+	require.NoError(t, err)
+
+	r := newRuntime(32)
+	module, _, err := r.compile(code)
+	require.NoError(t, err)
+	data, gasUsed, err := r.invoke(module, "sayHello", args, nil, invoker, 1_000_000_000)
+	require.NoError(t, err)
+	res, err := msgpack.Decode[string](data)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello from CosmoWrap, Joe", res)
+	assert.NotZero(t, gasUsed)
+	assert.Less(t, gasUsed, uint64(1_000_000_000))
+	assert.Zero(t, gasUsed%gasPerInstruction)
+
+	// same invocation is deterministic
+	_, gasUsedAgain, err := r.invoke(module, "sayHello", args, nil, invoker, 1_000_000_000)
+	require.NoError(t, err)
+	assert.Equal(t, gasUsed, gasUsedAgain)
+
+	// abort when the limit is below the gas required
+	gasLimit := gasUsed / 2
+	_, gasUsed, err = r.invoke(module, "sayHello", args, nil, invoker, gasLimit)
+	require.Error(t, err)
+	assert.IsType(t, types.OutOfGasError{}, err)
+	assert.Equal(t, gasLimit, gasUsed)
+}
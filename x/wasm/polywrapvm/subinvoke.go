@@ -0,0 +1,219 @@
+package polywrapvm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	"github.com/CosmWasm/wasmvm/types"
+	polywrapClient "github.com/polywrap/go-client/wasm/client"
+	"github.com/polywrap/go-client/wasm/uri"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// WasmosAuthority is the wrap:// URI authority of the contracts and codes stored on chain.
+// wrap://wasmos/<contract-address> invokes a wrapper contract, wrap://wasmos/code/<code-id> a wrapper code.
+const WasmosAuthority = "wasmos"
+
+// MaxCallDepth is the maximal nesting of wrap://wasmos subinvocations below the invoked contract
+const MaxCallDepth = 10
+
+// Contract is a wrapper contract resolved for a wrap://wasmos subinvocation
+type Contract struct {
+	Checksum wasmvm.Checksum
+	// Store is the isolated store of the contract
+	Store wasmvm.KVStore
+	// Querier serves the chain queries of the contract
+	Querier wasmvm.Querier
+	// Commit writes the state changes of the subinvocation back to the caller. Store and Querier work on
+	// a branch of the caller state, so nothing is kept unless the subinvocation succeeds.
+	Commit func()
+}
+
+// ContractResolver is implemented by the queriers passed to the VM to give wrappers access to the
+// contracts and codes on chain. Without it wrap://wasmos subinvocations fail.
+type ContractResolver interface {
+	// ResolveContract returns the wrapper contract with the given bech32 address
+	ResolveContract(address string) (*Contract, error)
+	// ResolveCode returns the checksum of the wrapper code with the given id
+	ResolveCode(codeID uint64) (wasmvm.Checksum, error)
+}
+
+// frame is the chain context of a wrapper invocation. Subinvocations derive their frame from it.
+type frame struct {
+	env      types.Env
+	info     *types.MessageInfo
+	store    wasmvm.KVStore
+	goapi    wasmvm.GoAPI
+	querier  wasmvm.Querier
+	readOnly bool
+	// depth is the number of subinvocations above this one
+	depth int
+	// stack holds the contracts of the current call chain, the invoking contract last
+	stack []string
+	// events collects the events emitted through the cosmos plugin
+	events *eventLog
+}
+
+func newFrame(env types.Env, info *types.MessageInfo, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier) frame {
+	return frame{env: env, info: info, store: store, goapi: goapi, querier: querier, stack: []string{env.Contract.Address}, events: &eventLog{}}
+}
+
+// subinvoker routes the subinvocations of a wrapper. wrap://wasmos URIs are resolved against the
+// chain state, any other URI is passed on to the polywrap client.
+type subinvoker struct {
+	vm     *VM
+	call   frame
+	plugin *CosmosPlugin
+	client *polywrapClient.Client
+}
+
+// Invoke implements Subinvoker for URIs outside of the wasmos authority
+func (s *subinvoker) Invoke(u uri.URI, method string, args []byte, env []byte) ([]byte, error) {
+	if u.Authority == WasmosAuthority {
+		return nil, errors.New("wasmos subinvocations must be metered")
+	}
+	return s.client.Invoke(u, method, args, env)
+}
+
+// InvokeMetered invokes the wrapper at rawURI with the remaining gas of the caller. The returned gas
+// is charged to the caller.
+func (s *subinvoker) InvokeMetered(rawURI, method string, args []byte, gasLimit uint64) ([]byte, uint64, error) {
+	path, ok := wasmosPath(rawURI)
+	if !ok {
+		u, err := uri.New(rawURI)
+		if err != nil {
+			return nil, 0, err
+		}
+		res, err := s.Invoke(*u, method, args, nil)
+		return res, 0, err
+	}
+	// plugin and query gas of the caller are charged separately, so they are not available to the callee
+	if used := s.plugin.gasConsumed(); used < gasLimit {
+		gasLimit -= used
+	} else {
+		gasLimit = 0
+	}
+	if s.call.depth >= MaxCallDepth {
+		return nil, 0, fmt.Errorf("max call depth %d exceeded", MaxCallDepth)
+	}
+	resolver, ok := s.call.querier.(ContractResolver)
+	if !ok {
+		return nil, 0, errors.New("wasmos subinvocations not supported")
+	}
+	checksum, call, commit, err := s.resolve(resolver, path)
+	if err != nil {
+		return nil, 0, err
+	}
+	module, err := s.vm.module(checksum)
+	if err != nil {
+		return nil, 0, err
+	}
+	data, gasUsed, err := s.vm.invoke(module, method, args, call, gasLimit)
+	if err != nil {
+		return nil, gasUsed, err
+	}
+	if call.env.Contract.Address == s.call.env.Contract.Address {
+		// code acts on behalf of the caller, so do its events
+		s.call.events.append(call.events)
+		return data, gasUsed, nil
+	}
+	// the events of a contract subinvocation belong to the callee
+	if err := call.events.emit(call.querier); err != nil {
+		return nil, gasUsed, err
+	}
+	commit()
+	return data, gasUsed, nil
+}
+
+// resolve returns the code and frame of the subinvocation for the path of a wasmos URI. Contract
+// subinvocations come with the commit of their state changes, code subinvocations have no state.
+func (s *subinvoker) resolve(resolver ContractResolver, path string) (wasmvm.Checksum, frame, func(), error) {
+	if strings.HasPrefix(path, "code/") {
+		id := strings.TrimPrefix(path, "code/")
+		codeID, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return nil, frame{}, nil, fmt.Errorf("invalid code id %q", id)
+		}
+		checksum, err := resolver.ResolveCode(codeID)
+		if err != nil {
+			return nil, frame{}, nil, err
+		}
+		// code runs in the context of the caller without access to any store
+		call := s.call
+		call.store = emptyStore{}
+		call.events = &eventLog{}
+		call.depth++
+		return checksum, call, nil, nil
+	}
+
+	for _, caller := range s.call.stack {
+		if caller == path {
+			return nil, frame{}, nil, fmt.Errorf("reentrant call of contract %s", path)
+		}
+	}
+	contract, err := resolver.ResolveContract(path)
+	if err != nil {
+		return nil, frame{}, nil, err
+	}
+	call := frame{
+		env:      s.call.env,
+		store:    contract.Store,
+		goapi:    s.call.goapi,
+		querier:  contract.Querier,
+		readOnly: s.call.readOnly,
+		depth:    s.call.depth + 1,
+		stack:    append(append([]string{}, s.call.stack...), path),
+		events:   &eventLog{},
+	}
+	call.env.Contract.Address = path
+	if !call.readOnly {
+		// code subinvocations act on behalf of the closest contract
+		caller := s.call.stack[len(s.call.stack)-1]
+		call.info = &types.MessageInfo{Sender: caller, Funds: types.Coins{}}
+	}
+	return contract.Checksum, call, contract.Commit, nil
+}
+
+// wasmosPath returns the path of a wrap://wasmos URI
+func wasmosPath(rawURI string) (string, bool) {
+	path := strings.TrimPrefix(rawURI, "wrap://")
+	if !strings.HasPrefix(path, WasmosAuthority+"/") {
+		return "", false
+	}
+	path = strings.TrimPrefix(path, WasmosAuthority+"/")
+	return path, path != ""
+}
+
+var errNoStore = errors.New("code subinvocations have no store")
+
+// emptyStore is the store of code subinvocations. It holds no data and rejects all writes.
+type emptyStore struct{}
+
+func (emptyStore) Get(_ []byte) []byte { return nil }
+
+func (emptyStore) Set(_, _ []byte) { panic(errNoStore) }
+
+func (emptyStore) Delete(_ []byte) { panic(errNoStore) }
+
+func (emptyStore) Iterator(_, _ []byte) dbm.Iterator { return emptyIterator{} }
+
+func (emptyStore) ReverseIterator(_, _ []byte) dbm.Iterator { return emptyIterator{} }
+
+type emptyIterator struct{}
+
+func (emptyIterator) Domain() (start []byte, end []byte) { return nil, nil }
+
+func (emptyIterator) Valid() bool { return false }
+
+func (emptyIterator) Next() {}
+
+func (emptyIterator) Key() []byte { return nil }
+
+func (emptyIterator) Value() []byte { return nil }
+
+func (emptyIterator) Error() error { return nil }
+
+func (emptyIterator) Close() error { return nil }
@@ -0,0 +1,220 @@
+package polywrapvm
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	"github.com/CosmWasm/wasmvm/types"
+	"github.com/bytecodealliance/wasmtime-go"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+type mockResolver struct {
+	mockQuerier
+	contracts map[string]*Contract
+	codes     map[uint64]wasmvm.Checksum
+}
+
+func (m mockResolver) ResolveContract(address string) (*Contract, error) {
+	if c, ok := m.contracts[address]; ok {
+		return c, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (m mockResolver) ResolveCode(codeID uint64) (wasmvm.Checksum, error) {
+	if c, ok := m.codes[codeID]; ok {
+		return c, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func TestWasmosPath(t *testing.T) {
+	specs := map[string]struct {
+		src     string
+		expPath string
+		expOK   bool
+	}{
+		"contract":        {src: "wrap://wasmos/cosmos1contract", expPath: "cosmos1contract", expOK: true},
+		"code":            {src: "wrap://wasmos/code/1", expPath: "code/1", expOK: true},
+		"without scheme":  {src: "wasmos/cosmos1contract", expPath: "cosmos1contract", expOK: true},
+		"empty path":      {src: "wrap://wasmos/"},
+		"other authority": {src: "wrap://cosmos/cosmos.eth"},
+		"authority only":  {src: "wrap://wasmos"},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			path, ok := wasmosPath(spec.src)
+			assert.Equal(t, spec.expOK, ok)
+			if spec.expOK {
+				assert.Equal(t, spec.expPath, path)
+			}
+		})
+	}
+}
+
+func TestSubinvokerResolve(t *testing.T) {
+	var committed bool
+	callee := &Contract{Checksum: wasmvm.Checksum{1}, Store: &dbadapter.Store{DB: dbm.NewMemDB()}, Querier: mockResolver{}, Commit: func() { committed = true }}
+	resolver := mockResolver{
+		contracts: map[string]*Contract{"cosmos1callee": callee, "cosmos1caller": callee},
+		codes:     map[uint64]wasmvm.Checksum{1: {2}},
+	}
+	env := types.Env{Contract: types.ContractInfo{Address: "cosmos1caller"}}
+	info := &types.MessageInfo{Sender: "cosmos1sender", Funds: types.Coins{types.NewCoin(1, "denom")}}
+	caller := newFrame(env, info, &dbadapter.Store{DB: dbm.NewMemDB()}, wasmvm.GoAPI{}, resolver)
+	s := &subinvoker{call: caller}
+
+	checksum, call, commit, err := s.resolve(resolver, "cosmos1callee")
+	require.NoError(t, err)
+	commit()
+	assert.True(t, committed)
+	assert.Equal(t, callee.Checksum, checksum)
+	assert.Equal(t, "cosmos1callee", call.env.Contract.Address)
+	assert.Equal(t, &types.MessageInfo{Sender: "cosmos1caller", Funds: types.Coins{}}, call.info)
+	assert.Equal(t, callee.Store, call.store)
+	assert.Equal(t, 1, call.depth)
+	assert.Equal(t, []string{"cosmos1caller", "cosmos1callee"}, call.stack)
+
+	// code runs in the frame of the caller without a store
+	checksum, call, commit, err = s.resolve(resolver, "code/1")
+	require.NoError(t, err)
+	assert.Nil(t, commit)
+	assert.Equal(t, wasmvm.Checksum{2}, checksum)
+	assert.Equal(t, "cosmos1caller", call.env.Contract.Address)
+	assert.Equal(t, info, call.info)
+	assert.Equal(t, emptyStore{}, call.store)
+	assert.Equal(t, 1, call.depth)
+	assert.Equal(t, []string{"cosmos1caller"}, call.stack)
+
+	// contracts called from code are called by the closest contract
+	_, call, _, err = (&subinvoker{call: call}).resolve(resolver, "cosmos1callee")
+	require.NoError(t, err)
+	assert.Equal(t, "cosmos1caller", call.info.Sender)
+	assert.Equal(t, 2, call.depth)
+
+	// queries stay read only and have no message info
+	readOnly := caller
+	readOnly.readOnly = true
+	_, call, _, err = (&subinvoker{call: readOnly}).resolve(resolver, "cosmos1callee")
+	require.NoError(t, err)
+	assert.True(t, call.readOnly)
+	assert.Nil(t, call.info)
+
+	specs := map[string]string{
+		"reentrancy":       "cosmos1caller",
+		"unknown contract": "cosmos1other",
+		"unknown code":     "code/2",
+		"invalid code id":  "code/one",
+	}
+	for name, path := range specs {
+		t.Run(name, func(t *testing.T) {
+			_, _, _, err := s.resolve(resolver, path)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestSubinvokerInvokeMetered(t *testing.T) {
+	code, err := os.ReadFile("../keeper/testdata/hello_world.wasm")
+	require.NoError(t, err)
+	vm, err := NewVM(t.TempDir(), "", 32, 0)
+	require.NoError(t, err)
+	checksum, err := vm.Create(code)
+	require.NoError(t, err)
+
+	store := &dbadapter.Store{DB: dbm.NewMemDB()}
+	store.Set([]byte("name"), []byte("Joe"))
+	var commits int
+	resolver := mockResolver{contracts: map[string]*Contract{"cosmos1callee": {Checksum: checksum, Store: store, Querier: mockResolver{}, Commit: func() { commits++ }}}}
+	env := types.Env{Contract: types.ContractInfo{Address: "cosmos1caller"}}
+	newInvoker := func(call frame) *subinvoker {
+		plugin := NewCosmosPlugin(call.store, call.goapi, call.querier, 1_000_000_000)
+		return &subinvoker{vm: vm, call: call, plugin: plugin, client: vm.newClient(plugin)}
+	}
+	args := encodeMsgpack(map[string]any{})
+
+	s := newInvoker(newFrame(env, nil, &dbadapter.Store{DB: dbm.NewMemDB()}, wasmvm.GoAPI{}, resolver))
+	data, gasUsed, err := s.InvokeMetered("wrap://wasmos/cosmos1callee", "sayHello", args, 1_000_000_000)
+	require.NoError(t, err)
+	res, err := decodeMsgpack(data)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello from CosmoWrap, Joe", res)
+	assert.NotZero(t, gasUsed)
+	assert.Equal(t, 1, commits)
+
+	// the callee is limited to the gas left to the caller, its changes are dropped when it fails
+	_, _, err = s.InvokeMetered("wrap://wasmos/cosmos1callee", "sayHello", args, gasUsed/2)
+	assert.IsType(t, types.OutOfGasError{}, err)
+	assert.Equal(t, 1, commits)
+
+	// call depth is limited
+	deep := s.call
+	deep.depth = MaxCallDepth
+	_, _, err = newInvoker(deep).InvokeMetered("wrap://wasmos/cosmos1callee", "sayHello", args, 1_000_000_000)
+	assert.ErrorContains(t, err, "max call depth")
+
+	// queriers without a resolver reject wasmos subinvocations
+	_, _, err = newInvoker(newFrame(env, nil, store, wasmvm.GoAPI{}, mockQuerier(nil))).InvokeMetered("wrap://wasmos/cosmos1callee", "sayHello", args, 1_000_000_000)
+	assert.ErrorContains(t, err, "not supported")
+}
+
+func TestInvokeMeteredChargesCaller(t *testing.T) {
+	engine := newRuntime(32).engine
+	newStore := func(fuel uint64) *wasmtime.Store {
+		store := wasmtime.NewStore(engine)
+		require.NoError(t, store.AddFuel(fuel))
+		return store
+	}
+	specs := map[string]struct {
+		gasUsed      uint64
+		err          error
+		expRemaining uint64
+		expErr       error
+	}{
+		"charged in fuel": {
+			gasUsed: 10 * gasPerInstruction, expRemaining: 90,
+		},
+		"rounded up": {
+			gasUsed: 10*gasPerInstruction + 1, expRemaining: 89,
+		},
+		"error charged": {
+			gasUsed: gasPerInstruction, err: errors.New("failed"), expRemaining: 99, expErr: errors.New("failed"),
+		},
+		"all gas used": {
+			gasUsed: 100 * gasPerInstruction, expErr: errOutOfFuel,
+		},
+		"callee out of gas": {
+			gasUsed: gasPerInstruction, err: types.OutOfGasError{}, expErr: errOutOfFuel,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(100)
+			var gasLimit uint64
+			invoker := meteredInvokerFn(func(_, _ string, _ []byte, limit uint64) ([]byte, uint64, error) {
+				gasLimit = limit
+				return nil, spec.gasUsed, spec.err
+			})
+			_, err := invokeMetered(store, invoker, "wrap://wasmos/cosmos1callee", "method", nil)
+			assert.Equal(t, spec.expErr, err)
+			assert.Equal(t, 100*uint64(gasPerInstruction), gasLimit)
+			if spec.expErr != errOutOfFuel {
+				remaining, err := store.ConsumeFuel(0)
+				require.NoError(t, err)
+				assert.Equal(t, spec.expRemaining, remaining)
+			}
+		})
+	}
+}
+
+type meteredInvokerFn func(uri, method string, args []byte, gasLimit uint64) ([]byte, uint64, error)
+
+func (f meteredInvokerFn) InvokeMetered(uri, method string, args []byte, gasLimit uint64) ([]byte, uint64, error) {
+	return f(uri, method, args, gasLimit)
+}
@@ -0,0 +1,206 @@
+package polywrapvm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/CosmWasm/wasmvm/types"
+)
+
+// Env is passed msgpack encoded as polywrap invocation env to every wrapper entry point.
+// The map fields are encoded in declaration order.
+type Env struct {
+	Block BlockInfo
+	// Transaction is nil when not executed in a transaction
+	Transaction *TransactionInfo
+	Contract    ContractInfo
+	// Info is only set for init and execute
+	Info *MessageInfo
+}
+
+type BlockInfo struct {
+	Height uint64
+	// Time in nanoseconds since unix epoch
+	Time    uint64
+	ChainId string
+}
+
+type TransactionInfo struct {
+	// Index is the position of the transaction in the block
+	Index uint32
+}
+
+type ContractInfo struct {
+	// Address is the bech32 address of the contract
+	Address string
+}
+
+type MessageInfo struct {
+	// Sender is the bech32 address executing the contract
+	Sender string
+	// Funds sent to the contract along with the message
+	Funds []Coin
+}
+
+type Coin struct {
+	Denom  string
+	Amount string
+}
+
+// NewEnv converts the wasmvm env and message info into the wrapper env schema. Info can be nil.
+func NewEnv(env types.Env, info *types.MessageInfo) Env {
+	res := Env{
+		Block: BlockInfo{
+			Height:  env.Block.Height,
+			Time:    env.Block.Time,
+			ChainId: env.Block.ChainID,
+		},
+		Contract: ContractInfo{
+			Address: env.Contract.Address,
+		},
+	}
+	if env.Transaction != nil {
+		res.Transaction = &TransactionInfo{Index: env.Transaction.Index}
+	}
+	if info != nil {
+		funds := make([]Coin, len(info.Funds))
+		for i, c := range info.Funds {
+			funds[i] = Coin{Denom: c.Denom, Amount: c.Amount}
+		}
+		res.Info = &MessageInfo{
+			Sender: info.Sender,
+			Funds:  funds,
+		}
+	}
+	return res
+}
+
+// Response is the result schema of the wrapper entry points. It mirrors the CosmWasm contract
+// response so that messages are dispatched and events emitted by the keeper. The map fields must
+// be encoded in declaration order.
+type Response struct {
+	// Messages to dispatch after the invocation
+	Messages []SubMsg
+	// Data returned to the caller
+	Data []byte
+	// Attributes added to the "wasm" event
+	Attributes []EventAttribute
+	// Events emitted as "wasm-<type>" events
+	Events []Event
+}
+
+// SubMsg is a message to dispatch with optional reply handling.
+type SubMsg struct {
+	// Id is passed back to the "reply" method
+	Id uint64
+	// Msg is the JSON encoded CosmWasm CosmosMsg, e.g. {"bank":{"send":{...}}}
+	Msg string
+	// GasLimit for the message execution, nil for no limit
+	GasLimit *uint64
+	// ReplyOn is one of "always", "success", "error" or "never". Empty means "never".
+	ReplyOn string
+}
+
+// Reply is the argument of the wrapper "reply" method. It carries the result of a submessage
+// dispatched with a reply on success or error.
+type Reply struct {
+	Id     uint64
+	Result SubMsgResult
+}
+
+// SubMsgResult contains either the submessage response or the error message.
+type SubMsgResult struct {
+	Ok  *SubMsgResponse
+	Err *string
+}
+
+// SubMsgResponse holds the events and data of a successful submessage execution.
+type SubMsgResponse struct {
+	Events []Event
+	Data   []byte
+}
+
+type Event struct {
+	Type       string
+	Attributes []EventAttribute
+}
+
+type EventAttribute struct {
+	Key   string
+	Value string
+}
+
+// toWasmVMResponse converts the wrapper response into the wasmvm response processed by the keeper
+func (r Response) toWasmVMResponse() (*types.Response, error) {
+	msgs := make([]types.SubMsg, len(r.Messages))
+	for i, m := range r.Messages {
+		var msg types.CosmosMsg
+		if err := json.Unmarshal([]byte(m.Msg), &msg); err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+		msgs[i] = types.SubMsg{
+			ID:       m.Id,
+			Msg:      msg,
+			GasLimit: m.GasLimit,
+			ReplyOn:  types.ReplyNever,
+		}
+		if m.ReplyOn != "" {
+			if err := msgs[i].ReplyOn.UnmarshalJSON([]byte(strconv.Quote(m.ReplyOn))); err != nil {
+				return nil, fmt.Errorf("message %d: %w", i, err)
+			}
+		}
+	}
+	attrs := make(types.EventAttributes, len(r.Attributes))
+	for i, a := range r.Attributes {
+		attrs[i] = types.EventAttribute{Key: a.Key, Value: a.Value}
+	}
+	events := make(types.Events, len(r.Events))
+	for i, e := range r.Events {
+		eventAttrs := make(types.EventAttributes, len(e.Attributes))
+		for j, a := range e.Attributes {
+			eventAttrs[j] = types.EventAttribute{Key: a.Key, Value: a.Value}
+		}
+		events[i] = types.Event{Type: e.Type, Attributes: eventAttrs}
+	}
+	return &types.Response{
+		Messages:   msgs,
+		Data:       r.Data,
+		Attributes: attrs,
+		Events:     events,
+	}, nil
+}
+
+type replyArgs struct {
+	Reply Reply
+}
+
+// NewReply converts the wasmvm reply into the wrapper reply schema
+func NewReply(reply types.Reply) Reply {
+	var result SubMsgResult
+	if reply.Result.Ok != nil {
+		result.Ok = &SubMsgResponse{
+			Events: newEvents(reply.Result.Ok.Events),
+			Data:   reply.Result.Ok.Data,
+		}
+	} else {
+		err := reply.Result.Err
+		result.Err = &err
+	}
+	return Reply{
+		Id:     reply.ID,
+		Result: result,
+	}
+}
+
+func newEvents(events types.Events) []Event {
+	res := make([]Event, len(events))
+	for i, e := range events {
+		attrs := make([]EventAttribute, len(e.Attributes))
+		for j, a := range e.Attributes {
+			attrs[j] = EventAttribute{Key: a.Key, Value: a.Value}
+		}
+		res[i] = Event{Type: e.Type, Attributes: attrs}
+	}
+	return res
+}
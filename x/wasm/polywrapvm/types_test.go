@@ -0,0 +1,155 @@
+package polywrapvm
+
+import (
+	"testing"
+
+	"github.com/CosmWasm/wasmvm/types"
+	"github.com/polywrap/go-client/msgpack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeResponse(t *testing.T) {
+	mustEncode := func(v any) []byte {
+		bz, err := msgpack.Encode(v)
+		require.NoError(t, err)
+		return bz
+	}
+	gasLimit := uint64(1000)
+
+	specs := map[string]struct {
+		src    []byte
+		exp    *types.Response
+		expErr bool
+	}{
+		"response": {
+			src: mustEncode(Response{
+				Messages: []SubMsg{{
+					Id:       1,
+					Msg:      `{"bank":{"burn":{"amount":[{"denom":"denom","amount":"1"}]}}}`,
+					GasLimit: &gasLimit,
+					ReplyOn:  "success",
+				}},
+				Data:       []byte("data"),
+				Attributes: []EventAttribute{{Key: "foo", Value: "bar"}},
+				Events:     []Event{{Type: "custom", Attributes: []EventAttribute{{Key: "baz", Value: "qux"}}}},
+			}),
+			exp: &types.Response{
+				Messages: []types.SubMsg{{
+					ID:       1,
+					Msg:      types.CosmosMsg{Bank: &types.BankMsg{Burn: &types.BurnMsg{Amount: types.Coins{{Denom: "denom", Amount: "1"}}}}},
+					GasLimit: &gasLimit,
+					ReplyOn:  types.ReplySuccess,
+				}},
+				Data:       []byte("data"),
+				Attributes: types.EventAttributes{{Key: "foo", Value: "bar"}},
+				Events:     types.Events{{Type: "custom", Attributes: types.EventAttributes{{Key: "baz", Value: "qux"}}}},
+			},
+		},
+		"reply on defaults to never": {
+			src: mustEncode(Response{Messages: []SubMsg{{Msg: `{"bank":{"burn":{"amount":[]}}}`}}}),
+			exp: &types.Response{
+				Messages:   []types.SubMsg{{Msg: types.CosmosMsg{Bank: &types.BankMsg{Burn: &types.BurnMsg{}}}, ReplyOn: types.ReplyNever}},
+				Attributes: types.EventAttributes{},
+				Events:     types.Events{},
+			},
+		},
+		"string": {
+			src: mustEncode("data"),
+			exp: &types.Response{Data: []byte("data")},
+		},
+		"init result": {
+			src: mustEncode(InitResult{Result: "data"}),
+			exp: &types.Response{Data: []byte("data")},
+		},
+		"invalid message": {
+			src:    mustEncode(Response{Messages: []SubMsg{{Msg: "not json"}}}),
+			expErr: true,
+		},
+		"invalid reply on": {
+			src:    mustEncode(Response{Messages: []SubMsg{{Msg: `{"bank":{"burn":{"amount":[]}}}`, ReplyOn: "sometimes"}}}),
+			expErr: true,
+		},
+		"malformed": {
+			src:    []byte{0xc1},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, err := decodeResponse(spec.src)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, got)
+		})
+	}
+}
+
+func TestQueryResult(t *testing.T) {
+	mustEncode := func(v any) []byte {
+		bz, err := msgpack.Encode(v)
+		require.NoError(t, err)
+		return bz
+	}
+
+	specs := map[string]struct {
+		src    []byte
+		exp    string
+		expErr bool
+	}{
+		"string": {
+			src: mustEncode("Hello World!"),
+			exp: "Hello World!",
+		},
+		"response": {
+			src: mustEncode(Response{Data: []byte("data")}),
+			exp: "data",
+		},
+		"init result": {
+			src: mustEncode(InitResult{Result: "data"}),
+			exp: "data",
+		},
+		"object": {
+			src: encodeMsgpack(map[string]any{"name": "foo", "count": uint64(2), "raw": []byte{1, 2}, "tags": []any{"a", nil}}),
+			exp: `{"count":2,"name":"foo","raw":"AQI=","tags":["a",null]}`,
+		},
+		"number": {
+			src: encodeMsgpack(int64(-42)),
+			exp: "-42",
+		},
+		"boolean": {
+			src: encodeMsgpack(true),
+			exp: "true",
+		},
+		"list": {
+			src: encodeMsgpack([]any{int64(1), int64(2)}),
+			exp: "[1,2]",
+		},
+		"nil": {
+			src: encodeMsgpack(nil),
+			exp: "",
+		},
+		"response with messages": {
+			src:    mustEncode(Response{Messages: []SubMsg{{Msg: `{"bank":{"burn":{"amount":[]}}}`, ReplyOn: "never"}}}),
+			expErr: true,
+		},
+		"malformed": {
+			src:    []byte{0xc1},
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			got, err := queryResult(spec.src)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.exp, string(got))
+		})
+	}
+}
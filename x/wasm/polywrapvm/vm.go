@@ -1,11 +1,15 @@
 package polywrapvm
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/CosmWasm/wasmvm/types"
+	"github.com/bytecodealliance/wasmtime-go"
+	"github.com/polywrap/go-client/msgpack"
 	"github.com/polywrap/go-client/plugin"
 	"github.com/polywrap/go-client/wasm"
 	polywrapClient "github.com/polywrap/go-client/wasm/client"
@@ -13,17 +17,32 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 
 	wasmvm "github.com/CosmWasm/wasmvm"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	wasmtypes "github.com/ConsiderItDone/wasmos/x/wasm/types"
 )
 
 const wasmDir = "wasm"
 
+// cosmosPluginURI is the URI of the plugin giving wrappers access to the chain
+const cosmosPluginURI = "wrap://cosmos/cosmos.eth"
+
+var _ wasmtypes.ContractEngine = &VM{}
+
 type VM struct {
-	dataDir      string
-	client       *polywrapClient.Client
-	cosmosPlugin *CosmosPlugin
+	dataDir   string
+	pluginURI string
+	// capabilities lists the capabilities available to wrappers, comma separated
+	capabilities string
+	runtime      *runtime
+	cache        *moduleCache
+	// manifest is the decoded wrap.info of the invoked code and manifestErr the error decoding it, see
+	// WithManifest
+	manifest    *Manifest
+	manifestErr error
 }
 
 type ArgsInstantiate struct {
@@ -36,37 +55,47 @@ type ExecResult struct {
 	Result string
 }
 
-func NewVM(dataDir string) (*VM, error) {
+// NewVM creates a VM storing the wrapper code in dataDir. Wrappers requiring capabilities not listed in the
+// comma separated supportedCapabilities are rejected and the memory of every invocation is limited to
+// memoryLimit MiB. Up to memoryCacheSize MiB of compiled wrappers are kept in memory next to the pinned ones.
+func NewVM(dataDir string, supportedCapabilities string, memoryLimit uint32, memoryCacheSize uint32) (*VM, error) {
 	wasmPath := filepath.Join(dataDir, wasmDir)
 	err := os.MkdirAll(wasmPath, 0755)
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "unable to create wasm directory")
 	}
 
-	cosmosPlugin := NewCosmosPlugin()
-
-	pluginPackage := plugin.NewPluginPackage(nil, plugin.NewPluginModule(cosmosPlugin))
-
-	wrapUri, err := uri.New("wrap://cosmos/cosmos.eth")
+	wrapUri, err := uri.New(cosmosPluginURI)
 	if err != nil {
 		log.Fatalf("bad wrapUri: %s (%s)", "ens/demo-plugin.eth", err)
 	}
 
-	resolver := wasm.NewStaticResolver(map[string]wasm.Package{
-		wrapUri.String(): pluginPackage,
-	})
-
-	client := polywrapClient.New(&polywrapClient.ClientConfig{
-		Resolver: wasm.NewBaseResolver(resolver, wasm.NewFsResolver()),
-	})
-
 	return &VM{
 		dataDir:      dataDir,
-		client:       client,
-		cosmosPlugin: cosmosPlugin,
+		pluginURI:    wrapUri.String(),
+		capabilities: supportedCapabilities,
+		runtime:      newRuntime(memoryLimit),
+		cache:        newModuleCache(memoryCacheSize),
 	}, nil
+}
 
+// ValidateCode checks new wrapper code against the deterministic profile and the available capabilities.
+// Create does not repeat the check, so code the chain accepted before, e.g. from genesis or a state sync
+// snapshot, is stored as it is.
+func (vm *VM) ValidateCode(code wasmvm.WasmCode) error {
+	if !IsWrapper(code) {
+		return errors.New("wasm code is not a wrapper: missing _wrap_invoke export")
+	}
+	required, err := analyzeCode(code, vm.runtime.memoryLimit)
+	if err != nil {
+		return err
+	}
+	if missing := unavailableCapabilities(required, vm.capabilities); len(missing) != 0 {
+		return fmt.Errorf("wrapper requires unavailable capabilities: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
+
 func (vm *VM) Create(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
 	if code == nil {
 		return nil, errors.New("wasm code couldn't be nil")
@@ -86,108 +115,399 @@ func (vm *VM) Create(code wasmvm.WasmCode) (wasmvm.Checksum, error) {
 		return nil, sdkerrors.Wrap(err, "unable to write wasm file")
 	}
 
-	// write fake manifest file
-	err = os.WriteFile(vm.getManifestFilePath(checksum[:]), []byte(""), 0755)
-	if err != nil {
-		return nil, sdkerrors.Wrap(err, "unable to write wasm file")
-	}
-
 	return checksum[:], nil
 }
 
-func (vm *VM) AnalyzeCode(_ wasmvm.Checksum) (*types.AnalysisReport, error) {
-	return &types.AnalysisReport{
-		HasIBCEntryPoints:    false,
-		RequiredFeatures:     "",
-		RequiredCapabilities: "",
-	}, nil
+// WithManifest returns a VM that runs wrapper code against the given wrap.info manifest. Manifests are part
+// of the chain state, so the keeper binds the manifest of the code to every call. The manifest is decoded
+// once for all uses of the bound VM, an invalid one fails them. The VM shares the compiled modules with
+// the original one.
+func (vm *VM) WithManifest(manifest []byte) wasmtypes.ContractEngine {
+	bound := *vm
+	bound.manifest, bound.manifestErr = nil, nil
+	if len(manifest) != 0 {
+		decoded, err := DecodeManifest(manifest)
+		if err != nil {
+			bound.manifestErr = sdkerrors.Wrap(err, "invalid stored manifest")
+		}
+		bound.manifest = decoded
+	}
+	return &bound
+}
+
+// AnalyzeCode reports the IBC entry points of the wrapper and the capabilities it requires beyond the
+// deterministic profile. Only wrappers stored with a manifest that declares all IBC methods can own an
+// IBC port.
+func (vm *VM) AnalyzeCode(checksum wasmvm.Checksum) (*types.AnalysisReport, error) {
+	code, err := vm.GetCode(checksum)
+	if err != nil {
+		return nil, err
+	}
+	required, err := analyzeCode(code, vm.runtime.memoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	if vm.manifestErr != nil {
+		return nil, vm.manifestErr
+	}
+	report := &types.AnalysisReport{RequiredCapabilities: strings.Join(required, ",")}
+	if vm.manifest != nil {
+		report.HasIBCEntryPoints = vm.manifest.hasIBCEntryPoints()
+	}
+	return report, nil
 }
 
+// GetCode returns the wrapper code stored for the checksum. The code is verified against its checksum, so
+// a wrapper file modified on disk is never executed.
 func (vm *VM) GetCode(checksum wasmvm.Checksum) (wasmvm.WasmCode, error) {
+	if len(checksum) != sha256.Size {
+		return nil, fmt.Errorf("invalid checksum length %d", len(checksum))
+	}
 	wrapper, err := os.ReadFile(vm.getWasmFilePath(checksum))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("wrapper code %X not found", []byte(checksum))
+	}
 	if err != nil {
 		return nil, err
 	}
-
+	if sum := sha256.Sum256(wrapper); !bytes.Equal(sum[:], checksum) {
+		return nil, fmt.Errorf("wrapper code %X does not match its checksum", []byte(checksum))
+	}
 	return wrapper, nil
 }
 
-func (vm *VM) Init(checksum wasmvm.Checksum, env types.Env, info types.MessageInfo, initMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost types.UFraction) (*types.Response, uint64, error) {
-	wrapperPath := "wrap://fs/" + vm.getWasmFileDir(checksum)
-	wrapperUri, err := uri.New(wrapperPath)
+// Pin compiles the wrapper code and keeps it in memory until it is unpinned
+func (vm *VM) Pin(checksum wasmvm.Checksum) error {
+	if vm.cache.isPinned(checksum) {
+		return nil
+	}
+	module, size, err := vm.compile(checksum)
+	if err != nil {
+		return err
+	}
+	vm.cache.pin(checksum, module, size)
+	return nil
+}
+
+// Unpin releases a pinned wrapper code. It stays cached until it is evicted.
+func (vm *VM) Unpin(checksum wasmvm.Checksum) error {
+	vm.cache.unpin(checksum)
+	return nil
+}
+
+// GetMetrics returns the metrics of the compiled module cache. Wrappers are not cached on disk, every
+// miss compiles the code.
+func (vm *VM) GetMetrics() (*types.Metrics, error) {
+	return vm.cache.getMetrics(), nil
+}
+
+// module returns the compiled wrapper code from the cache and caches it on a miss
+func (vm *VM) module(checksum wasmvm.Checksum) (*wasmtime.Module, error) {
+	if module, ok := vm.cache.get(checksum); ok {
+		return module, nil
+	}
+	module, size, err := vm.compile(checksum)
+	if err != nil {
+		return nil, err
+	}
+	vm.cache.add(checksum, module, size)
+	return module, nil
+}
+
+func (vm *VM) compile(checksum wasmvm.Checksum) (*wasmtime.Module, uint64, error) {
+	code, err := vm.GetCode(checksum)
+	if err != nil {
+		return nil, 0, err
+	}
+	return vm.runtime.compile(code)
+}
+
+func (vm *VM) Instantiate(checksum wasmvm.Checksum, env types.Env, info types.MessageInfo, initMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost types.UFraction) (*types.Response, uint64, error) {
+	module, err := vm.module(checksum)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	encodedArgs, err := vm.encodeArgs("init", initMsg)
+	if err != nil {
+		return nil, 0, sdkerrors.Wrap(err, "unable to encode init message")
+	}
+
+	call := newFrame(env, &info, store, goapi, querier)
+	data, gasUsed, err := vm.invoke(module, "init", encodedArgs, call, gasLimit)
+	if err != nil {
+		return nil, gasUsed, err
+	}
+	res, err := decodeResponse(data)
+	if err != nil {
+		return nil, gasUsed, sdkerrors.Wrap(err, "unable to decode init result")
+	}
+	call.events.addTo(res)
+
+	return res, gasUsed, nil
+}
+
+func (vm *VM) Execute(checksum wasmvm.Checksum, env types.Env, info types.MessageInfo, executeMsg []byte, method string, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost types.UFraction) (*types.Response, uint64, error) {
+	module, err := vm.module(checksum)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	encodedArgs, err := vm.encodeArgs(method, executeMsg)
+	if err != nil {
+		return nil, 0, sdkerrors.Wrap(err, "unable to encode execute message")
+	}
+
+	call := newFrame(env, &info, store, goapi, querier)
+	data, gasUsed, err := vm.invoke(module, method, encodedArgs, call, gasLimit)
+	if err != nil {
+		return nil, gasUsed, err
+	}
+	res, err := decodeResponse(data)
+	if err != nil {
+		return nil, gasUsed, sdkerrors.Wrap(err, "unable to decode execute result")
+	}
+	call.events.addTo(res)
+
+	return res, gasUsed, nil
+}
+
+// Migrate invokes the "migrate" method of the new wrapper code against the existing contract store.
+func (vm *VM) Migrate(checksum wasmvm.Checksum, env types.Env, migrateMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost types.UFraction) (*types.Response, uint64, error) {
+	module, err := vm.module(checksum)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	gasUsed := uint64(100) //10847  99149
+	encodedArgs, err := vm.encodeArgs("migrate", migrateMsg)
+	if err != nil {
+		return nil, 0, sdkerrors.Wrap(err, "unable to encode migrate message")
+	}
 
-	var args map[string]interface{}
-	err = json.Unmarshal(initMsg, &args)
+	call := newFrame(env, nil, store, goapi, querier)
+	data, gasUsed, err := vm.invoke(module, "migrate", encodedArgs, call, gasLimit)
+	if err != nil {
+		return nil, gasUsed, err
+	}
+	res, err := decodeResponse(data)
 	if err != nil {
-		return nil, 0, sdkerrors.Wrap(err, "unable to unmarshal init message")
+		return nil, gasUsed, sdkerrors.Wrap(err, "unable to decode migrate result")
 	}
+	call.events.addTo(res)
 
-	// set store pointer to current store for this specific contract
-	vm.cosmosPlugin.SetStore(store)
+	return res, gasUsed, nil
+}
+
+// Sudo invokes the "sudo" method of the wrapper. It is only called by native modules or governance.
+func (vm *VM) Sudo(checksum wasmvm.Checksum, env types.Env, sudoMsg []byte, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost types.UFraction) (*types.Response, uint64, error) {
+	module, err := vm.module(checksum)
+	if err != nil {
+		return nil, 0, err
+	}
 
-	res, err := polywrapClient.Invoke[map[string]interface{}, InitResult, []byte](vm.client, *wrapperUri, "init", args, nil)
+	encodedArgs, err := vm.encodeArgs("sudo", sudoMsg)
+	if err != nil {
+		return nil, 0, sdkerrors.Wrap(err, "unable to encode sudo message")
+	}
+
+	call := newFrame(env, nil, store, goapi, querier)
+	data, gasUsed, err := vm.invoke(module, "sudo", encodedArgs, call, gasLimit)
 	if err != nil {
 		return nil, gasUsed, err
 	}
+	res, err := decodeResponse(data)
+	if err != nil {
+		return nil, gasUsed, sdkerrors.Wrap(err, "unable to decode sudo result")
+	}
+	call.events.addTo(res)
 
-	// reset store pointer
-	vm.cosmosPlugin.SetStore(nil)
+	return res, gasUsed, nil
+}
+
+// Reply invokes the "reply" method of the wrapper with the result of a dispatched submessage.
+func (vm *VM) Reply(checksum wasmvm.Checksum, env types.Env, reply types.Reply, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost types.UFraction) (*types.Response, uint64, error) {
+	module, err := vm.module(checksum)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	encodedArgs, err := msgpack.Encode(replyArgs{Reply: NewReply(reply)})
+	if err != nil {
+		return nil, 0, sdkerrors.Wrap(err, "unable to encode reply")
+	}
+
+	call := newFrame(env, nil, store, goapi, querier)
+	data, gasUsed, err := vm.invoke(module, "reply", encodedArgs, call, gasLimit)
+	if err != nil {
+		return nil, gasUsed, err
+	}
+	res, err := decodeResponse(data)
+	if err != nil {
+		return nil, gasUsed, sdkerrors.Wrap(err, "unable to decode reply result")
+	}
+	call.events.addTo(res)
 
-	return &types.Response{
-		Messages:   nil,
-		Data:       []byte(res.Result),
-		Attributes: nil,
-		Events:     nil,
-	}, gasUsed, nil
+	return res, gasUsed, nil
 }
 
-func (vm *VM) Execute(checksum wasmvm.Checksum, env types.Env, info types.MessageInfo, executeMsg []byte, method string, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost types.UFraction) (*types.Response, uint64, error) {
-	wrapperPath := "wrap://fs/" + vm.getWasmFileDir(checksum)
-	wrapperUri, err := uri.New(wrapperPath)
+// Query invokes a read-only wrapper method. Any attempt of the wrapper to modify the contract
+// store aborts the query with an error.
+func (vm *VM) Query(checksum wasmvm.Checksum, env types.Env, queryMsg []byte, method string, store wasmvm.KVStore, goapi wasmvm.GoAPI, querier wasmvm.Querier, gasMeter wasmvm.GasMeter, gasLimit uint64, deserCost types.UFraction) (res []byte, gasUsed uint64, err error) {
+	module, err := vm.module(checksum)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	gasUsed := uint64(100) //10847  99149
+	encodedArgs, err := vm.encodeArgs(method, queryMsg)
+	if err != nil {
+		return nil, 0, sdkerrors.Wrap(err, "unable to encode query message")
+	}
 
-	var args map[string]interface{}
-	if executeMsg != nil {
-		err = json.Unmarshal(executeMsg, &args)
-		if err != nil {
-			return nil, 0, sdkerrors.Wrap(err, "unable to unmarshal execute message")
+	// bind the plugin to a read only view of the contract store
+	call := newFrame(env, nil, store, goapi, querier)
+	call.readOnly = true
+	data, gasUsed, err := vm.invoke(module, method, encodedArgs, call, gasLimit)
+	if err != nil {
+		return nil, gasUsed, err
+	}
+	result, err := queryResult(data)
+	if err != nil {
+		return nil, gasUsed, sdkerrors.Wrap(err, "unable to decode query result")
+	}
+	return result, gasUsed, nil
+}
+
+// queryResult returns the data of a query result. A String result is the data itself and a Response or
+// InitResult its data, like in the other entry points, a missing result has no data. Any other result,
+// e.g. an object, a list or a number, is returned as JSON with Bytes as base64 strings.
+func queryResult(data []byte) ([]byte, error) {
+	v, err := decodeMsgpack(data)
+	if err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []byte(v), nil
+	case map[string]any:
+		if res, err := decode[Response](data); err == nil {
+			if len(res.Messages) != 0 {
+				return nil, errors.New("queries can not dispatch messages")
+			}
+			return res.Data, nil
+		}
+		if res, err := decode[InitResult](data); err == nil {
+			return []byte(res.Result), nil
 		}
 	}
+	return json.Marshal(v)
+}
 
-	// set store pointer to current store for this specific contract
-	vm.cosmosPlugin.SetStore(store)
+var errReadOnlyStore = errors.New("contract store is read only in queries")
 
-	res, err := polywrapClient.Invoke[map[string]interface{}, string, []byte](vm.client, *wrapperUri, method, args, nil)
+// readOnlyStore rejects all writes to the wrapped store
+type readOnlyStore struct {
+	wasmvm.KVStore
+}
+
+func (readOnlyStore) Set(_, _ []byte) {
+	panic(errReadOnlyStore)
+}
+
+func (readOnlyStore) Delete(_ []byte) {
+	panic(errReadOnlyStore)
+}
+
+// invoke runs the wrapper method with a cosmos plugin bound to the given contract store. The gas charged
+// by the plugin is added to the gas used by the wrapper.
+func (vm *VM) invoke(module *wasmtime.Module, method string, args []byte, call frame, gasLimit uint64) ([]byte, uint64, error) {
+	env, err := msgpack.Encode(NewEnv(call.env, call.info))
 	if err != nil {
-		return nil, gasUsed, err
+		return nil, 0, sdkerrors.Wrap(err, "unable to encode env")
+	}
+	store := call.store
+	if call.readOnly {
+		store = readOnlyStore{store}
+	}
+	cosmosPlugin := NewCosmosPlugin(store, call.goapi, call.querier, gasLimit)
+	cosmosPlugin.readOnly = call.readOnly
+	cosmosPlugin.events = call.events
+	invoker := &subinvoker{vm: vm, call: call, plugin: cosmosPlugin, client: vm.newClient(cosmosPlugin)}
+	data, gasUsed, err := vm.runtime.invoke(module, method, args, env, invoker, gasLimit)
+	gasUsed += cosmosPlugin.GasUsed()
+	// query gas is charged by the querier, but counts towards the limit
+	if gasUsed+cosmosPlugin.queryGas > gasLimit {
+		return nil, gasLimit, types.OutOfGasError{}
 	}
+	return data, gasUsed, err
+}
 
-	// reset store pointer
-	vm.cosmosPlugin.SetStore(nil)
+// encodeArgs converts the JSON message into the msgpack encoded method arguments. Wrappers stored
+// with a manifest get their arguments validated and coerced against the ABI of the method, invalid
+// arguments fail with ErrInvalidMsg. Arguments of wrappers without a manifest are encoded as they are,
+// with integral numbers as integers and the map keys sorted.
+func (vm *VM) encodeArgs(method string, msg []byte) ([]byte, error) {
+	if vm.manifestErr != nil {
+		return nil, vm.manifestErr
+	}
+	if vm.manifest != nil {
+		args, err := vm.manifest.EncodeArgs(method, msg)
+		if err != nil {
+			return nil, sdkerrors.Wrap(wasmtypes.ErrInvalidMsg, err.Error())
+		}
+		return args, nil
+	}
 
-	return &types.Response{
-		Messages:   nil,
-		Data:       []byte(*res),
-		Attributes: nil,
-		Events:     nil,
-	}, gasUsed, nil
+	args, err := decodeJSONArgs(msg)
+	if err != nil {
+		return nil, sdkerrors.Wrap(wasmtypes.ErrInvalidMsg, err.Error())
+	}
+	v, err := plain(args)
+	if err != nil {
+		return nil, sdkerrors.Wrap(wasmtypes.ErrInvalidMsg, err.Error())
+	}
+	return encodeMsgpack(v), nil
 }
 
-func (vm *VM) getWasmFilePath(checksum wasmvm.Checksum) string {
-	return filepath.Join(vm.getWasmFileDir(checksum), "wrap.wasm")
+// newClient creates a polywrap client with the given cosmos plugin. Every invocation gets its own client
+// so that nested and concurrent invocations never share a store.
+func (vm *VM) newClient(cosmosPlugin *CosmosPlugin) *polywrapClient.Client {
+	return polywrapClient.New(&polywrapClient.ClientConfig{
+		Resolver: sandboxResolver{packages: map[string]wasm.Package{
+			vm.pluginURI: plugin.NewPluginPackage(nil, plugin.NewPluginModule(cosmosPlugin)),
+		}},
+	})
+}
+
+// decodeResponse decodes the wrapper result into the wasmvm response. Besides the Response schema
+// wrappers may return a plain string or an InitResult, which only set the response data.
+func decodeResponse(data []byte) (*types.Response, error) {
+	if res, err := decode[Response](data); err == nil {
+		return res.toWasmVMResponse()
+	}
+	if res, err := decode[string](data); err == nil {
+		return &types.Response{Data: []byte(res)}, nil
+	}
+	res, err := decode[InitResult](data)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Response{Data: []byte(res.Result)}, nil
 }
 
-func (vm *VM) getManifestFilePath(checksum wasmvm.Checksum) string {
-	return filepath.Join(vm.getWasmFileDir(checksum), "wrap.info")
+// decode is msgpack.Decode returning malformed input as error instead of panicking
+func decode[T any](data []byte) (res T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return msgpack.Decode[T](data)
+}
+
+func (vm *VM) getWasmFilePath(checksum wasmvm.Checksum) string {
+	return filepath.Join(vm.getWasmFileDir(checksum), wrapFile)
 }
 
 func (vm *VM) getWasmFileDir(checksum wasmvm.Checksum) string {
@@ -0,0 +1,201 @@
+package polywrapvm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	wasmvm "github.com/CosmWasm/wasmvm"
+	"github.com/CosmWasm/wasmvm/types"
+	"github.com/cosmos/cosmos-sdk/store/dbadapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	wasmtypes "github.com/ConsiderItDone/wasmos/x/wasm/types"
+)
+
+func TestVMInvocationsUseOwnStore(t *testing.T) {
+	code, err := os.ReadFile("../keeper/testdata/hello_world.wasm")
+	require.NoError(t, err)
+	vm, err := NewVM(t.TempDir(), "", 32, 0)
+	require.NoError(t, err)
+	checksum, err := vm.Create(code)
+	require.NoError(t, err)
+
+	const contracts = 4
+	stores := make([]*dbadapter.Store, contracts)
+	for i := range stores {
+		stores[i] = &dbadapter.Store{DB: dbm.NewMemDB()}
+		stores[i].Set([]byte("name"), []byte(fmt.Sprintf("contract %d", i)))
+	}
+	env := types.Env{Contract: types.ContractInfo{Address: "cosmos1contract"}}
+
+	// a failed invocation must not leave its store bound
+	_, _, err = vm.Execute(checksum, env, types.MessageInfo{}, nil, "unknown", stores[0], wasmvm.GoAPI{}, nil, nil, 1_000_000_000, types.UFraction{})
+	require.Error(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < contracts; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 3; j++ {
+				res, _, err := vm.Execute(checksum, env, types.MessageInfo{}, nil, "sayHello", stores[i], wasmvm.GoAPI{}, nil, nil, 1_000_000_000, types.UFraction{})
+				if assert.NoError(t, err) {
+					assert.Equal(t, fmt.Sprintf("Hello from CosmoWrap, contract %d", i), string(res.Data))
+				}
+			}
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 3; j++ {
+				res, _, err := vm.Query(checksum, env, nil, "sayHello", stores[i], wasmvm.GoAPI{}, nil, nil, 1_000_000_000, types.UFraction{})
+				if assert.NoError(t, err) {
+					assert.Equal(t, fmt.Sprintf("Hello from CosmoWrap, contract %d", i), string(res))
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestVMWithManifest(t *testing.T) {
+	code, err := os.ReadFile("../keeper/testdata/hello_world.wasm")
+	require.NoError(t, err)
+	helloWorldManifest, err := os.ReadFile("../keeper/testdata/hello_world.wrap.info")
+	require.NoError(t, err)
+	vm, err := NewVM(t.TempDir(), "", 32, 0)
+	require.NoError(t, err)
+	checksum, err := vm.Create(code)
+	require.NoError(t, err)
+
+	// manifests are never kept on disk
+	_, err = os.Stat(filepath.Join(vm.getWasmFileDir(checksum), manifestFile))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	// arguments are checked against the bound manifest only, which is decoded once
+	msg := []byte(`{"newName": 1}`)
+	bound := vm.WithManifest(helloWorldManifest).(*VM)
+	require.NotNil(t, bound.manifest)
+	assert.Equal(t, "hello-world", bound.manifest.Name)
+	_, err = bound.encodeArgs("updateName", msg)
+	require.ErrorIs(t, err, wasmtypes.ErrInvalidMsg)
+	_, err = vm.encodeArgs("updateName", msg)
+	require.NoError(t, err)
+
+	// an invalid manifest fails every call
+	invalid := vm.WithManifest([]byte("manifest")).(*VM)
+	_, err = invalid.encodeArgs("updateName", msg)
+	require.ErrorContains(t, err, "invalid stored manifest")
+	_, err = invalid.AnalyzeCode(checksum)
+	require.ErrorContains(t, err, "invalid stored manifest")
+
+	// the bound VM shares the compiled code
+	require.NoError(t, vm.Pin(checksum))
+	assert.True(t, bound.cache.isPinned(checksum))
+}
+
+func TestVMEncodeArgsWithoutManifest(t *testing.T) {
+	vm, err := NewVM(t.TempDir(), "", 32, 0)
+	require.NoError(t, err)
+
+	specs := map[string]struct {
+		msg    string
+		exp    any
+		expErr bool
+	}{
+		"empty":   {msg: ``, exp: map[string]any{}},
+		"null":    {msg: `null`, exp: map[string]any{}},
+		"object":  {msg: `{"b": "x", "a": true}`, exp: map[string]any{"a": true, "b": "x"}},
+		"numbers": {msg: `{"i": -1, "u": 18446744073709551615, "f": 1.5}`, exp: map[string]any{"f": 1.5, "i": int64(-1), "u": uint64(18446744073709551615)}},
+		"nested": {
+			msg: `{"o": {"z": 1, "y": [2, {"x": null}]}}`,
+			exp: map[string]any{"o": map[string]any{"y": []any{int64(2), map[string]any{"x": nil}}, "z": int64(1)}},
+		},
+		"not an object": {msg: `[1]`, expErr: true},
+		"invalid json":  {msg: `{`, expErr: true},
+		"trailing data": {msg: `{} {}`, expErr: true},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			args, err := vm.encodeArgs("any", []byte(spec.msg))
+			if spec.expErr {
+				require.ErrorIs(t, err, wasmtypes.ErrInvalidMsg)
+				return
+			}
+			require.NoError(t, err)
+			// keys are sorted, so the encoding is deterministic
+			assert.Equal(t, encodeMsgpack(spec.exp), args)
+		})
+	}
+}
+
+func TestVMGetCode(t *testing.T) {
+	code, err := os.ReadFile("../keeper/testdata/hello_world.wasm")
+	require.NoError(t, err)
+	vm, err := NewVM(t.TempDir(), "", 32, 0)
+	require.NoError(t, err)
+	checksum, err := vm.Create(code)
+	require.NoError(t, err)
+
+	got, err := vm.GetCode(checksum)
+	require.NoError(t, err)
+	assert.Equal(t, code, []byte(got))
+
+	// unknown code and invalid checksums
+	_, err = vm.GetCode(make([]byte, 32))
+	assert.ErrorContains(t, err, "not found")
+	_, err = vm.GetCode(nil)
+	assert.ErrorContains(t, err, "invalid checksum")
+
+	// code modified on disk is not loaded
+	require.NoError(t, os.WriteFile(vm.getWasmFilePath(checksum), append(code, 0), 0o644))
+	_, err = vm.GetCode(checksum)
+	assert.ErrorContains(t, err, "does not match its checksum")
+}
+
+func TestVMPin(t *testing.T) {
+	code, err := os.ReadFile("../keeper/testdata/hello_world.wasm")
+	require.NoError(t, err)
+	vm, err := NewVM(t.TempDir(), "", 32, 100)
+	require.NoError(t, err)
+	checksum, err := vm.Create(code)
+	require.NoError(t, err)
+	store := &dbadapter.Store{DB: dbm.NewMemDB()}
+	store.Set([]byte("name"), []byte("Joe"))
+	query := func() {
+		_, _, err := vm.Query(checksum, types.Env{}, nil, "sayHello", store, wasmvm.GoAPI{}, nil, nil, 1_000_000_000, types.UFraction{})
+		require.NoError(t, err)
+	}
+
+	// compiled on the first call and cached
+	query()
+	query()
+	m, err := vm.GetMetrics()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), m.Misses)
+	assert.Equal(t, uint32(1), m.HitsMemoryCache)
+	assert.Equal(t, uint64(1), m.ElementsMemoryCache)
+	assert.NotZero(t, m.SizeMemoryCache)
+
+	require.NoError(t, vm.Pin(checksum))
+	require.NoError(t, vm.Pin(checksum))
+	query()
+	m, err = vm.GetMetrics()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), m.HitsPinnedMemoryCache)
+	assert.Equal(t, uint64(1), m.ElementsPinnedMemoryCache)
+	assert.Zero(t, m.ElementsMemoryCache)
+
+	require.NoError(t, vm.Unpin(checksum))
+	m, err = vm.GetMetrics()
+	require.NoError(t, err)
+	assert.Zero(t, m.ElementsPinnedMemoryCache)
+	assert.Equal(t, uint64(1), m.ElementsMemoryCache)
+
+	// unknown code
+	require.Error(t, vm.Pin(make([]byte, 32)))
+}
@@ -38,7 +38,7 @@ type WasmKeeper interface {
 	GetParams(ctx sdk.Context) types.Params
 	IterateCodeInfos(ctx sdk.Context, cb func(uint64, types.CodeInfo) bool)
 	IterateContractInfo(ctx sdk.Context, cb func(sdk.AccAddress, types.ContractInfo) bool)
-	QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte) ([]byte, error)
+	QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte, method string) ([]byte, error)
 	PeekAutoIncrementID(ctx sdk.Context, lastIDKey []byte) uint64
 }
 type BankKeeper interface {
@@ -508,7 +508,7 @@ func DefaultSimulationExecuteSenderSelector(wasmKeeper WasmKeeper, ctx sdk.Conte
 	if err != nil {
 		return none, sdkerrors.Wrap(err, "build smart query")
 	}
-	got, err := wasmKeeper.QuerySmart(ctx, contractAddr, bz)
+	got, err := wasmKeeper.QuerySmart(ctx, contractAddr, bz, "")
 	if err != nil {
 		return none, sdkerrors.Wrap(err, "exec smart query")
 	}
@@ -16,8 +16,10 @@ const gasDeserializationCostPerByte = uint64(1)
 
 var (
 	_ authztypes.Authorization         = &ContractExecutionAuthorization{}
+	_ authztypes.Authorization         = &ContractBatchExecutionAuthorization{}
 	_ authztypes.Authorization         = &ContractMigrationAuthorization{}
 	_ cdctypes.UnpackInterfacesMessage = &ContractExecutionAuthorization{}
+	_ cdctypes.UnpackInterfacesMessage = &ContractBatchExecutionAuthorization{}
 	_ cdctypes.UnpackInterfacesMessage = &ContractMigrationAuthorization{}
 )
 
@@ -66,6 +68,79 @@ func (a ContractExecutionAuthorization) UnpackInterfaces(unpacker cdctypes.AnyUn
 	return nil
 }
 
+// NewContractBatchExecutionAuthorization constructor
+func NewContractBatchExecutionAuthorization(grants ...ContractGrant) *ContractBatchExecutionAuthorization {
+	return &ContractBatchExecutionAuthorization{
+		Grants: grants,
+	}
+}
+
+// MsgTypeURL implements Authorization.MsgTypeURL.
+func (a ContractBatchExecutionAuthorization) MsgTypeURL() string {
+	return sdk.MsgTypeURL(&MsgExecuteContractBatch{})
+}
+
+// NewAuthz factory method to create an Authorization with updated grants
+func (a ContractBatchExecutionAuthorization) NewAuthz(g []ContractGrant) authztypes.Authorization {
+	return NewContractBatchExecutionAuthorization(g...)
+}
+
+// Accept implements Authorization.Accept. The calls of the batch are accepted in order like single
+// executions, each one against the grants updated by the calls before it. The batch is rejected
+// when any call is not accepted.
+func (a *ContractBatchExecutionAuthorization) Accept(ctx sdk.Context, msg sdk.Msg) (authztypes.AcceptResponse, error) {
+	batch, ok := msg.(*MsgExecuteContractBatch)
+	if !ok {
+		return authztypes.AcceptResponse{}, sdkerrors.ErrInvalidType.Wrap("type mismatch")
+	}
+	if err := batch.ValidateBasic(); err != nil {
+		return authztypes.AcceptResponse{}, err
+	}
+
+	// copy the grants as accepting a call updates the slice in place
+	grants := append([]ContractGrant{}, a.Grants...)
+	var updated bool
+	for i, exec := range batch.Executions() {
+		if len(grants) == 0 {
+			return authztypes.AcceptResponse{Accept: false}, nil
+		}
+		result, err := AcceptGrantedMessage[*MsgExecuteContract](ctx, grants, exec, a)
+		switch {
+		case err != nil:
+			return authztypes.AcceptResponse{}, sdkerrors.Wrapf(err, "call %d", i)
+		case !result.Accept:
+			return authztypes.AcceptResponse{Accept: false}, nil
+		case result.Delete:
+			grants, updated = nil, true
+		case result.Updated != nil:
+			grants, updated = result.Updated.(*ContractBatchExecutionAuthorization).Grants, true
+		}
+	}
+	switch {
+	case len(grants) == 0:
+		return authztypes.AcceptResponse{Accept: true, Delete: true}, nil
+	case updated:
+		return authztypes.AcceptResponse{Accept: true, Updated: a.NewAuthz(grants)}, nil
+	default:
+		return authztypes.AcceptResponse{Accept: true}, nil
+	}
+}
+
+// ValidateBasic implements Authorization.ValidateBasic.
+func (a ContractBatchExecutionAuthorization) ValidateBasic() error {
+	return validateGrants(a.Grants)
+}
+
+// UnpackInterfaces implements UnpackInterfacesMessage.UnpackInterfaces
+func (a ContractBatchExecutionAuthorization) UnpackInterfaces(unpacker cdctypes.AnyUnpacker) error {
+	for _, g := range a.Grants {
+		if err := g.UnpackInterfaces(unpacker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // NewContractMigrationAuthorization constructor
 func NewContractMigrationAuthorization(grants ...ContractGrant) *ContractMigrationAuthorization {
 	return &ContractMigrationAuthorization{
@@ -155,7 +230,7 @@ func AcceptGrantedMessage[T AuthzableWasmMsg](ctx sdk.Context, grants []Contract
 		}
 
 		// then check permission set
-		ok, err := g.GetFilter().Accept(ctx, exec.GetMsg())
+		ok, err := acceptFilter(ctx, g.GetFilter(), exec)
 		switch {
 		case err != nil:
 			return authztypes.AcceptResponse{}, sdkerrors.Wrap(err, "filter")
@@ -219,6 +294,33 @@ type ContractAuthzFilterX interface {
 	ValidateBasic() error
 }
 
+// ContractAuthzMethodFilterX is a ContractAuthzFilterX that selects on the wrapper method of
+// the operation. AcceptMethod is called instead of Accept for these filters.
+type ContractAuthzMethodFilterX interface {
+	ContractAuthzFilterX
+	AcceptMethod(ctx sdk.Context, method string, msg RawContractMessage) (bool, error)
+}
+
+// methodMsg is implemented by messages that invoke a named wrapper method
+type methodMsg interface {
+	GetMethod() string
+}
+
+// methodOf returns the wrapper method of the message or an empty string when it has none
+func methodOf(msg AuthzableWasmMsg) string {
+	if m, ok := msg.(methodMsg); ok {
+		return m.GetMethod()
+	}
+	return ""
+}
+
+func acceptFilter(ctx sdk.Context, filter ContractAuthzFilterX, msg AuthzableWasmMsg) (bool, error) {
+	if f, ok := filter.(ContractAuthzMethodFilterX); ok {
+		return f.AcceptMethod(ctx, methodOf(msg), msg.GetMsg())
+	}
+	return filter.Accept(ctx, msg.GetMsg())
+}
+
 var _ cdctypes.UnpackInterfacesMessage = &ContractGrant{}
 
 // NewContractGrant constructor
@@ -410,11 +512,56 @@ func (f AcceptedMessagesFilter) ValidateBasic() error {
 	return nil
 }
 
+// NewAcceptedMethodsFilter constructor
+func NewAcceptedMethodsFilter(methods ...string) *AcceptedMethodsFilter {
+	return &AcceptedMethodsFilter{Methods: methods}
+}
+
+// Accept rejects any message, as the filter only applies to wrapper method executions.
+func (f *AcceptedMethodsFilter) Accept(ctx sdk.Context, msg RawContractMessage) (bool, error) {
+	return f.AcceptMethod(ctx, "", msg)
+}
+
+// AcceptMethod accepts only valid json messages executing one of the granted wrapper methods.
+func (f *AcceptedMethodsFilter) AcceptMethod(_ sdk.Context, method string, msg RawContractMessage) (bool, error) {
+	if method == "" {
+		return false, nil
+	}
+	for _, m := range f.Methods {
+		if m == method {
+			return true, msg.ValidateBasic()
+		}
+	}
+	return false, nil
+}
+
+// ValidateBasic validates the filter
+func (f AcceptedMethodsFilter) ValidateBasic() error {
+	if len(f.Methods) == 0 {
+		return ErrEmpty.Wrap("methods")
+	}
+	idx := make(map[string]struct{}, len(f.Methods))
+	for _, m := range f.Methods {
+		if m == "" {
+			return ErrEmpty.Wrap("method")
+		}
+		if err := ValidateMethod(m); err != nil {
+			return err
+		}
+		if _, exists := idx[m]; exists {
+			return ErrDuplicate.Wrapf("method %q", m)
+		}
+		idx[m] = struct{}{}
+	}
+	return nil
+}
+
 var (
 	_ ContractAuthzLimitX = &UndefinedLimit{}
 	_ ContractAuthzLimitX = &MaxCallsLimit{}
 	_ ContractAuthzLimitX = &MaxFundsLimit{}
 	_ ContractAuthzLimitX = &CombinedLimit{}
+	_ ContractAuthzLimitX = &MaxMethodCallsLimit{}
 )
 
 // UndefinedLimit null object that is always rejected in execution
@@ -532,3 +679,60 @@ func (l CombinedLimit) ValidateBasic() error {
 	}
 	return nil
 }
+
+// NewMaxMethodCallsLimit constructor
+func NewMaxMethodCallsLimit(calls ...MethodCalls) *MaxMethodCallsLimit {
+	return &MaxMethodCallsLimit{Calls: calls}
+}
+
+// Accept only the defined number of calls of each listed wrapper method. No token transfers to
+// the contract allowed. The limit is removed when no calls are left for any method.
+func (l MaxMethodCallsLimit) Accept(_ sdk.Context, msg AuthzableWasmMsg) (*ContractAuthzLimitAcceptResult, error) {
+	if !msg.GetFunds().Empty() {
+		return &ContractAuthzLimitAcceptResult{Accepted: false}, nil
+	}
+	method := methodOf(msg)
+	for i, c := range l.Calls {
+		if method == "" || c.Method != method {
+			continue
+		}
+		if c.Remaining == 0 { // sanity check
+			return nil, sdkerrors.ErrUnauthorized.Wrapf("no calls left for method %q", method)
+		}
+		calls := make([]MethodCalls, 0, len(l.Calls))
+		calls = append(calls, l.Calls[:i]...)
+		if c.Remaining > 1 {
+			calls = append(calls, MethodCalls{Method: c.Method, Remaining: c.Remaining - 1})
+		}
+		calls = append(calls, l.Calls[i+1:]...)
+		if len(calls) == 0 {
+			return &ContractAuthzLimitAcceptResult{Accepted: true, DeleteLimit: true}, nil
+		}
+		return &ContractAuthzLimitAcceptResult{Accepted: true, UpdateLimit: NewMaxMethodCallsLimit(calls...)}, nil
+	}
+	return &ContractAuthzLimitAcceptResult{Accepted: false}, nil
+}
+
+// ValidateBasic validates the limit
+func (l MaxMethodCallsLimit) ValidateBasic() error {
+	if len(l.Calls) == 0 {
+		return ErrEmpty.Wrap("calls")
+	}
+	idx := make(map[string]struct{}, len(l.Calls))
+	for _, c := range l.Calls {
+		if c.Method == "" {
+			return ErrEmpty.Wrap("method")
+		}
+		if err := ValidateMethod(c.Method); err != nil {
+			return err
+		}
+		if _, exists := idx[c.Method]; exists {
+			return ErrDuplicate.Wrapf("method %q", c.Method)
+		}
+		idx[c.Method] = struct{}{}
+		if c.Remaining == 0 {
+			return ErrEmpty.Wrapf("remaining calls of method %q", c.Method)
+		}
+	}
+	return nil
+}
@@ -67,6 +67,46 @@ func (m *ContractExecutionAuthorization) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ContractExecutionAuthorization proto.InternalMessageInfo
 
+// ContractBatchExecutionAuthorization defines authorization for wasm batch
+// executions. Every call of the batch must be accepted by the grants.
+type ContractBatchExecutionAuthorization struct {
+	// Grants for contract executions
+	Grants []ContractGrant `protobuf:"bytes,1,rep,name=grants,proto3" json:"grants"`
+}
+
+func (m *ContractBatchExecutionAuthorization) Reset()         { *m = ContractBatchExecutionAuthorization{} }
+func (m *ContractBatchExecutionAuthorization) String() string { return proto.CompactTextString(m) }
+func (*ContractBatchExecutionAuthorization) ProtoMessage()    {}
+func (*ContractBatchExecutionAuthorization) Descriptor() ([]byte, []int) {
+	return fileDescriptor_36ff3a20cf32b258, []int{1}
+}
+func (m *ContractBatchExecutionAuthorization) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ContractBatchExecutionAuthorization) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ContractBatchExecutionAuthorization.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ContractBatchExecutionAuthorization) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractBatchExecutionAuthorization.Merge(m, src)
+}
+func (m *ContractBatchExecutionAuthorization) XXX_Size() int {
+	return m.Size()
+}
+func (m *ContractBatchExecutionAuthorization) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractBatchExecutionAuthorization.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractBatchExecutionAuthorization proto.InternalMessageInfo
+
 // ContractMigrationAuthorization defines authorization for wasm contract
 // migration. Since: wasmd 0.30
 type ContractMigrationAuthorization struct {
@@ -78,7 +118,7 @@ func (m *ContractMigrationAuthorization) Reset()         { *m = ContractMigratio
 func (m *ContractMigrationAuthorization) String() string { return proto.CompactTextString(m) }
 func (*ContractMigrationAuthorization) ProtoMessage()    {}
 func (*ContractMigrationAuthorization) Descriptor() ([]byte, []int) {
-	return fileDescriptor_36ff3a20cf32b258, []int{1}
+	return fileDescriptor_36ff3a20cf32b258, []int{2}
 }
 func (m *ContractMigrationAuthorization) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -125,7 +165,7 @@ func (m *ContractGrant) Reset()         { *m = ContractGrant{} }
 func (m *ContractGrant) String() string { return proto.CompactTextString(m) }
 func (*ContractGrant) ProtoMessage()    {}
 func (*ContractGrant) Descriptor() ([]byte, []int) {
-	return fileDescriptor_36ff3a20cf32b258, []int{2}
+	return fileDescriptor_36ff3a20cf32b258, []int{3}
 }
 func (m *ContractGrant) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -165,7 +205,7 @@ func (m *MaxCallsLimit) Reset()         { *m = MaxCallsLimit{} }
 func (m *MaxCallsLimit) String() string { return proto.CompactTextString(m) }
 func (*MaxCallsLimit) ProtoMessage()    {}
 func (*MaxCallsLimit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_36ff3a20cf32b258, []int{3}
+	return fileDescriptor_36ff3a20cf32b258, []int{4}
 }
 func (m *MaxCallsLimit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -205,7 +245,7 @@ func (m *MaxFundsLimit) Reset()         { *m = MaxFundsLimit{} }
 func (m *MaxFundsLimit) String() string { return proto.CompactTextString(m) }
 func (*MaxFundsLimit) ProtoMessage()    {}
 func (*MaxFundsLimit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_36ff3a20cf32b258, []int{4}
+	return fileDescriptor_36ff3a20cf32b258, []int{5}
 }
 func (m *MaxFundsLimit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -248,7 +288,7 @@ func (m *CombinedLimit) Reset()         { *m = CombinedLimit{} }
 func (m *CombinedLimit) String() string { return proto.CompactTextString(m) }
 func (*CombinedLimit) ProtoMessage()    {}
 func (*CombinedLimit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_36ff3a20cf32b258, []int{5}
+	return fileDescriptor_36ff3a20cf32b258, []int{6}
 }
 func (m *CombinedLimit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -277,6 +317,87 @@ func (m *CombinedLimit) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CombinedLimit proto.InternalMessageInfo
 
+// MaxMethodCallsLimit limited number of calls per wrapper method. Only the
+// listed methods can be executed. No funds transferable.
+type MaxMethodCallsLimit struct {
+	// Calls is the list of methods with their remaining number of calls
+	Calls []MethodCalls `protobuf:"bytes,1,rep,name=calls,proto3" json:"calls"`
+}
+
+func (m *MaxMethodCallsLimit) Reset()         { *m = MaxMethodCallsLimit{} }
+func (m *MaxMethodCallsLimit) String() string { return proto.CompactTextString(m) }
+func (*MaxMethodCallsLimit) ProtoMessage()    {}
+func (*MaxMethodCallsLimit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_36ff3a20cf32b258, []int{7}
+}
+func (m *MaxMethodCallsLimit) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MaxMethodCallsLimit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MaxMethodCallsLimit.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MaxMethodCallsLimit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MaxMethodCallsLimit.Merge(m, src)
+}
+func (m *MaxMethodCallsLimit) XXX_Size() int {
+	return m.Size()
+}
+func (m *MaxMethodCallsLimit) XXX_DiscardUnknown() {
+	xxx_messageInfo_MaxMethodCallsLimit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MaxMethodCallsLimit proto.InternalMessageInfo
+
+// MethodCalls remaining number of calls of a wrapper method
+type MethodCalls struct {
+	// Method is the name of the wrapper method
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// Remaining number that is decremented on each execution of the method
+	Remaining uint64 `protobuf:"varint,2,opt,name=remaining,proto3" json:"remaining,omitempty"`
+}
+
+func (m *MethodCalls) Reset()         { *m = MethodCalls{} }
+func (m *MethodCalls) String() string { return proto.CompactTextString(m) }
+func (*MethodCalls) ProtoMessage()    {}
+func (*MethodCalls) Descriptor() ([]byte, []int) {
+	return fileDescriptor_36ff3a20cf32b258, []int{8}
+}
+func (m *MethodCalls) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MethodCalls) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MethodCalls.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MethodCalls) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MethodCalls.Merge(m, src)
+}
+func (m *MethodCalls) XXX_Size() int {
+	return m.Size()
+}
+func (m *MethodCalls) XXX_DiscardUnknown() {
+	xxx_messageInfo_MethodCalls.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MethodCalls proto.InternalMessageInfo
+
 // AllowAllMessagesFilter is a wildcard to allow any type of contract payload
 // message.
 // Since: wasmd 0.30
@@ -287,7 +408,7 @@ func (m *AllowAllMessagesFilter) Reset()         { *m = AllowAllMessagesFilter{}
 func (m *AllowAllMessagesFilter) String() string { return proto.CompactTextString(m) }
 func (*AllowAllMessagesFilter) ProtoMessage()    {}
 func (*AllowAllMessagesFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_36ff3a20cf32b258, []int{6}
+	return fileDescriptor_36ff3a20cf32b258, []int{9}
 }
 func (m *AllowAllMessagesFilter) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -328,7 +449,7 @@ func (m *AcceptedMessageKeysFilter) Reset()         { *m = AcceptedMessageKeysFi
 func (m *AcceptedMessageKeysFilter) String() string { return proto.CompactTextString(m) }
 func (*AcceptedMessageKeysFilter) ProtoMessage()    {}
 func (*AcceptedMessageKeysFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_36ff3a20cf32b258, []int{7}
+	return fileDescriptor_36ff3a20cf32b258, []int{10}
 }
 func (m *AcceptedMessageKeysFilter) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -369,7 +490,7 @@ func (m *AcceptedMessagesFilter) Reset()         { *m = AcceptedMessagesFilter{}
 func (m *AcceptedMessagesFilter) String() string { return proto.CompactTextString(m) }
 func (*AcceptedMessagesFilter) ProtoMessage()    {}
 func (*AcceptedMessagesFilter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_36ff3a20cf32b258, []int{8}
+	return fileDescriptor_36ff3a20cf32b258, []int{11}
 }
 func (m *AcceptedMessagesFilter) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -398,59 +519,108 @@ func (m *AcceptedMessagesFilter) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_AcceptedMessagesFilter proto.InternalMessageInfo
 
+// AcceptedMethodsFilter accept only executions of the specific wrapper methods.
+// Messages without a method are rejected.
+type AcceptedMethodsFilter struct {
+	// Methods is the list of unique wrapper method names
+	Methods []string `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
+}
+
+func (m *AcceptedMethodsFilter) Reset()         { *m = AcceptedMethodsFilter{} }
+func (m *AcceptedMethodsFilter) String() string { return proto.CompactTextString(m) }
+func (*AcceptedMethodsFilter) ProtoMessage()    {}
+func (*AcceptedMethodsFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_36ff3a20cf32b258, []int{12}
+}
+func (m *AcceptedMethodsFilter) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *AcceptedMethodsFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_AcceptedMethodsFilter.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *AcceptedMethodsFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcceptedMethodsFilter.Merge(m, src)
+}
+func (m *AcceptedMethodsFilter) XXX_Size() int {
+	return m.Size()
+}
+func (m *AcceptedMethodsFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcceptedMethodsFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcceptedMethodsFilter proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*ContractExecutionAuthorization)(nil), "cosmwasm.wasm.v1.ContractExecutionAuthorization")
+	proto.RegisterType((*ContractBatchExecutionAuthorization)(nil), "cosmwasm.wasm.v1.ContractBatchExecutionAuthorization")
 	proto.RegisterType((*ContractMigrationAuthorization)(nil), "cosmwasm.wasm.v1.ContractMigrationAuthorization")
 	proto.RegisterType((*ContractGrant)(nil), "cosmwasm.wasm.v1.ContractGrant")
 	proto.RegisterType((*MaxCallsLimit)(nil), "cosmwasm.wasm.v1.MaxCallsLimit")
 	proto.RegisterType((*MaxFundsLimit)(nil), "cosmwasm.wasm.v1.MaxFundsLimit")
 	proto.RegisterType((*CombinedLimit)(nil), "cosmwasm.wasm.v1.CombinedLimit")
+	proto.RegisterType((*MaxMethodCallsLimit)(nil), "cosmwasm.wasm.v1.MaxMethodCallsLimit")
+	proto.RegisterType((*MethodCalls)(nil), "cosmwasm.wasm.v1.MethodCalls")
 	proto.RegisterType((*AllowAllMessagesFilter)(nil), "cosmwasm.wasm.v1.AllowAllMessagesFilter")
 	proto.RegisterType((*AcceptedMessageKeysFilter)(nil), "cosmwasm.wasm.v1.AcceptedMessageKeysFilter")
 	proto.RegisterType((*AcceptedMessagesFilter)(nil), "cosmwasm.wasm.v1.AcceptedMessagesFilter")
+	proto.RegisterType((*AcceptedMethodsFilter)(nil), "cosmwasm.wasm.v1.AcceptedMethodsFilter")
 }
 
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/authz.proto", fileDescriptor_36ff3a20cf32b258) }
 
 var fileDescriptor_36ff3a20cf32b258 = []byte{
-	// 578 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x54, 0x4d, 0x8f, 0xd2, 0x40,
-	0x18, 0xa6, 0xbb, 0x2b, 0x2e, 0xb3, 0xe2, 0x47, 0x25, 0x08, 0x64, 0x53, 0x08, 0x07, 0xe5, 0x42,
-	0x2b, 0x78, 0x23, 0xf1, 0x00, 0x28, 0xc6, 0x28, 0x97, 0x5e, 0xdc, 0x78, 0xd9, 0x4c, 0xcb, 0x50,
-	0x26, 0xdb, 0x76, 0x48, 0x67, 0xca, 0xd7, 0x9f, 0xd0, 0xdf, 0xe1, 0x99, 0x83, 0x3f, 0x81, 0x70,
-	0xda, 0xa3, 0xa7, 0x55, 0xe1, 0x5f, 0x78, 0x32, 0x9d, 0x99, 0xb2, 0x40, 0xc2, 0x1e, 0xf5, 0x52,
-	0xe6, 0xfd, 0x78, 0x9e, 0xf7, 0x99, 0xf7, 0x7d, 0x19, 0x70, 0x6e, 0x13, 0xea, 0x8d, 0x21, 0xf5,
-	0x0c, 0xfe, 0x19, 0xd5, 0x0c, 0x18, 0xb2, 0xc1, 0x4c, 0x1f, 0x06, 0x84, 0x11, 0xf5, 0x71, 0x1c,
-	0xd5, 0xf9, 0x67, 0x54, 0x2b, 0x64, 0x1c, 0xe2, 0x10, 0x1e, 0x34, 0xa2, 0x93, 0xc8, 0x2b, 0xe4,
-	0xa3, 0x3c, 0x42, 0x2f, 0x45, 0x40, 0x18, 0x32, 0xa4, 0x09, 0xcb, 0xb0, 0x20, 0x45, 0xc6, 0xa8,
-	0x66, 0x21, 0x06, 0x6b, 0x86, 0x4d, 0xb0, 0x1f, 0x43, 0x1d, 0x42, 0x1c, 0x17, 0x19, 0xdc, 0xb2,
-	0xc2, 0xbe, 0x01, 0xfd, 0xa9, 0x08, 0x95, 0x03, 0xa0, 0xb5, 0x89, 0xcf, 0x02, 0x68, 0xb3, 0xb7,
-	0x13, 0x64, 0x87, 0x0c, 0x13, 0xbf, 0x19, 0xb2, 0x01, 0x09, 0xf0, 0x0c, 0x46, 0x86, 0xfa, 0x1a,
-	0x24, 0x9d, 0x00, 0xfa, 0x8c, 0xe6, 0x94, 0xd2, 0x71, 0xe5, 0xac, 0x5e, 0xd4, 0xf7, 0x05, 0xeb,
-	0x31, 0xc3, 0xbb, 0x28, 0xaf, 0x75, 0xb2, 0xb8, 0x29, 0x26, 0x4c, 0x09, 0x6a, 0x3c, 0x59, 0xce,
-	0xab, 0xe9, 0x1d, 0xc6, 0xed, 0x9a, 0x5d, 0xec, 0x04, 0xf0, 0x5f, 0xd4, 0xfc, 0xae, 0x80, 0xf4,
-	0x0e, 0x44, 0x2d, 0x80, 0x53, 0x5b, 0x3a, 0x72, 0x4a, 0x49, 0xa9, 0xa4, 0xcc, 0x8d, 0xad, 0xb6,
-	0xc1, 0x3d, 0x17, 0x7b, 0x98, 0xe5, 0x8e, 0x4a, 0x4a, 0xe5, 0xac, 0x9e, 0xd1, 0x45, 0x03, 0xf5,
-	0xb8, 0x81, 0x7a, 0xd3, 0x9f, 0xb6, 0x9e, 0x2d, 0xe7, 0xd5, 0xa7, 0x31, 0x67, 0x54, 0x6d, 0xf6,
-	0x31, 0xc2, 0x5c, 0x98, 0x02, 0xab, 0x76, 0x40, 0xb2, 0x8f, 0x5d, 0x86, 0x82, 0xdc, 0xf1, 0x1d,
-	0x2c, 0xb9, 0xe5, 0xbc, 0x9a, 0xd9, 0x61, 0xe9, 0x70, 0xd0, 0x85, 0x29, 0xd1, 0xe5, 0x0e, 0x48,
-	0x77, 0xe1, 0xa4, 0x0d, 0x5d, 0x97, 0xf2, 0x02, 0xea, 0x39, 0x48, 0x05, 0xc8, 0x83, 0xd8, 0xc7,
-	0xbe, 0xc3, 0xa5, 0x9f, 0x98, 0xb7, 0x8e, 0xc6, 0x21, 0x59, 0xe5, 0x2f, 0x0a, 0x27, 0xea, 0x84,
-	0x7e, 0x4f, 0x12, 0x21, 0x70, 0x1f, 0x7a, 0x24, 0xbc, 0xed, 0x73, 0x5e, 0x97, 0x7b, 0x15, 0x6d,
-	0x92, 0x2e, 0x37, 0x49, 0x6f, 0x13, 0xec, 0xb7, 0x5e, 0x46, 0x1d, 0xfe, 0xf6, 0xb3, 0x58, 0x71,
-	0x30, 0x1b, 0x84, 0x96, 0x6e, 0x13, 0x4f, 0x2e, 0xa1, 0xfc, 0xa9, 0xd2, 0xde, 0x95, 0xc1, 0xa6,
-	0x43, 0x44, 0x39, 0x80, 0x9a, 0x31, 0xf7, 0x61, 0x45, 0x62, 0x28, 0x9e, 0x85, 0x7d, 0xd4, 0x13,
-	0x8a, 0x5e, 0x80, 0x47, 0x76, 0x74, 0xd1, 0xcb, 0xfd, 0x0b, 0x3e, 0xe4, 0x6e, 0x33, 0xf6, 0x6e,
-	0x4b, 0x3f, 0xfa, 0x1f, 0xd2, 0xeb, 0x20, 0xdb, 0x74, 0x5d, 0x32, 0x6e, 0xba, 0x6e, 0x17, 0x51,
-	0x0a, 0x1d, 0x44, 0xc5, 0xdc, 0x1a, 0x07, 0x07, 0x5a, 0x7e, 0x0f, 0xf2, 0x4d, 0xdb, 0x46, 0x43,
-	0x86, 0x7a, 0x12, 0xf3, 0x01, 0x4d, 0x25, 0x4c, 0x55, 0xc1, 0xc9, 0x15, 0x9a, 0x8a, 0x41, 0xa4,
-	0x4c, 0x7e, 0xbe, 0x83, 0xaa, 0x0f, 0xb2, 0x7b, 0x54, 0x31, 0x4f, 0x1d, 0x9c, 0x7a, 0xd2, 0xc3,
-	0xb9, 0x1e, 0xb4, 0xb2, 0x7f, 0x6e, 0x8a, 0xaa, 0x09, 0xc7, 0x9b, 0xff, 0x9c, 0x08, 0x9b, 0x9b,
-	0xbc, 0xc3, 0x75, 0x5a, 0x6f, 0x16, 0xbf, 0xb5, 0xc4, 0x62, 0xa5, 0x29, 0xd7, 0x2b, 0x4d, 0xf9,
-	0xb5, 0xd2, 0x94, 0xaf, 0x6b, 0x2d, 0x71, 0xbd, 0xd6, 0x12, 0x3f, 0xd6, 0x5a, 0xe2, 0xf3, 0xf3,
-	0xad, 0x86, 0xb6, 0x09, 0xf5, 0x3e, 0xc5, 0x6f, 0x5c, 0xcf, 0x98, 0x88, 0xb7, 0x8e, 0x37, 0xd5,
-	0x4a, 0xf2, 0x8d, 0x7f, 0xf5, 0x37, 0x00, 0x00, 0xff, 0xff, 0x13, 0xc8, 0x1d, 0x99, 0x09, 0x05,
-	0x00, 0x00,
+	// 659 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x55, 0x4d, 0x53, 0xd3, 0x40,
+	0x18, 0x6e, 0xf8, 0x28, 0xb0, 0x88, 0x1f, 0x01, 0x6b, 0x61, 0x30, 0x65, 0xea, 0x8c, 0xf6, 0xd2,
+	0xc4, 0xd6, 0x93, 0xcc, 0x78, 0x68, 0xa2, 0x75, 0x1c, 0xec, 0x25, 0x17, 0x19, 0x2f, 0xcc, 0x26,
+	0x59, 0xd2, 0x1d, 0x92, 0x2c, 0x93, 0xdd, 0xd0, 0x96, 0x3f, 0xa1, 0xbf, 0xc3, 0x33, 0x07, 0x7f,
+	0x02, 0xc3, 0x89, 0xa3, 0x27, 0x54, 0xf8, 0x17, 0x9e, 0x9c, 0xec, 0x07, 0xa4, 0x75, 0xca, 0x11,
+	0x2f, 0xc9, 0xbe, 0xef, 0xbb, 0xcf, 0xf3, 0x3e, 0xfb, 0xee, 0xd3, 0x06, 0x6c, 0xfa, 0x84, 0xc6,
+	0x03, 0x48, 0x63, 0x8b, 0x3f, 0x8e, 0x5a, 0x16, 0xcc, 0x58, 0xff, 0xd8, 0x3c, 0x4c, 0x09, 0x23,
+	0xfa, 0x43, 0x55, 0x35, 0xf9, 0xe3, 0xa8, 0xb5, 0xb1, 0x16, 0x92, 0x90, 0xf0, 0xa2, 0x95, 0xaf,
+	0xc4, 0xbe, 0x8d, 0xf5, 0x7c, 0x1f, 0xa1, 0x7b, 0xa2, 0x20, 0x02, 0x59, 0x32, 0x44, 0x64, 0x79,
+	0x90, 0x22, 0xeb, 0xa8, 0xe5, 0x21, 0x06, 0x5b, 0x96, 0x4f, 0x70, 0xa2, 0xa0, 0x21, 0x21, 0x61,
+	0x84, 0x2c, 0x1e, 0x79, 0xd9, 0xbe, 0x05, 0x93, 0x91, 0x28, 0xd5, 0x53, 0x60, 0x38, 0x24, 0x61,
+	0x29, 0xf4, 0xd9, 0xbb, 0x21, 0xf2, 0x33, 0x86, 0x49, 0xd2, 0xc9, 0x58, 0x9f, 0xa4, 0xf8, 0x18,
+	0xe6, 0x81, 0xfe, 0x06, 0x94, 0xc3, 0x14, 0x26, 0x8c, 0x56, 0xb5, 0xad, 0xd9, 0xc6, 0x72, 0xbb,
+	0x66, 0x4e, 0x0a, 0x36, 0x15, 0xc3, 0xfb, 0x7c, 0x9f, 0x3d, 0x77, 0x7a, 0x51, 0x2b, 0xb9, 0x12,
+	0xb4, 0xfd, 0xe8, 0xec, 0xa4, 0xb9, 0x32, 0xc6, 0x58, 0x1f, 0x80, 0x67, 0x0a, 0x61, 0x43, 0xe6,
+	0xf7, 0xef, 0xac, 0x71, 0xe1, 0xb0, 0x3d, 0x1c, 0xa6, 0xf0, 0x2e, 0x7a, 0x7e, 0xd7, 0xc0, 0xca,
+	0x18, 0x44, 0xdf, 0x00, 0x8b, 0xbe, 0x4c, 0x54, 0xb5, 0x2d, 0xad, 0xb1, 0xe4, 0x5e, 0xc7, 0xba,
+	0x03, 0xe6, 0x23, 0x1c, 0x63, 0x56, 0x9d, 0xd9, 0xd2, 0x1a, 0xcb, 0xed, 0x35, 0x53, 0xdc, 0x9c,
+	0xa9, 0x6e, 0xce, 0xec, 0x24, 0x23, 0xfb, 0xc9, 0xd9, 0x49, 0x73, 0x55, 0x71, 0xe6, 0xdd, 0x8e,
+	0x3f, 0xe6, 0x98, 0x5d, 0x57, 0x60, 0xf5, 0x2e, 0x28, 0xef, 0xe3, 0x88, 0xa1, 0xb4, 0x3a, 0x7b,
+	0x0b, 0x4b, 0xf5, 0xec, 0xa4, 0xb9, 0x36, 0xc6, 0xd2, 0xe5, 0xa0, 0x5d, 0x57, 0xa2, 0xeb, 0x5d,
+	0xb0, 0xd2, 0x83, 0x43, 0x07, 0x46, 0x11, 0xe5, 0x0d, 0xf4, 0x4d, 0xb0, 0x94, 0xa2, 0x18, 0xe2,
+	0x04, 0x27, 0x21, 0x97, 0x3e, 0xe7, 0xde, 0x24, 0xb6, 0xa7, 0xc9, 0xaa, 0x7f, 0xd1, 0x38, 0x51,
+	0x37, 0x4b, 0x02, 0x49, 0x84, 0xc0, 0x02, 0x8c, 0x49, 0x76, 0x33, 0xe7, 0x75, 0x53, 0x1a, 0x3a,
+	0xb7, 0xb0, 0x29, 0x2d, 0x6c, 0x3a, 0x04, 0x27, 0xf6, 0xcb, 0x7c, 0xc2, 0xdf, 0x7e, 0xd6, 0x1a,
+	0x21, 0x66, 0xfd, 0xcc, 0x33, 0x7d, 0x12, 0x4b, 0xf7, 0xcb, 0x57, 0x93, 0x06, 0x07, 0x16, 0x1b,
+	0x1d, 0x22, 0xca, 0x01, 0xd4, 0x55, 0xdc, 0xd3, 0x15, 0x89, 0x4b, 0x89, 0x3d, 0x9c, 0xa0, 0x40,
+	0x28, 0x7a, 0x01, 0x1e, 0xf8, 0xf9, 0x41, 0xf7, 0x26, 0x0f, 0x78, 0x9f, 0xa7, 0x5d, 0x95, 0x2d,
+	0x4a, 0x9f, 0xf9, 0x1f, 0xd2, 0x31, 0x58, 0xed, 0xc1, 0x61, 0x0f, 0xb1, 0x3e, 0x09, 0x0a, 0x57,
+	0xf3, 0x1a, 0xcc, 0x73, 0xa1, 0x72, 0x9e, 0x4f, 0xff, 0xf5, 0x6d, 0x01, 0x22, 0x5d, 0x2b, 0x10,
+	0xd3, 0x5b, 0x39, 0x60, 0xb9, 0x00, 0xd2, 0x2b, 0xa0, 0x1c, 0xf3, 0x50, 0xba, 0x56, 0x46, 0xe3,
+	0xae, 0x98, 0x99, 0x70, 0x45, 0xbd, 0x0d, 0x2a, 0x9d, 0x28, 0x22, 0x83, 0x4e, 0x14, 0xf5, 0x10,
+	0xa5, 0x30, 0x44, 0x54, 0xf8, 0x6c, 0x7b, 0xaa, 0x01, 0xeb, 0x1f, 0xc0, 0x7a, 0xc7, 0xf7, 0xd1,
+	0x21, 0x43, 0x81, 0xc4, 0xec, 0xa0, 0x91, 0x84, 0xe9, 0x3a, 0x98, 0x3b, 0x40, 0x23, 0x71, 0xd0,
+	0x25, 0x97, 0xaf, 0x6f, 0xa1, 0xda, 0x07, 0x95, 0x09, 0x2a, 0xc5, 0xd3, 0x06, 0x8b, 0xb1, 0xcc,
+	0x70, 0xae, 0x7b, 0x76, 0xe5, 0xcf, 0x45, 0x4d, 0x77, 0xe1, 0xe0, 0xfa, 0x3f, 0x42, 0x94, 0xdd,
+	0xeb, 0x7d, 0xb7, 0xf4, 0xd9, 0x01, 0x8f, 0x6f, 0xfa, 0xe4, 0x63, 0x51, 0x6d, 0xaa, 0x60, 0x41,
+	0xcc, 0x49, 0x29, 0x56, 0xe1, 0x74, 0x32, 0xfb, 0xed, 0xe9, 0x6f, 0xa3, 0x74, 0x7a, 0x69, 0x68,
+	0xe7, 0x97, 0x86, 0xf6, 0xeb, 0xd2, 0xd0, 0xbe, 0x5e, 0x19, 0xa5, 0xf3, 0x2b, 0xa3, 0xf4, 0xe3,
+	0xca, 0x28, 0x7d, 0x7e, 0x5e, 0x70, 0x93, 0x43, 0x68, 0xfc, 0x49, 0x7d, 0x59, 0x02, 0x6b, 0xc8,
+	0xdf, 0xc2, 0x51, 0x5e, 0x99, 0xff, 0xdc, 0x5f, 0xfd, 0x1d, 0x00, 0x1c, 0x45, 0x14, 0x45, 0x7f,
+	0x06, 0x00, 0x00,
 }
 
 func (m *ContractExecutionAuthorization) Marshal() (dAtA []byte, err error) {
@@ -490,6 +660,43 @@ func (m *ContractExecutionAuthorization) MarshalToSizedBuffer(dAtA []byte) (int,
 	return len(dAtA) - i, nil
 }
 
+func (m *ContractBatchExecutionAuthorization) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ContractBatchExecutionAuthorization) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContractBatchExecutionAuthorization) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Grants) > 0 {
+		for iNdEx := len(m.Grants) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Grants[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAuthz(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ContractMigrationAuthorization) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -688,6 +895,78 @@ func (m *CombinedLimit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *MaxMethodCallsLimit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MaxMethodCallsLimit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MaxMethodCallsLimit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Calls) > 0 {
+		for iNdEx := len(m.Calls) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Calls[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintAuthz(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MethodCalls) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MethodCalls) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MethodCalls) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Remaining != 0 {
+		i = encodeVarintAuthz(dAtA, i, uint64(m.Remaining))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Method) > 0 {
+		i -= len(m.Method)
+		copy(dAtA[i:], m.Method)
+		i = encodeVarintAuthz(dAtA, i, uint64(len(m.Method)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *AllowAllMessagesFilter) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -775,6 +1054,38 @@ func (m *AcceptedMessagesFilter) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *AcceptedMethodsFilter) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AcceptedMethodsFilter) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AcceptedMethodsFilter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Methods) > 0 {
+		for iNdEx := len(m.Methods) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Methods[iNdEx])
+			copy(dAtA[i:], m.Methods[iNdEx])
+			i = encodeVarintAuthz(dAtA, i, uint64(len(m.Methods[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintAuthz(dAtA []byte, offset int, v uint64) int {
 	offset -= sovAuthz(v)
 	base := offset
@@ -801,6 +1112,21 @@ func (m *ContractExecutionAuthorization) Size() (n int) {
 	return n
 }
 
+func (m *ContractBatchExecutionAuthorization) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Grants) > 0 {
+		for _, e := range m.Grants {
+			l = e.Size()
+			n += 1 + l + sovAuthz(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *ContractMigrationAuthorization) Size() (n int) {
 	if m == nil {
 		return 0
@@ -882,42 +1208,88 @@ func (m *CombinedLimit) Size() (n int) {
 	return n
 }
 
-func (m *AllowAllMessagesFilter) Size() (n int) {
+func (m *MaxMethodCallsLimit) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
+	if len(m.Calls) > 0 {
+		for _, e := range m.Calls {
+			l = e.Size()
+			n += 1 + l + sovAuthz(uint64(l))
+		}
+	}
 	return n
 }
 
-func (m *AcceptedMessageKeysFilter) Size() (n int) {
+func (m *MethodCalls) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Keys) > 0 {
-		for _, s := range m.Keys {
-			l = len(s)
-			n += 1 + l + sovAuthz(uint64(l))
-		}
+	l = len(m.Method)
+	if l > 0 {
+		n += 1 + l + sovAuthz(uint64(l))
+	}
+	if m.Remaining != 0 {
+		n += 1 + sovAuthz(uint64(m.Remaining))
 	}
 	return n
 }
 
-func (m *AcceptedMessagesFilter) Size() (n int) {
+func (m *AllowAllMessagesFilter) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Messages) > 0 {
-		for _, b := range m.Messages {
-			l = len(b)
-			n += 1 + l + sovAuthz(uint64(l))
-		}
-	}
+	return n
+}
+
+func (m *AcceptedMessageKeysFilter) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Keys) > 0 {
+		for _, s := range m.Keys {
+			l = len(s)
+			n += 1 + l + sovAuthz(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AcceptedMessagesFilter) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Messages) > 0 {
+		for _, b := range m.Messages {
+			l = len(b)
+			n += 1 + l + sovAuthz(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *AcceptedMethodsFilter) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Methods) > 0 {
+		for _, s := range m.Methods {
+			l = len(s)
+			n += 1 + l + sovAuthz(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1011,6 +1383,90 @@ func (m *ContractExecutionAuthorization) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ContractBatchExecutionAuthorization) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuthz
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractBatchExecutionAuthorization: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractBatchExecutionAuthorization: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Grants", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Grants = append(m.Grants, ContractGrant{})
+			if err := m.Grants[len(m.Grants)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuthz(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *ContractMigrationAuthorization) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1505,6 +1961,191 @@ func (m *CombinedLimit) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *MaxMethodCallsLimit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuthz
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MaxMethodCallsLimit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MaxMethodCallsLimit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Calls", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Calls = append(m.Calls, MethodCalls{})
+			if err := m.Calls[len(m.Calls)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuthz(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MethodCalls) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuthz
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MethodCalls: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MethodCalls: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Method = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Remaining", wireType)
+			}
+			m.Remaining = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Remaining |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuthz(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *AllowAllMessagesFilter) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -1719,6 +2360,88 @@ func (m *AcceptedMessagesFilter) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *AcceptedMethodsFilter) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowAuthz
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AcceptedMethodsFilter: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AcceptedMethodsFilter: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Methods", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAuthz
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Methods = append(m.Methods, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipAuthz(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthAuthz
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipAuthz(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
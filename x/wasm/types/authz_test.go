@@ -71,6 +71,25 @@ func TestContractAuthzFilterValidate(t *testing.T) {
 		"allow all message - always valid": {
 			src: NewAllowAllMessagesFilter(),
 		},
+		"allow methods - multiple": {
+			src: NewAcceptedMethodsFilter("updateName", "sayHello"),
+		},
+		"allow methods - empty": {
+			src:    NewAcceptedMethodsFilter(),
+			expErr: true,
+		},
+		"allow methods - empty method": {
+			src:    NewAcceptedMethodsFilter("updateName", ""),
+			expErr: true,
+		},
+		"allow methods - duplicate": {
+			src:    NewAcceptedMethodsFilter("updateName", "updateName"),
+			expErr: true,
+		},
+		"allow methods - invalid name": {
+			src:    NewAcceptedMethodsFilter("update name"),
+			expErr: true,
+		},
 		"undefined - always invalid": {
 			src:    &UndefinedFilter{},
 			expErr: true,
@@ -170,6 +189,11 @@ func TestContractAuthzFilterAccept(t *testing.T) {
 			src:    []byte(`{"foo":"bar"}`),
 			expErr: true,
 		},
+		"allow methods - rejects message without method": {
+			filter: NewAcceptedMethodsFilter("updateName"),
+			src:    []byte(`{"foo":"bar"}`),
+			exp:    false,
+		},
 	}
 	for name, spec := range specs {
 		t.Run(name, func(t *testing.T) {
@@ -188,6 +212,47 @@ func TestContractAuthzFilterAccept(t *testing.T) {
 	}
 }
 
+func TestAcceptedMethodsFilterAcceptMethod(t *testing.T) {
+	filter := NewAcceptedMethodsFilter("updateName", "sayHello")
+	specs := map[string]struct {
+		method string
+		src    RawContractMessage
+		exp    bool
+		expErr bool
+	}{
+		"accepted method": {
+			method: "sayHello",
+			src:    []byte(`{}`),
+			exp:    true,
+		},
+		"other method": {
+			method: "init",
+			src:    []byte(`{}`),
+			exp:    false,
+		},
+		"no method": {
+			src: []byte(`{"updateName":{}}`),
+			exp: false,
+		},
+		"accepted method with invalid json": {
+			method: "updateName",
+			src:    []byte(`not json`),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			allowed, gotErr := filter.AcceptMethod(sdk.Context{}, spec.method, spec.src)
+			if spec.expErr {
+				require.Error(t, gotErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.exp, allowed)
+		})
+	}
+}
+
 func TestContractAuthzLimitValidate(t *testing.T) {
 	oneToken := sdk.NewCoin(sdk.DefaultBondDenom, sdk.OneInt())
 	specs := map[string]struct {
@@ -238,6 +303,29 @@ func TestContractAuthzLimitValidate(t *testing.T) {
 			src:    &CombinedLimit{CallsRemaining: 1, Amounts: sdk.Coins{oneToken, oneToken}},
 			expErr: true,
 		},
+		"max method calls": {
+			src: NewMaxMethodCallsLimit(MethodCalls{Method: "updateName", Remaining: 1}, MethodCalls{Method: "sayHello", Remaining: 2}),
+		},
+		"max method calls - empty": {
+			src:    NewMaxMethodCallsLimit(),
+			expErr: true,
+		},
+		"max method calls - empty calls": {
+			src:    NewMaxMethodCallsLimit(MethodCalls{Method: "updateName"}),
+			expErr: true,
+		},
+		"max method calls - empty method": {
+			src:    NewMaxMethodCallsLimit(MethodCalls{Remaining: 1}),
+			expErr: true,
+		},
+		"max method calls - invalid method": {
+			src:    NewMaxMethodCallsLimit(MethodCalls{Method: "update-name", Remaining: 1}),
+			expErr: true,
+		},
+		"max method calls - duplicate method": {
+			src:    NewMaxMethodCallsLimit(MethodCalls{Method: "updateName", Remaining: 1}, MethodCalls{Method: "updateName", Remaining: 2}),
+			expErr: true,
+		},
 		"undefined": {
 			src:    &UndefinedLimit{},
 			expErr: true,
@@ -369,6 +457,38 @@ func TestContractAuthzLimitAccept(t *testing.T) {
 			src:   &MsgExecuteContract{Funds: sdk.NewCoins(otherToken)},
 			exp:   &ContractAuthzLimitAcceptResult{Accepted: false},
 		},
+		"max method calls - updated": {
+			limit: NewMaxMethodCallsLimit(MethodCalls{Method: "updateName", Remaining: 2}, MethodCalls{Method: "sayHello", Remaining: 1}),
+			src:   &MsgExecuteContract{Method: "updateName"},
+			exp: &ContractAuthzLimitAcceptResult{Accepted: true, UpdateLimit: NewMaxMethodCallsLimit(
+				MethodCalls{Method: "updateName", Remaining: 1}, MethodCalls{Method: "sayHello", Remaining: 1},
+			)},
+		},
+		"max method calls - method removed": {
+			limit: NewMaxMethodCallsLimit(MethodCalls{Method: "updateName", Remaining: 2}, MethodCalls{Method: "sayHello", Remaining: 1}),
+			src:   &MsgExecuteContract{Method: "sayHello"},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: true, UpdateLimit: NewMaxMethodCallsLimit(MethodCalls{Method: "updateName", Remaining: 2})},
+		},
+		"max method calls - removed on last call": {
+			limit: NewMaxMethodCallsLimit(MethodCalls{Method: "sayHello", Remaining: 1}),
+			src:   &MsgExecuteContract{Method: "sayHello"},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: true, DeleteLimit: true},
+		},
+		"max method calls - other method": {
+			limit: NewMaxMethodCallsLimit(MethodCalls{Method: "sayHello", Remaining: 1}),
+			src:   &MsgExecuteContract{Method: "updateName"},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: false},
+		},
+		"max method calls - no method": {
+			limit: NewMaxMethodCallsLimit(MethodCalls{Method: "sayHello", Remaining: 1}),
+			src:   &MsgMigrateContract{},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: false},
+		},
+		"max method calls - rejected with some fund transfer": {
+			limit: NewMaxMethodCallsLimit(MethodCalls{Method: "sayHello", Remaining: 1}),
+			src:   &MsgExecuteContract{Method: "sayHello", Funds: sdk.NewCoins(oneToken)},
+			exp:   &ContractAuthzLimitAcceptResult{Accepted: false},
+		},
 		"undefined": {
 			limit:  &UndefinedLimit{},
 			expErr: true,
@@ -646,6 +766,54 @@ func TestAcceptGrantedMessage(t *testing.T) {
 			},
 			expResult: authztypes.AcceptResponse{Accept: false},
 		},
+		"accepted and updated - wrapper method": {
+			auth: NewContractExecutionAuthorization(mustGrant(myContractAddr,
+				NewMaxMethodCallsLimit(MethodCalls{Method: "updateName", Remaining: 2}),
+				NewAcceptedMethodsFilter("updateName"),
+			)),
+			msg: &MsgExecuteContract{
+				Sender:   sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				Contract: myContractAddr.String(),
+				Msg:      []byte(`{"newName":"Joe"}`),
+				Method:   "updateName",
+			},
+			expResult: authztypes.AcceptResponse{
+				Accept: true,
+				Updated: NewContractExecutionAuthorization(mustGrant(myContractAddr,
+					NewMaxMethodCallsLimit(MethodCalls{Method: "updateName", Remaining: 1}),
+					NewAcceptedMethodsFilter("updateName"),
+				)),
+			},
+		},
+		"not accepted - method not allowed": {
+			auth: NewContractExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAcceptedMethodsFilter("sayHello"))),
+			msg: &MsgExecuteContract{
+				Sender:   sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				Contract: myContractAddr.String(),
+				Msg:      []byte(`{"newName":"Joe"}`),
+				Method:   "updateName",
+			},
+			expResult: authztypes.AcceptResponse{Accept: false},
+		},
+		"not accepted - message without method": {
+			auth: NewContractExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAcceptedMethodsFilter("updateName"))),
+			msg: &MsgExecuteContract{
+				Sender:   sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				Contract: myContractAddr.String(),
+				Msg:      []byte(`{"updateName":{}}`),
+			},
+			expResult: authztypes.AcceptResponse{Accept: false},
+		},
+		"not accepted - method envelope is not unwrapped": {
+			// only the message encoders unwrap envelopes, a signed execute names its method in the field
+			auth: NewContractExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAcceptedMethodsFilter("updateName"))),
+			msg: &MsgExecuteContract{
+				Sender:   sdk.AccAddress(randBytes(SDKAddrLen)).String(),
+				Contract: myContractAddr.String(),
+				Msg:      []byte(`{"wasmos":{"method":"updateName","msg":{"newName":"Joe"}}}`),
+			},
+			expResult: authztypes.AcceptResponse{Accept: false},
+		},
 		"invalid msg type - contract execution": {
 			auth: NewContractExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter())),
 			msg: &MsgMigrateContract{
@@ -726,3 +894,86 @@ func mustGrant(contract sdk.AccAddress, limit ContractAuthzLimitX, filter Contra
 	}
 	return *g
 }
+
+func TestAcceptGrantedBatch(t *testing.T) {
+	myContractAddr := sdk.AccAddress(randBytes(SDKAddrLen))
+	otherContractAddr := sdk.AccAddress(randBytes(SDKAddrLen))
+	sender := sdk.AccAddress(randBytes(SDKAddrLen)).String()
+	call := func(contract sdk.AccAddress, method string) ContractCall {
+		return ContractCall{Contract: contract.String(), Method: method, Msg: []byte(`{}`)}
+	}
+	specs := map[string]struct {
+		auth      authztypes.Authorization
+		calls     []ContractCall
+		expResult authztypes.AcceptResponse
+		expErr    *sdkerrors.Error
+	}{
+		"accepted and updated - every call counted": {
+			auth:  NewContractBatchExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(3), NewAllowAllMessagesFilter())),
+			calls: []ContractCall{call(myContractAddr, "init"), call(myContractAddr, "deposit")},
+			expResult: authztypes.AcceptResponse{
+				Accept:  true,
+				Updated: NewContractBatchExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter())),
+			},
+		},
+		"accepted and removed - limit used up by the batch": {
+			auth:      NewContractBatchExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(2), NewAllowAllMessagesFilter())),
+			calls:     []ContractCall{call(myContractAddr, "init"), call(myContractAddr, "deposit")},
+			expResult: authztypes.AcceptResponse{Accept: true, Delete: true},
+		},
+		"accepted and not updated - limit not touched": {
+			auth:      NewContractBatchExecutionAuthorization(mustGrant(myContractAddr, NewMaxFundsLimit(sdk.NewCoin(sdk.DefaultBondDenom, sdk.OneInt())), NewAllowAllMessagesFilter())),
+			calls:     []ContractCall{call(myContractAddr, "init"), call(myContractAddr, "deposit")},
+			expResult: authztypes.AcceptResponse{Accept: true},
+		},
+		"accepted and updated - calls on multiple contracts": {
+			auth: NewContractBatchExecutionAuthorization(
+				mustGrant(myContractAddr, NewMaxMethodCallsLimit(MethodCalls{Method: "init", Remaining: 1}, MethodCalls{Method: "deposit", Remaining: 2}), NewAcceptedMethodsFilter("init", "deposit")),
+				mustGrant(otherContractAddr, NewMaxCallsLimit(1), NewAcceptedMethodsFilter("approve")),
+			),
+			calls: []ContractCall{call(myContractAddr, "init"), call(otherContractAddr, "approve"), call(myContractAddr, "deposit")},
+			expResult: authztypes.AcceptResponse{
+				Accept: true,
+				Updated: NewContractBatchExecutionAuthorization(
+					mustGrant(myContractAddr, NewMaxMethodCallsLimit(MethodCalls{Method: "deposit", Remaining: 1}), NewAcceptedMethodsFilter("init", "deposit")),
+				),
+			},
+		},
+		"not accepted - one call not granted": {
+			auth:      NewContractBatchExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(3), NewAcceptedMethodsFilter("init"))),
+			calls:     []ContractCall{call(myContractAddr, "init"), call(myContractAddr, "withdraw")},
+			expResult: authztypes.AcceptResponse{Accept: false},
+		},
+		"not accepted - limit exceeded within the batch": {
+			auth:      NewContractBatchExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter())),
+			calls:     []ContractCall{call(myContractAddr, "init"), call(myContractAddr, "deposit")},
+			expResult: authztypes.AcceptResponse{Accept: false},
+		},
+		"not accepted - other contract": {
+			auth:      NewContractBatchExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(3), NewAllowAllMessagesFilter())),
+			calls:     []ContractCall{call(myContractAddr, "init"), call(otherContractAddr, "init")},
+			expResult: authztypes.AcceptResponse{Accept: false},
+		},
+		"empty batch": {
+			auth:   NewContractBatchExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter())),
+			expErr: ErrEmpty,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			ctx := sdk.Context{}.WithGasMeter(sdk.NewInfiniteGasMeter())
+			gotResult, gotErr := spec.auth.Accept(ctx, &MsgExecuteContractBatch{Sender: sender, Calls: spec.calls})
+			if spec.expErr != nil {
+				require.ErrorIs(t, gotErr, spec.expErr)
+				return
+			}
+			require.NoError(t, gotErr)
+			assert.Equal(t, spec.expResult, gotResult)
+		})
+	}
+
+	// single executions are not accepted by batch grants
+	auth := NewContractBatchExecutionAuthorization(mustGrant(myContractAddr, NewMaxCallsLimit(1), NewAllowAllMessagesFilter()))
+	_, err := auth.Accept(sdk.Context{}, &MsgExecuteContract{Sender: sender, Contract: myContractAddr.String(), Msg: []byte(`{}`)})
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidType)
+}
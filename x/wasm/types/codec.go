@@ -16,6 +16,7 @@ func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) { //nolint:staticcheck
 	cdc.RegisterConcrete(&MsgInstantiateContract{}, "wasm/MsgInstantiateContract", nil)
 	cdc.RegisterConcrete(&MsgInstantiateContract2{}, "wasm/MsgInstantiateContract2", nil)
 	cdc.RegisterConcrete(&MsgExecuteContract{}, "wasm/MsgExecuteContract", nil)
+	cdc.RegisterConcrete(&MsgExecuteContractBatch{}, "wasm/MsgExecuteContractBatch", nil)
 	cdc.RegisterConcrete(&MsgMigrateContract{}, "wasm/MsgMigrateContract", nil)
 	cdc.RegisterConcrete(&MsgUpdateAdmin{}, "wasm/MsgUpdateAdmin", nil)
 	cdc.RegisterConcrete(&MsgClearAdmin{}, "wasm/MsgClearAdmin", nil)
@@ -29,6 +30,7 @@ func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) { //nolint:staticcheck
 	cdc.RegisterConcrete(&MigrateContractProposal{}, "wasm/MigrateContractProposal", nil)
 	cdc.RegisterConcrete(&SudoContractProposal{}, "wasm/SudoContractProposal", nil)
 	cdc.RegisterConcrete(&ExecuteContractProposal{}, "wasm/ExecuteContractProposal", nil)
+	cdc.RegisterConcrete(&ExecuteContractBatchProposal{}, "wasm/ExecuteContractBatchProposal", nil)
 	cdc.RegisterConcrete(&UpdateAdminProposal{}, "wasm/UpdateAdminProposal", nil)
 	cdc.RegisterConcrete(&ClearAdminProposal{}, "wasm/ClearAdminProposal", nil)
 	cdc.RegisterConcrete(&UpdateInstantiateConfigProposal{}, "wasm/UpdateInstantiateConfigProposal", nil)
@@ -39,13 +41,16 @@ func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) { //nolint:staticcheck
 	cdc.RegisterConcrete(&AllowAllMessagesFilter{}, "wasm/AllowAllMessagesFilter", nil)
 	cdc.RegisterConcrete(&AcceptedMessageKeysFilter{}, "wasm/AcceptedMessageKeysFilter", nil)
 	cdc.RegisterConcrete(&AcceptedMessagesFilter{}, "wasm/AcceptedMessagesFilter", nil)
+	cdc.RegisterConcrete(&AcceptedMethodsFilter{}, "wasm/AcceptedMethodsFilter", nil)
 
 	cdc.RegisterInterface((*ContractAuthzLimitX)(nil), nil)
 	cdc.RegisterConcrete(&MaxCallsLimit{}, "wasm/MaxCallsLimit", nil)
 	cdc.RegisterConcrete(&MaxFundsLimit{}, "wasm/MaxFundsLimit", nil)
 	cdc.RegisterConcrete(&CombinedLimit{}, "wasm/CombinedLimit", nil)
+	cdc.RegisterConcrete(&MaxMethodCallsLimit{}, "wasm/MaxMethodCallsLimit", nil)
 
 	cdc.RegisterConcrete(&ContractExecutionAuthorization{}, "wasm/ContractExecutionAuthorization", nil)
+	cdc.RegisterConcrete(&ContractBatchExecutionAuthorization{}, "wasm/ContractBatchExecutionAuthorization", nil)
 	cdc.RegisterConcrete(&ContractMigrationAuthorization{}, "wasm/ContractMigrationAuthorization", nil)
 	cdc.RegisterConcrete(&StoreAndInstantiateContractProposal{}, "wasm/StoreAndInstantiateContractProposal", nil)
 }
@@ -57,6 +62,7 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&MsgInstantiateContract{},
 		&MsgInstantiateContract2{},
 		&MsgExecuteContract{},
+		&MsgExecuteContractBatch{},
 		&MsgMigrateContract{},
 		&MsgUpdateAdmin{},
 		&MsgClearAdmin{},
@@ -72,6 +78,7 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&MigrateContractProposal{},
 		&SudoContractProposal{},
 		&ExecuteContractProposal{},
+		&ExecuteContractBatchProposal{},
 		&UpdateAdminProposal{},
 		&ClearAdminProposal{},
 		&PinCodesProposal{},
@@ -88,6 +95,7 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&AllowAllMessagesFilter{},
 		&AcceptedMessageKeysFilter{},
 		&AcceptedMessagesFilter{},
+		&AcceptedMethodsFilter{},
 	)
 
 	registry.RegisterInterface("ContractAuthzLimitX", (*ContractAuthzLimitX)(nil))
@@ -96,11 +104,13 @@ func RegisterInterfaces(registry types.InterfaceRegistry) {
 		&MaxCallsLimit{},
 		&MaxFundsLimit{},
 		&CombinedLimit{},
+		&MaxMethodCallsLimit{},
 	)
 
 	registry.RegisterImplementations(
 		(*authz.Authorization)(nil),
 		&ContractExecutionAuthorization{},
+		&ContractBatchExecutionAuthorization{},
 		&ContractMigrationAuthorization{},
 	)
 
@@ -0,0 +1,177 @@
+package types
+
+import (
+	wasmvm "github.com/CosmWasm/wasmvm"
+	wasmvmtypes "github.com/CosmWasm/wasmvm/types"
+)
+
+// ContractEngine is the contract runtime the keeper dispatches a call to. The engine of a contract
+// is selected by the Runtime recorded in its CodeInfo.
+// It matches the WasmerEngine calls made by the keeper except that Execute and Query take the
+// wrapper method to invoke, which CosmWasm contracts ignore.
+type ContractEngine interface {
+	// Create stores the code and returns its checksum
+	Create(code wasmvm.WasmCode) (wasmvm.Checksum, error)
+
+	// AnalyzeCode reports the entry points and capabilities required by the code
+	AnalyzeCode(checksum wasmvm.Checksum) (*wasmvmtypes.AnalysisReport, error)
+
+	// GetCode returns the original code
+	GetCode(checksum wasmvm.Checksum) (wasmvm.WasmCode, error)
+
+	Instantiate(
+		checksum wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		info wasmvmtypes.MessageInfo,
+		initMsg []byte,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) (*wasmvmtypes.Response, uint64, error)
+
+	// Execute invokes the method of a wrapper or the execute entry point of a CosmWasm contract
+	Execute(
+		checksum wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		info wasmvmtypes.MessageInfo,
+		executeMsg []byte,
+		method string,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) (*wasmvmtypes.Response, uint64, error)
+
+	// Query invokes the method of a wrapper or the query entry point of a CosmWasm contract
+	Query(
+		checksum wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		queryMsg []byte,
+		method string,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) ([]byte, uint64, error)
+
+	Migrate(
+		checksum wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		migrateMsg []byte,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) (*wasmvmtypes.Response, uint64, error)
+
+	Sudo(
+		checksum wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		sudoMsg []byte,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) (*wasmvmtypes.Response, uint64, error)
+
+	Reply(
+		checksum wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		reply wasmvmtypes.Reply,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) (*wasmvmtypes.Response, uint64, error)
+
+	IBCChannelOpen(
+		checksum wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		channel wasmvmtypes.IBCChannelOpenMsg,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) (*wasmvmtypes.IBC3ChannelOpenResponse, uint64, error)
+
+	IBCChannelConnect(
+		checksum wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		channel wasmvmtypes.IBCChannelConnectMsg,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) (*wasmvmtypes.IBCBasicResponse, uint64, error)
+
+	IBCChannelClose(
+		checksum wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		channel wasmvmtypes.IBCChannelCloseMsg,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) (*wasmvmtypes.IBCBasicResponse, uint64, error)
+
+	IBCPacketReceive(
+		checksum wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		packet wasmvmtypes.IBCPacketReceiveMsg,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) (*wasmvmtypes.IBCReceiveResult, uint64, error)
+
+	IBCPacketAck(
+		checksum wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		ack wasmvmtypes.IBCPacketAckMsg,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) (*wasmvmtypes.IBCBasicResponse, uint64, error)
+
+	IBCPacketTimeout(
+		checksum wasmvm.Checksum,
+		env wasmvmtypes.Env,
+		packet wasmvmtypes.IBCPacketTimeoutMsg,
+		store wasmvm.KVStore,
+		goapi wasmvm.GoAPI,
+		querier wasmvm.Querier,
+		gasMeter wasmvm.GasMeter,
+		gasLimit uint64,
+		deserCost wasmvmtypes.UFraction,
+	) (*wasmvmtypes.IBCBasicResponse, uint64, error)
+
+	// Pin keeps the compiled code in memory
+	Pin(checksum wasmvm.Checksum) error
+
+	// Unpin removes the guarantee of a contract to be pinned
+	Unpin(checksum wasmvm.Checksum) error
+}
@@ -9,7 +9,7 @@ import (
 // ViewKeeper provides read only operations
 type ViewKeeper interface {
 	GetContractHistory(ctx sdk.Context, contractAddr sdk.AccAddress) []ContractCodeHistoryEntry
-	QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte) ([]byte, error)
+	QuerySmart(ctx sdk.Context, contractAddr sdk.AccAddress, req []byte, method string) ([]byte, error)
 	QueryRaw(ctx sdk.Context, contractAddress sdk.AccAddress, key []byte) []byte
 	HasContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) bool
 	GetContractInfo(ctx sdk.Context, contractAddress sdk.AccAddress) *ContractInfo
@@ -20,6 +20,7 @@ type ViewKeeper interface {
 	GetCodeInfo(ctx sdk.Context, codeID uint64) *CodeInfo
 	IterateCodeInfos(ctx sdk.Context, cb func(uint64, CodeInfo) bool)
 	GetByteCode(ctx sdk.Context, codeID uint64) ([]byte, error)
+	GetManifest(ctx sdk.Context, codeID uint64) []byte
 	IsPinnedCode(ctx sdk.Context, codeID uint64) bool
 	GetParams(ctx sdk.Context) Params
 }
@@ -29,6 +30,9 @@ type ContractOpsKeeper interface {
 	// Create uploads and compiles a WASM contract, returning a short identifier for the contract
 	Create(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *AccessConfig) (codeID uint64, checksum []byte, err error)
 
+	// CreateWithRuntime uploads the code for the given runtime. The runtime is detected from the wasm exports when unspecified.
+	CreateWithRuntime(ctx sdk.Context, creator sdk.AccAddress, wasmCode []byte, instantiateAccess *AccessConfig, runtime Runtime) (codeID uint64, checksum []byte, err error)
+
 	// Instantiate creates an instance of a WASM contract using the classic sequence based address generator
 	Instantiate(
 		ctx sdk.Context,
@@ -54,6 +58,10 @@ type ContractOpsKeeper interface {
 	// Execute executes the contract instance
 	Execute(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, msg []byte, method string, coins sdk.Coins) ([]byte, error)
 
+	// ExecuteBatch executes the calls in order and returns the data of every call. State changes are
+	// only persisted when all calls succeed.
+	ExecuteBatch(ctx sdk.Context, caller sdk.AccAddress, calls []ContractCall) ([][]byte, error)
+
 	// Migrate allows to upgrade a contract to a new code with data migration.
 	Migrate(ctx sdk.Context, contractAddress sdk.AccAddress, caller sdk.AccAddress, newCodeID uint64, msg []byte) ([]byte, error)
 
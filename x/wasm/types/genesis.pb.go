@@ -99,6 +99,8 @@ type Code struct {
 	CodeBytes []byte   `protobuf:"bytes,3,opt,name=code_bytes,json=codeBytes,proto3" json:"code_bytes,omitempty"`
 	// Pinned to wasmvm cache
 	Pinned bool `protobuf:"varint,4,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	// Manifest wrap.info of a Polywrap wrapper, empty for CosmWasm contracts
+	Manifest []byte `protobuf:"bytes,5,opt,name=manifest,proto3" json:"manifest,omitempty"`
 }
 
 func (m *Code) Reset()         { *m = Code{} }
@@ -162,6 +164,13 @@ func (m *Code) GetPinned() bool {
 	return false
 }
 
+func (m *Code) GetManifest() []byte {
+	if m != nil {
+		return m.Manifest
+	}
+	return nil
+}
+
 // Contract struct encompasses ContractAddress, ContractInfo, and ContractState
 type Contract struct {
 	ContractAddress     string                     `protobuf:"bytes,1,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
@@ -294,42 +303,42 @@ func init() {
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/genesis.proto", fileDescriptor_2ab3f539b23472a6) }
 
 var fileDescriptor_2ab3f539b23472a6 = []byte{
-	// 546 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x93, 0xcf, 0x6e, 0xd3, 0x40,
-	0x10, 0xc6, 0xe3, 0xd4, 0x31, 0xe9, 0x36, 0xd0, 0x6a, 0x29, 0xad, 0x15, 0xc0, 0x89, 0x82, 0x84,
-	0x02, 0x42, 0xb6, 0x5a, 0x24, 0x6e, 0x48, 0xe0, 0xa6, 0x02, 0xab, 0x42, 0x42, 0xae, 0x10, 0x12,
-	0x97, 0xc8, 0xf1, 0x6e, 0x53, 0x8b, 0xda, 0x6b, 0xbc, 0x9b, 0x80, 0xdf, 0x82, 0x57, 0xe0, 0xce,
-	0x1b, 0xf0, 0x02, 0x3d, 0xf6, 0xc8, 0x29, 0x42, 0xce, 0x8d, 0xa7, 0x40, 0xfb, 0xc7, 0xae, 0x45,
-	0x9a, 0x8b, 0xe5, 0x9d, 0xf9, 0xe6, 0xe7, 0xf5, 0x37, 0x33, 0xc0, 0x0a, 0x09, 0x8d, 0xbf, 0x06,
-	0x34, 0x76, 0xc4, 0x63, 0x7e, 0xe0, 0x4c, 0x71, 0x82, 0x69, 0x44, 0xed, 0x34, 0x23, 0x8c, 0xc0,
-	0x9d, 0x32, 0x6f, 0x8b, 0xc7, 0xfc, 0xa0, 0xbb, 0x3b, 0x25, 0x53, 0x22, 0x92, 0x0e, 0x7f, 0x93,
-	0xba, 0xee, 0x83, 0x15, 0x0e, 0xcb, 0x53, 0xac, 0x28, 0x83, 0x5f, 0x4d, 0xd0, 0x79, 0x23, 0xb9,
-	0xa7, 0x2c, 0x60, 0x18, 0xbe, 0x00, 0x46, 0x1a, 0x64, 0x41, 0x4c, 0x4d, 0xad, 0xaf, 0x0d, 0xb7,
-	0x0e, 0x4d, 0xfb, 0xff, 0xef, 0xd8, 0xef, 0x45, 0xde, 0xd5, 0x2f, 0x17, 0xbd, 0x86, 0xaf, 0xd4,
-	0xf0, 0x18, 0xb4, 0x42, 0x82, 0x30, 0x35, 0x9b, 0xfd, 0x8d, 0xe1, 0xd6, 0xe1, 0xde, 0x6a, 0xd9,
-	0x11, 0x41, 0xd8, 0xdd, 0xe7, 0x45, 0x7f, 0x17, 0xbd, 0x6d, 0x21, 0x7e, 0x46, 0xe2, 0x88, 0xe1,
-	0x38, 0x65, 0xb9, 0x2f, 0xab, 0xe1, 0x07, 0xb0, 0x19, 0x92, 0x84, 0x65, 0x41, 0xc8, 0xa8, 0xb9,
-	0x21, 0x50, 0xdd, 0x9b, 0x50, 0x52, 0xe2, 0xde, 0x57, 0xb8, 0xbb, 0x55, 0x51, 0x0d, 0x79, 0x4d,
-	0xe2, 0x58, 0x8a, 0xbf, 0xcc, 0x70, 0x12, 0x62, 0x6a, 0xea, 0xeb, 0xb0, 0xa7, 0x4a, 0x72, 0x8d,
-	0xad, 0x8a, 0xea, 0xd8, 0x2a, 0x38, 0xf8, 0xa1, 0x01, 0x9d, 0xff, 0x16, 0x7c, 0x04, 0x6e, 0xf1,
-	0xfb, 0x8f, 0x23, 0x24, 0x6c, 0xd3, 0x5d, 0x50, 0x2c, 0x7a, 0x06, 0x4f, 0x79, 0x23, 0xdf, 0xe0,
-	0x29, 0x0f, 0xc1, 0x97, 0xfc, 0xdf, 0xb8, 0x28, 0x39, 0x23, 0x66, 0x53, 0xb8, 0xdb, 0xbd, 0xd9,
-	0x26, 0x2f, 0x39, 0x23, 0xca, 0xdf, 0x76, 0xa8, 0xce, 0xf0, 0x21, 0x00, 0xa2, 0x7c, 0x92, 0x33,
-	0xcc, 0xbd, 0xd1, 0x86, 0x1d, 0x5f, 0x00, 0x5d, 0x1e, 0x80, 0x7b, 0xc0, 0x48, 0xa3, 0x24, 0xc1,
-	0xc8, 0xd4, 0xfb, 0xda, 0xb0, 0xed, 0xab, 0xd3, 0xe0, 0x67, 0x13, 0xb4, 0x4b, 0xbf, 0xe0, 0x13,
-	0xb0, 0x53, 0x9a, 0x32, 0x0e, 0x10, 0xca, 0x30, 0x95, 0x7d, 0xde, 0xf4, 0xb7, 0xcb, 0xf8, 0x6b,
-	0x19, 0x86, 0x1e, 0xb8, 0x5d, 0x49, 0x6b, 0x37, 0xb6, 0xd6, 0x77, 0xa3, 0x76, 0xeb, 0x4e, 0x58,
-	0x8b, 0xc1, 0x11, 0xb8, 0x53, 0xa1, 0x28, 0x9f, 0x32, 0xd5, 0xd9, 0xfd, 0x55, 0xd6, 0x3b, 0x82,
-	0xf0, 0x85, 0x82, 0x54, 0xdf, 0x97, 0x93, 0x89, 0xc0, 0xbd, 0x8a, 0x22, 0x8c, 0x38, 0x8f, 0x28,
-	0x23, 0x59, 0xae, 0xfa, 0xf9, 0x74, 0xfd, 0xc5, 0xb8, 0xa5, 0x6f, 0xa5, 0xf8, 0x38, 0x61, 0x59,
-	0xae, 0xf8, 0xd5, 0xd0, 0xd4, 0xf2, 0x03, 0x17, 0xb4, 0xcb, 0x31, 0x80, 0x7d, 0x60, 0x44, 0x68,
-	0xfc, 0x19, 0xe7, 0xc2, 0xa3, 0x8e, 0xbb, 0x59, 0x2c, 0x7a, 0x2d, 0x6f, 0x74, 0x82, 0x73, 0xbf,
-	0x15, 0xa1, 0x13, 0x9c, 0xc3, 0x5d, 0xd0, 0x9a, 0x07, 0x17, 0x33, 0x2c, 0xcc, 0xd1, 0x7d, 0x79,
-	0x70, 0x5f, 0x5d, 0x16, 0x96, 0x76, 0x55, 0x58, 0xda, 0x9f, 0xc2, 0xd2, 0xbe, 0x2f, 0xad, 0xc6,
-	0xd5, 0xd2, 0x6a, 0xfc, 0x5e, 0x5a, 0x8d, 0x4f, 0x8f, 0xa7, 0x11, 0x3b, 0x9f, 0x4d, 0xec, 0x90,
-	0xc4, 0xce, 0x11, 0xa1, 0xf1, 0xc7, 0x72, 0x2f, 0x91, 0xf3, 0x4d, 0xee, 0xa7, 0x58, 0xce, 0x89,
-	0x21, 0xb6, 0xf3, 0xf9, 0xbf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x14, 0x33, 0x7b, 0x9f, 0x05, 0x04,
-	0x00, 0x00,
+	// 556 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x93, 0xdf, 0x6e, 0xd3, 0x3c,
+	0x18, 0xc6, 0x9b, 0x2e, 0xcd, 0xd7, 0xbe, 0xeb, 0xc7, 0x26, 0x6f, 0x6c, 0x51, 0x81, 0xb4, 0x2a,
+	0x12, 0x2a, 0x08, 0xb5, 0xda, 0x90, 0x38, 0x43, 0x82, 0xac, 0x13, 0x54, 0x13, 0x12, 0xca, 0x84,
+	0x90, 0x38, 0xa9, 0xd2, 0xd8, 0xed, 0x22, 0x96, 0xb8, 0xc4, 0x6e, 0x21, 0x77, 0xc1, 0x85, 0x70,
+	0x07, 0x88, 0xf3, 0x1d, 0xee, 0x90, 0xa3, 0x0a, 0xb5, 0x67, 0x5c, 0x05, 0xf2, 0x9f, 0x64, 0x11,
+	0x5d, 0x4f, 0xa2, 0xf8, 0x7d, 0x9f, 0xe7, 0x67, 0xfb, 0xb1, 0x0d, 0x4e, 0x40, 0x59, 0xf4, 0xc5,
+	0x67, 0x51, 0x4f, 0x7e, 0xe6, 0x47, 0xbd, 0x09, 0x89, 0x09, 0x0b, 0x59, 0x77, 0x9a, 0x50, 0x4e,
+	0xd1, 0x6e, 0xd6, 0xef, 0xca, 0xcf, 0xfc, 0xa8, 0xb1, 0x3f, 0xa1, 0x13, 0x2a, 0x9b, 0x3d, 0xf1,
+	0xa7, 0x74, 0x8d, 0xfb, 0x6b, 0x1c, 0x9e, 0x4e, 0x89, 0xa6, 0xb4, 0x7f, 0x94, 0xa1, 0xfe, 0x5a,
+	0x71, 0xcf, 0xb9, 0xcf, 0x09, 0x7a, 0x0e, 0xd6, 0xd4, 0x4f, 0xfc, 0x88, 0xd9, 0x46, 0xcb, 0xe8,
+	0x6c, 0x1f, 0xdb, 0xdd, 0x7f, 0xe7, 0xe9, 0xbe, 0x93, 0x7d, 0xd7, 0xbc, 0x5a, 0x34, 0x4b, 0x9e,
+	0x56, 0xa3, 0x53, 0xa8, 0x04, 0x14, 0x13, 0x66, 0x97, 0x5b, 0x5b, 0x9d, 0xed, 0xe3, 0x83, 0x75,
+	0xdb, 0x09, 0xc5, 0xc4, 0x3d, 0x14, 0xa6, 0x3f, 0x8b, 0xe6, 0x8e, 0x14, 0x3f, 0xa5, 0x51, 0xc8,
+	0x49, 0x34, 0xe5, 0xa9, 0xa7, 0xdc, 0xe8, 0x3d, 0xd4, 0x02, 0x1a, 0xf3, 0xc4, 0x0f, 0x38, 0xb3,
+	0xb7, 0x24, 0xaa, 0x71, 0x1b, 0x4a, 0x49, 0xdc, 0x7b, 0x1a, 0xb7, 0x97, 0x9b, 0x0a, 0xc8, 0x1b,
+	0x92, 0xc0, 0x32, 0xf2, 0x79, 0x46, 0xe2, 0x80, 0x30, 0xdb, 0xdc, 0x84, 0x3d, 0xd7, 0x92, 0x1b,
+	0x6c, 0x6e, 0x2a, 0x62, 0xf3, 0x62, 0xfb, 0xa7, 0x01, 0xa6, 0xd8, 0x16, 0x7a, 0x08, 0xff, 0x89,
+	0xf5, 0x0f, 0x43, 0x2c, 0x63, 0x33, 0x5d, 0x58, 0x2e, 0x9a, 0x96, 0x68, 0x0d, 0xfa, 0x9e, 0x25,
+	0x5a, 0x03, 0x8c, 0x5e, 0x40, 0x4d, 0x89, 0xe2, 0x31, 0xb5, 0xcb, 0x2d, 0xe3, 0xf6, 0x45, 0x48,
+	0x53, 0x3c, 0xa6, 0x3a, 0xdf, 0x6a, 0xa0, 0xc7, 0xe8, 0x01, 0x80, 0xb4, 0x8f, 0x52, 0x4e, 0x44,
+	0x36, 0x46, 0xa7, 0xee, 0x49, 0xa0, 0x2b, 0x0a, 0xe8, 0x00, 0xac, 0x69, 0x18, 0xc7, 0x04, 0xdb,
+	0x66, 0xcb, 0xe8, 0x54, 0x3d, 0x3d, 0x42, 0x0d, 0xa8, 0x46, 0x7e, 0x1c, 0x8e, 0x09, 0xe3, 0x76,
+	0x45, 0x9a, 0xf2, 0x71, 0xfb, 0x7b, 0x19, 0xaa, 0x59, 0x96, 0xe8, 0x31, 0xec, 0x66, 0x81, 0x0d,
+	0x7d, 0x8c, 0x13, 0xc2, 0xd4, 0x1d, 0xa8, 0x79, 0x3b, 0x59, 0xfd, 0x95, 0x2a, 0xa3, 0x01, 0xfc,
+	0x9f, 0x4b, 0x0b, 0xbb, 0x71, 0x36, 0x9f, 0x54, 0x61, 0x47, 0xf5, 0xa0, 0x50, 0x43, 0x7d, 0xb8,
+	0x93, 0xa3, 0x98, 0xb8, 0x81, 0xfa, 0xd4, 0x0f, 0xd7, 0x59, 0x6f, 0x29, 0x26, 0x97, 0x1a, 0x92,
+	0xcf, 0xaf, 0x6e, 0x2d, 0x86, 0xbb, 0x39, 0x45, 0x86, 0x74, 0x11, 0x32, 0x4e, 0x93, 0x54, 0x9f,
+	0xf5, 0x93, 0xcd, 0x0b, 0x13, 0x71, 0xbf, 0x51, 0xe2, 0xd3, 0x98, 0x27, 0xa9, 0xe6, 0xef, 0x05,
+	0xeb, 0xfd, 0xb6, 0x0b, 0xd5, 0xec, 0x8a, 0xa0, 0x16, 0x58, 0x21, 0x1e, 0x7e, 0x22, 0xa9, 0xcc,
+	0xa8, 0xee, 0xd6, 0x96, 0x8b, 0x66, 0x65, 0xd0, 0x3f, 0x23, 0xa9, 0x57, 0x09, 0xf1, 0x19, 0x49,
+	0xd1, 0x3e, 0x54, 0xe6, 0xfe, 0xe5, 0x8c, 0xc8, 0x70, 0x4c, 0x4f, 0x0d, 0xdc, 0x97, 0x57, 0x4b,
+	0xc7, 0xb8, 0x5e, 0x3a, 0xc6, 0xef, 0xa5, 0x63, 0x7c, 0x5b, 0x39, 0xa5, 0xeb, 0x95, 0x53, 0xfa,
+	0xb5, 0x72, 0x4a, 0x1f, 0x1f, 0x4d, 0x42, 0x7e, 0x31, 0x1b, 0x75, 0x03, 0x1a, 0xf5, 0x4e, 0x28,
+	0x8b, 0x3e, 0x64, 0x6f, 0x16, 0xf7, 0xbe, 0xaa, 0xb7, 0x2b, 0x1f, 0xee, 0xc8, 0x92, 0x2f, 0xf7,
+	0xd9, 0xdf, 0x01, 0x00, 0x2a, 0x85, 0x6b, 0xe4, 0x21, 0x04, 0x00, 0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -427,6 +436,13 @@ func (m *Code) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Manifest) > 0 {
+		i -= len(m.Manifest)
+		copy(dAtA[i:], m.Manifest)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.Manifest)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if m.Pinned {
 		i--
 		if m.Pinned {
@@ -623,6 +639,10 @@ func (m *Code) Size() (n int) {
 	if m.Pinned {
 		n += 2
 	}
+	l = len(m.Manifest)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
 	return n
 }
 
@@ -995,6 +1015,40 @@ func (m *Code) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Pinned = bool(v != 0)
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Manifest", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Manifest = append(m.Manifest[:0], dAtA[iNdEx:postIndex]...)
+			if m.Manifest == nil {
+				m.Manifest = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
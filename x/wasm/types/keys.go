@@ -33,6 +33,7 @@ var (
 	PinnedCodeIndexPrefix                          = []byte{0x07}
 	TXCounterPrefix                                = []byte{0x08}
 	ContractsByCreatorPrefix                       = []byte{0x09}
+	ManifestKeyPrefix                              = []byte{0x0a}
 
 	KeyLastCodeID     = append(SequenceKeyPrefix, []byte("lastCodeId")...)
 	KeyLastInstanceID = append(SequenceKeyPrefix, []byte("lastContractId")...)
@@ -44,6 +45,11 @@ func GetCodeKey(codeID uint64) []byte {
 	return append(CodeKeyPrefix, contractIDBz...)
 }
 
+// GetManifestKey returns the key for the wrap.info manifest of a wrapper code
+func GetManifestKey(codeID uint64) []byte {
+	return append(ManifestKeyPrefix, sdk.Uint64ToBigEndian(codeID)...)
+}
+
 // GetContractAddressKey returns the key for the WASM contract instance
 func GetContractAddressKey(addr sdk.AccAddress) []byte {
 	return append(ContractKeyPrefix, addr...)
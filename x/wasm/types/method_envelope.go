@@ -0,0 +1,64 @@
+package types
+
+import (
+	"encoding/json"
+	"regexp"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// methodEnvelopeKey is the single top level key of a method envelope
+const methodEnvelopeKey = "wasmos"
+
+// methodNamePattern matches the GraphQL names wrapper methods are declared with
+var methodNamePattern = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// ValidateMethod checks that a non empty method is a valid wrapper method name
+func ValidateMethod(method string) error {
+	if method != "" && !methodNamePattern.MatchString(method) {
+		return sdkerrors.Wrapf(ErrInvalid, "method name %q", method)
+	}
+	return nil
+}
+
+// MethodEnvelope wraps a contract message with the name of the wrapper method to invoke.
+// CosmWasm messages like the smart query or the dispatched execute have no method field, so the envelope is sent as their
+// json message instead: {"wasmos":{"method":"sayHello","msg":{}}}
+type MethodEnvelope struct {
+	Method string             `json:"method"`
+	Msg    RawContractMessage `json:"msg"`
+}
+
+// NewMethodEnvelope returns the json encoded envelope for the given method and message
+func NewMethodEnvelope(method string, msg RawContractMessage) (RawContractMessage, error) {
+	return json.Marshal(map[string]MethodEnvelope{
+		methodEnvelopeKey: {Method: method, Msg: msg},
+	})
+}
+
+// UnwrapMethodEnvelope returns the wrapped message and method when msg is a method envelope.
+// Any other message is returned as it is with an empty method.
+func UnwrapMethodEnvelope(msg RawContractMessage) (RawContractMessage, string, error) {
+	var document map[string]json.RawMessage
+	if err := json.Unmarshal(msg, &document); err != nil || len(document) != 1 {
+		return msg, "", nil
+	}
+	raw, ok := document[methodEnvelopeKey]
+	if !ok {
+		return msg, "", nil
+	}
+	var envelope MethodEnvelope
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, "", sdkerrors.Wrap(ErrInvalid, "method envelope")
+	}
+	if envelope.Method == "" {
+		return nil, "", sdkerrors.Wrap(ErrEmpty, "method")
+	}
+	if err := ValidateMethod(envelope.Method); err != nil {
+		return nil, "", err
+	}
+	if err := envelope.Msg.ValidateBasic(); err != nil {
+		return nil, "", sdkerrors.Wrap(err, "method envelope msg")
+	}
+	return envelope.Msg, envelope.Method, nil
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnwrapMethodEnvelope(t *testing.T) {
+	specs := map[string]struct {
+		src       RawContractMessage
+		expMsg    RawContractMessage
+		expMethod string
+		expErr    bool
+	}{
+		"envelope": {
+			src:       RawContractMessage(`{"wasmos":{"method":"sayHello","msg":{"foo":"bar"}}}`),
+			expMsg:    RawContractMessage(`{"foo":"bar"}`),
+			expMethod: "sayHello",
+		},
+		"plain message": {
+			src:    RawContractMessage(`{"foo":"bar"}`),
+			expMsg: RawContractMessage(`{"foo":"bar"}`),
+		},
+		"other top level keys": {
+			src:    RawContractMessage(`{"wasmos":{"method":"sayHello","msg":{}},"foo":"bar"}`),
+			expMsg: RawContractMessage(`{"wasmos":{"method":"sayHello","msg":{}},"foo":"bar"}`),
+		},
+		"no object": {
+			src:    RawContractMessage(`[]`),
+			expMsg: RawContractMessage(`[]`),
+		},
+		"empty method": {
+			src:    RawContractMessage(`{"wasmos":{"method":"","msg":{}}}`),
+			expErr: true,
+		},
+		"invalid method name": {
+			src:    RawContractMessage(`{"wasmos":{"method":"say hello","msg":{}}}`),
+			expErr: true,
+		},
+		"missing msg": {
+			src:    RawContractMessage(`{"wasmos":{"method":"sayHello"}}`),
+			expErr: true,
+		},
+		"malformed envelope": {
+			src:    RawContractMessage(`{"wasmos":"sayHello"}`),
+			expErr: true,
+		},
+	}
+	for name, spec := range specs {
+		t.Run(name, func(t *testing.T) {
+			msg, method, err := UnwrapMethodEnvelope(spec.src)
+			if spec.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, spec.expMsg, msg)
+			assert.Equal(t, spec.expMethod, method)
+		})
+	}
+}
+
+func TestNewMethodEnvelope(t *testing.T) {
+	envelope, err := NewMethodEnvelope("sayHello", RawContractMessage(`{"foo":"bar"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"wasmos":{"method":"sayHello","msg":{"foo":"bar"}}}`, string(envelope))
+
+	msg, method, err := UnwrapMethodEnvelope(envelope)
+	require.NoError(t, err)
+	assert.Equal(t, RawContractMessage(`{"foo":"bar"}`), msg)
+	assert.Equal(t, "sayHello", method)
+}
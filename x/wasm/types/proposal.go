@@ -20,6 +20,7 @@ const (
 	ProposalTypeMigrateContract                     ProposalType = "MigrateContract"
 	ProposalTypeSudoContract                        ProposalType = "SudoContract"
 	ProposalTypeExecuteContract                     ProposalType = "ExecuteContract"
+	ProposalTypeExecuteContractBatch                ProposalType = "ExecuteContractBatch"
 	ProposalTypeUpdateAdmin                         ProposalType = "UpdateAdmin"
 	ProposalTypeClearAdmin                          ProposalType = "ClearAdmin"
 	ProposalTypePinCodes                            ProposalType = "PinCodes"
@@ -39,6 +40,7 @@ var EnableAllProposals = []ProposalType{
 	ProposalTypeMigrateContract,
 	ProposalTypeSudoContract,
 	ProposalTypeExecuteContract,
+	ProposalTypeExecuteContractBatch,
 	ProposalTypeUpdateAdmin,
 	ProposalTypeClearAdmin,
 	ProposalTypePinCodes,
@@ -72,6 +74,7 @@ func init() { // register new content types with the sdk
 	govtypes.RegisterProposalType(string(ProposalTypeMigrateContract))
 	govtypes.RegisterProposalType(string(ProposalTypeSudoContract))
 	govtypes.RegisterProposalType(string(ProposalTypeExecuteContract))
+	govtypes.RegisterProposalType(string(ProposalTypeExecuteContractBatch))
 	govtypes.RegisterProposalType(string(ProposalTypeUpdateAdmin))
 	govtypes.RegisterProposalType(string(ProposalTypeClearAdmin))
 	govtypes.RegisterProposalType(string(ProposalTypePinCodes))
@@ -84,6 +87,7 @@ func init() { // register new content types with the sdk
 	govtypes.RegisterProposalTypeCodec(&MigrateContractProposal{}, "wasm/MigrateContractProposal")
 	govtypes.RegisterProposalTypeCodec(&SudoContractProposal{}, "wasm/SudoContractProposal")
 	govtypes.RegisterProposalTypeCodec(&ExecuteContractProposal{}, "wasm/ExecuteContractProposal")
+	govtypes.RegisterProposalTypeCodec(&ExecuteContractBatchProposal{}, "wasm/ExecuteContractBatchProposal")
 	govtypes.RegisterProposalTypeCodec(&UpdateAdminProposal{}, "wasm/UpdateAdminProposal")
 	govtypes.RegisterProposalTypeCodec(&ClearAdminProposal{}, "wasm/ClearAdminProposal")
 	govtypes.RegisterProposalTypeCodec(&PinCodesProposal{}, "wasm/PinCodesProposal")
@@ -103,7 +107,17 @@ func NewStoreCodeProposal(
 	builder string,
 	codeHash []byte,
 ) *StoreCodeProposal {
-	return &StoreCodeProposal{title, description, runAs, wasmBz, permission, unpinCode, source, builder, codeHash}
+	return &StoreCodeProposal{
+		Title:                 title,
+		Description:           description,
+		RunAs:                 runAs,
+		WASMByteCode:          wasmBz,
+		InstantiatePermission: permission,
+		UnpinCode:             unpinCode,
+		Source:                source,
+		Builder:               builder,
+		CodeHash:              codeHash,
+	}
 }
 
 // ProposalRoute returns the routing key of a parameter change proposal.
@@ -140,6 +154,10 @@ func (p StoreCodeProposal) ValidateBasic() error {
 	if err := ValidateVerificationInfo(p.Source, p.Builder, p.CodeHash); err != nil {
 		return sdkerrors.Wrapf(err, "code verification info")
 	}
+
+	if err := p.Runtime.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "runtime")
+	}
 	return nil
 }
 
@@ -468,6 +486,9 @@ func (p StoreAndInstantiateContractProposal) ValidateBasic() error {
 	if err := p.Msg.ValidateBasic(); err != nil {
 		return sdkerrors.Wrap(err, "payload msg")
 	}
+	if err := p.Runtime.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "runtime")
+	}
 	return nil
 }
 
@@ -736,6 +757,79 @@ func (p ExecuteContractProposal) MarshalYAML() (interface{}, error) {
 	}, nil
 }
 
+func NewExecuteContractBatchProposal(
+	title string,
+	description string,
+	runAs string,
+	calls []ContractCall,
+) *ExecuteContractBatchProposal {
+	return &ExecuteContractBatchProposal{title, description, runAs, calls}
+}
+
+// ProposalRoute returns the routing key of a parameter change proposal.
+func (p ExecuteContractBatchProposal) ProposalRoute() string { return RouterKey }
+
+// GetTitle returns the title of the proposal
+func (p *ExecuteContractBatchProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the human readable description of the proposal
+func (p ExecuteContractBatchProposal) GetDescription() string { return p.Description }
+
+// ProposalType returns the type
+func (p ExecuteContractBatchProposal) ProposalType() string {
+	return string(ProposalTypeExecuteContractBatch)
+}
+
+// ValidateBasic validates the proposal
+func (p ExecuteContractBatchProposal) ValidateBasic() error {
+	if err := validateProposalCommons(p.Title, p.Description); err != nil {
+		return err
+	}
+	if _, err := sdk.AccAddressFromBech32(p.RunAs); err != nil {
+		return sdkerrors.Wrap(err, "run as")
+	}
+	return validateContractCalls(p.Calls)
+}
+
+// String implements the Stringer interface.
+func (p ExecuteContractBatchProposal) String() string {
+	var calls strings.Builder
+	for _, c := range p.Calls {
+		fmt.Fprintf(&calls, "    - Contract: %s\n      Method:   %s\n      Msg:      %q\n      Funds:    %s\n", c.Contract, c.Method, c.Msg, c.Funds)
+	}
+	return fmt.Sprintf(`Execute Contract Batch Proposal:
+  Title:       %s
+  Description: %s
+  Run as:      %s
+  Calls:
+%s`, p.Title, p.Description, p.RunAs, calls.String())
+}
+
+// MarshalYAML pretty prints the calls with their json messages
+func (p ExecuteContractBatchProposal) MarshalYAML() (interface{}, error) {
+	type call struct {
+		Contract string    `yaml:"contract"`
+		Method   string    `yaml:"method"`
+		Msg      string    `yaml:"msg"`
+		Funds    sdk.Coins `yaml:"funds"`
+	}
+	calls := make([]call, len(p.Calls))
+	for i, c := range p.Calls {
+		calls[i] = call{Contract: c.Contract, Method: c.Method, Msg: string(c.Msg), Funds: c.Funds}
+	}
+	return struct {
+		Title       string `yaml:"title"`
+		Description string `yaml:"description"`
+		RunAs       string `yaml:"run_as"`
+		Calls       []call `yaml:"calls"`
+	}{
+		Title:       p.Title,
+		Description: p.Description,
+		RunAs:       p.RunAs,
+		Calls:       calls,
+	}, nil
+}
+
 func NewUpdateAdminProposal(
 	title string,
 	description string,
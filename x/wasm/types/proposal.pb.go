@@ -49,6 +49,9 @@ type StoreCodeProposal struct {
 	// CodeHash is the SHA256 sum of the code outputted by builder, used for smart
 	// contract verification
 	CodeHash []byte `protobuf:"bytes,11,opt,name=code_hash,json=codeHash,proto3" json:"code_hash,omitempty"`
+	// Runtime engine that executes the code, detected from the wasm exports when
+	// unspecified
+	Runtime Runtime `protobuf:"varint,12,opt,name=runtime,proto3,enum=cosmwasm.wasm.v1.Runtime" json:"runtime,omitempty"`
 }
 
 func (m *StoreCodeProposal) Reset()      { *m = StoreCodeProposal{} }
@@ -335,6 +338,51 @@ func (m *ExecuteContractProposal) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_ExecuteContractProposal proto.InternalMessageInfo
 
+// ExecuteContractBatchProposal gov proposal content type to run several
+// contract executions that succeed or fail together.
+type ExecuteContractBatchProposal struct {
+	// Title is a short summary
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	// Description is a human readable text
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// RunAs is the address that is passed to the contracts' environment as sender
+	RunAs string `protobuf:"bytes,3,opt,name=run_as,json=runAs,proto3" json:"run_as,omitempty"`
+	// Calls are the contract executions in the order they are run
+	Calls []ContractCall `protobuf:"bytes,4,rep,name=calls,proto3" json:"calls"`
+}
+
+func (m *ExecuteContractBatchProposal) Reset()      { *m = ExecuteContractBatchProposal{} }
+func (*ExecuteContractBatchProposal) ProtoMessage() {}
+func (*ExecuteContractBatchProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be6422d717c730cb, []int{6}
+}
+func (m *ExecuteContractBatchProposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExecuteContractBatchProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExecuteContractBatchProposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExecuteContractBatchProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecuteContractBatchProposal.Merge(m, src)
+}
+func (m *ExecuteContractBatchProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExecuteContractBatchProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecuteContractBatchProposal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecuteContractBatchProposal proto.InternalMessageInfo
+
 // UpdateAdminProposal gov proposal content type to set an admin for a contract.
 type UpdateAdminProposal struct {
 	// Title is a short summary
@@ -350,7 +398,7 @@ type UpdateAdminProposal struct {
 func (m *UpdateAdminProposal) Reset()      { *m = UpdateAdminProposal{} }
 func (*UpdateAdminProposal) ProtoMessage() {}
 func (*UpdateAdminProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_be6422d717c730cb, []int{6}
+	return fileDescriptor_be6422d717c730cb, []int{7}
 }
 func (m *UpdateAdminProposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -393,7 +441,7 @@ type ClearAdminProposal struct {
 func (m *ClearAdminProposal) Reset()      { *m = ClearAdminProposal{} }
 func (*ClearAdminProposal) ProtoMessage() {}
 func (*ClearAdminProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_be6422d717c730cb, []int{7}
+	return fileDescriptor_be6422d717c730cb, []int{8}
 }
 func (m *ClearAdminProposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -436,7 +484,7 @@ type PinCodesProposal struct {
 func (m *PinCodesProposal) Reset()      { *m = PinCodesProposal{} }
 func (*PinCodesProposal) ProtoMessage() {}
 func (*PinCodesProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_be6422d717c730cb, []int{8}
+	return fileDescriptor_be6422d717c730cb, []int{9}
 }
 func (m *PinCodesProposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -479,7 +527,7 @@ type UnpinCodesProposal struct {
 func (m *UnpinCodesProposal) Reset()      { *m = UnpinCodesProposal{} }
 func (*UnpinCodesProposal) ProtoMessage() {}
 func (*UnpinCodesProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_be6422d717c730cb, []int{9}
+	return fileDescriptor_be6422d717c730cb, []int{10}
 }
 func (m *UnpinCodesProposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -520,7 +568,7 @@ type AccessConfigUpdate struct {
 func (m *AccessConfigUpdate) Reset()      { *m = AccessConfigUpdate{} }
 func (*AccessConfigUpdate) ProtoMessage() {}
 func (*AccessConfigUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_be6422d717c730cb, []int{10}
+	return fileDescriptor_be6422d717c730cb, []int{11}
 }
 func (m *AccessConfigUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -564,7 +612,7 @@ type UpdateInstantiateConfigProposal struct {
 func (m *UpdateInstantiateConfigProposal) Reset()      { *m = UpdateInstantiateConfigProposal{} }
 func (*UpdateInstantiateConfigProposal) ProtoMessage() {}
 func (*UpdateInstantiateConfigProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_be6422d717c730cb, []int{11}
+	return fileDescriptor_be6422d717c730cb, []int{12}
 }
 func (m *UpdateInstantiateConfigProposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -624,12 +672,15 @@ type StoreAndInstantiateContractProposal struct {
 	// CodeHash is the SHA256 sum of the code outputted by builder, used for smart
 	// contract verification
 	CodeHash []byte `protobuf:"bytes,13,opt,name=code_hash,json=codeHash,proto3" json:"code_hash,omitempty"`
+	// Runtime engine that executes the code, detected from the wasm exports when
+	// unspecified
+	Runtime Runtime `protobuf:"varint,14,opt,name=runtime,proto3,enum=cosmwasm.wasm.v1.Runtime" json:"runtime,omitempty"`
 }
 
 func (m *StoreAndInstantiateContractProposal) Reset()      { *m = StoreAndInstantiateContractProposal{} }
 func (*StoreAndInstantiateContractProposal) ProtoMessage() {}
 func (*StoreAndInstantiateContractProposal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_be6422d717c730cb, []int{12}
+	return fileDescriptor_be6422d717c730cb, []int{13}
 }
 func (m *StoreAndInstantiateContractProposal) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -665,6 +716,7 @@ func init() {
 	proto.RegisterType((*MigrateContractProposal)(nil), "cosmwasm.wasm.v1.MigrateContractProposal")
 	proto.RegisterType((*SudoContractProposal)(nil), "cosmwasm.wasm.v1.SudoContractProposal")
 	proto.RegisterType((*ExecuteContractProposal)(nil), "cosmwasm.wasm.v1.ExecuteContractProposal")
+	proto.RegisterType((*ExecuteContractBatchProposal)(nil), "cosmwasm.wasm.v1.ExecuteContractBatchProposal")
 	proto.RegisterType((*UpdateAdminProposal)(nil), "cosmwasm.wasm.v1.UpdateAdminProposal")
 	proto.RegisterType((*ClearAdminProposal)(nil), "cosmwasm.wasm.v1.ClearAdminProposal")
 	proto.RegisterType((*PinCodesProposal)(nil), "cosmwasm.wasm.v1.PinCodesProposal")
@@ -677,72 +729,76 @@ func init() {
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/proposal.proto", fileDescriptor_be6422d717c730cb) }
 
 var fileDescriptor_be6422d717c730cb = []byte{
-	// 1038 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x57, 0x4f, 0x6f, 0x1b, 0x45,
-	0x14, 0xf7, 0xc6, 0xf6, 0x7a, 0xfd, 0x6c, 0xc0, 0x4c, 0xf3, 0x67, 0x9b, 0x96, 0x5d, 0xcb, 0x45,
-	0x95, 0x2f, 0xb5, 0x49, 0x90, 0x10, 0xf4, 0x96, 0x0d, 0x48, 0xa4, 0x22, 0x52, 0xb4, 0x51, 0x54,
-	0x09, 0x24, 0x56, 0xe3, 0xdd, 0xf1, 0x7a, 0x85, 0xbd, 0x63, 0xed, 0xec, 0xe6, 0xcf, 0x99, 0x0b,
-	0x12, 0x17, 0x4e, 0x88, 0x8f, 0x80, 0x38, 0xf7, 0xc8, 0x07, 0x08, 0xbd, 0x50, 0x6e, 0x3d, 0x20,
-	0x43, 0x9d, 0x1b, 0xc7, 0x1c, 0x39, 0xa1, 0x9d, 0x19, 0x1b, 0x27, 0x4d, 0xec, 0x86, 0xc6, 0x3d,
-	0xa0, 0x5e, 0xec, 0x7d, 0xf3, 0xde, 0xcc, 0xfc, 0xde, 0xef, 0xcd, 0xbc, 0x79, 0x0f, 0x4c, 0x97,
-	0xb2, 0xde, 0x01, 0x66, 0xbd, 0x26, 0xff, 0xd9, 0x5f, 0x6b, 0xf6, 0x23, 0xda, 0xa7, 0x0c, 0x77,
-	0x1b, 0xfd, 0x88, 0xc6, 0x14, 0x55, 0x46, 0x06, 0x0d, 0xfe, 0xb3, 0xbf, 0xb6, 0xba, 0xe8, 0x53,
-	0x9f, 0x72, 0x65, 0x33, 0xfd, 0x12, 0x76, 0xab, 0x37, 0x53, 0x3b, 0xca, 0x1c, 0xa1, 0x10, 0x82,
-	0x54, 0x19, 0x42, 0x6a, 0xb6, 0x30, 0x23, 0xcd, 0xfd, 0xb5, 0x16, 0x89, 0xf1, 0x5a, 0xd3, 0xa5,
-	0x41, 0x28, 0xf5, 0xb7, 0x9f, 0xc3, 0x10, 0x1f, 0xf5, 0x89, 0x9c, 0x5d, 0xfb, 0x36, 0x0b, 0x6f,
-	0xef, 0xc6, 0x34, 0x22, 0x9b, 0xd4, 0x23, 0x3b, 0x12, 0x1c, 0x5a, 0x84, 0x7c, 0x1c, 0xc4, 0x5d,
-	0xa2, 0x2b, 0x55, 0xa5, 0x5e, 0xb4, 0x85, 0x80, 0xaa, 0x50, 0xf2, 0x08, 0x73, 0xa3, 0xa0, 0x1f,
-	0x07, 0x34, 0xd4, 0x17, 0xb8, 0x6e, 0x72, 0x08, 0x2d, 0x81, 0x1a, 0x25, 0xa1, 0x83, 0x99, 0x9e,
-	0x15, 0x13, 0xa3, 0x24, 0xdc, 0x60, 0xe8, 0x03, 0x78, 0x33, 0xdd, 0xdb, 0x69, 0x1d, 0xc5, 0xc4,
-	0x71, 0xa9, 0x47, 0xf4, 0x5c, 0x55, 0xa9, 0x97, 0xad, 0xca, 0x70, 0x60, 0x96, 0x1f, 0x6e, 0xec,
-	0x6e, 0x5b, 0x47, 0x31, 0x07, 0x60, 0x97, 0x53, 0xbb, 0x91, 0x84, 0xf6, 0x60, 0x39, 0x08, 0x59,
-	0x8c, 0xc3, 0x38, 0xc0, 0x31, 0x71, 0xfa, 0x24, 0xea, 0x05, 0x8c, 0xa5, 0x7b, 0x17, 0xaa, 0x4a,
-	0xbd, 0xb4, 0x6e, 0x34, 0xce, 0xd3, 0xd7, 0xd8, 0x70, 0x5d, 0xc2, 0xd8, 0x26, 0x0d, 0xdb, 0x81,
-	0x6f, 0x2f, 0x4d, 0xcc, 0xde, 0x19, 0x4f, 0x46, 0xef, 0x00, 0x24, 0x61, 0x3f, 0x08, 0x05, 0x14,
-	0xad, 0xaa, 0xd4, 0x35, 0xbb, 0xc8, 0x47, 0xf8, 0xae, 0xcb, 0xa0, 0x32, 0x9a, 0x44, 0x2e, 0xd1,
-	0x8b, 0xdc, 0x09, 0x29, 0x21, 0x1d, 0x0a, 0xad, 0x24, 0xe8, 0x7a, 0x24, 0xd2, 0x81, 0x2b, 0x46,
-	0x22, 0xba, 0x05, 0xc5, 0x74, 0x29, 0xa7, 0x83, 0x59, 0x47, 0x2f, 0xa5, 0xae, 0xd9, 0x5a, 0x3a,
-	0xf0, 0x29, 0x66, 0x9d, 0xfb, 0xc6, 0xe3, 0x47, 0xf7, 0x56, 0x65, 0xc4, 0x7c, 0xba, 0xdf, 0x90,
-	0x21, 0x6a, 0x6c, 0xd2, 0x30, 0x26, 0x61, 0xfc, 0x20, 0xa7, 0xe5, 0x2b, 0xea, 0x83, 0x9c, 0xa6,
-	0x56, 0x0a, 0xb5, 0xbf, 0x16, 0xe0, 0xd6, 0xd6, 0xbf, 0x98, 0x53, 0x93, 0x08, 0xbb, 0xf1, 0xbc,
-	0xe2, 0xb2, 0x08, 0x79, 0xec, 0xf5, 0x82, 0x90, 0x87, 0xa3, 0x68, 0x0b, 0x01, 0xdd, 0x81, 0x02,
-	0xf7, 0x26, 0xf0, 0xf4, 0x7c, 0x55, 0xa9, 0xe7, 0x2c, 0x18, 0x0e, 0x4c, 0x35, 0xa5, 0x66, 0xeb,
-	0x63, 0x5b, 0x4d, 0x55, 0x5b, 0x5e, 0x3a, 0xb5, 0x8b, 0x5b, 0xa4, 0xab, 0xab, 0x62, 0x2a, 0x17,
-	0x50, 0x1d, 0xb2, 0x3d, 0xe6, 0xf3, 0xe8, 0x94, 0xad, 0xe5, 0xbf, 0x07, 0x26, 0xb2, 0xf1, 0xc1,
-	0xc8, 0x8b, 0x6d, 0xc2, 0x18, 0xf6, 0x89, 0x9d, 0x9a, 0x20, 0x0c, 0xf9, 0x76, 0x12, 0x7a, 0x4c,
-	0xd7, 0xaa, 0xd9, 0x7a, 0x69, 0xfd, 0x66, 0x43, 0x32, 0x94, 0x9e, 0xe2, 0x09, 0x8a, 0x82, 0xd0,
-	0x7a, 0xef, 0x78, 0x60, 0x66, 0x7e, 0xfa, 0xc3, 0xac, 0xfb, 0x41, 0xdc, 0x49, 0x5a, 0x0d, 0x97,
-	0xf6, 0xe4, 0x05, 0x90, 0x7f, 0xf7, 0x98, 0xf7, 0x95, 0x3c, 0xd3, 0xe9, 0x04, 0x66, 0x8b, 0x95,
-	0x67, 0x11, 0x5f, 0xfb, 0x21, 0x0b, 0xb7, 0x2f, 0x20, 0x7b, 0xfd, 0x35, 0xdb, 0xff, 0x81, 0x6d,
-	0x84, 0x20, 0xc7, 0x70, 0x37, 0xe6, 0x77, 0xa6, 0x6c, 0xf3, 0x6f, 0xb4, 0x02, 0x85, 0x76, 0x70,
-	0xe8, 0xa4, 0x20, 0x81, 0xdf, 0x32, 0xb5, 0x1d, 0x1c, 0x6e, 0x33, 0x7f, 0x66, 0x68, 0x7e, 0x57,
-	0x60, 0x65, 0x3b, 0xf0, 0xa3, 0xeb, 0xbc, 0x03, 0xab, 0xa0, 0xb9, 0x72, 0x2d, 0x19, 0x81, 0xb1,
-	0xfc, 0x62, 0x41, 0x90, 0x74, 0xab, 0x33, 0xe9, 0x9e, 0xe9, 0xde, 0x23, 0x05, 0x16, 0x77, 0x13,
-	0x8f, 0xce, 0xc5, 0xb7, 0xec, 0x39, 0xdf, 0x24, 0xec, 0xdc, 0xcb, 0xc3, 0xfe, 0x65, 0x01, 0x56,
-	0x3e, 0x39, 0x24, 0x6e, 0x32, 0xff, 0xcc, 0x34, 0x2d, 0x58, 0xd2, 0xa1, 0xfc, 0x15, 0x8e, 0xbd,
-	0x3a, 0xb7, 0x63, 0xbf, 0x0c, 0x6a, 0x8f, 0xc4, 0x1d, 0xea, 0xf1, 0x6b, 0x58, 0xb4, 0xa5, 0x34,
-	0x93, 0xcb, 0x9f, 0x15, 0xb8, 0xb1, 0xd7, 0xf7, 0x70, 0x4c, 0x36, 0xd2, 0x34, 0xf0, 0xd2, 0x3c,
-	0xae, 0x41, 0x31, 0x24, 0x07, 0x8e, 0x48, 0x30, 0x9c, 0x4a, 0x6b, 0xf1, 0x74, 0x60, 0x56, 0x8e,
-	0x70, 0xaf, 0x7b, 0xbf, 0x36, 0x56, 0xd5, 0x6c, 0x2d, 0x24, 0x07, 0x7c, 0xcb, 0x69, 0x1c, 0xcf,
-	0x84, 0xff, 0x8d, 0x02, 0x68, 0xb3, 0x4b, 0x70, 0x74, 0x3d, 0xe8, 0xa7, 0x9c, 0xdf, 0x99, 0x50,
-	0x7e, 0x55, 0xa0, 0xb2, 0x23, 0x9e, 0x6e, 0x36, 0x06, 0x72, 0xf7, 0x0c, 0x10, 0xab, 0x72, 0x3a,
-	0x30, 0xcb, 0x82, 0x0a, 0x3e, 0x5c, 0x1b, 0x41, 0xfb, 0xf0, 0x02, 0x68, 0xd6, 0xf2, 0xe9, 0xc0,
-	0x44, 0xc2, 0x7a, 0x42, 0x59, 0x3b, 0x0b, 0xf9, 0x23, 0xd0, 0x64, 0xca, 0x48, 0x8f, 0x6e, 0xb6,
-	0x9e, 0xb3, 0x8c, 0xe1, 0xc0, 0x2c, 0x88, 0x9c, 0xc1, 0x4e, 0x07, 0xe6, 0x5b, 0x62, 0x85, 0x91,
-	0x51, 0xcd, 0x2e, 0x88, 0x3c, 0x32, 0xfb, 0x61, 0xfa, 0x4d, 0x01, 0xb4, 0x37, 0x2a, 0x47, 0xfe,
-	0x27, 0x3e, 0x7d, 0xaf, 0x00, 0x9a, 0xac, 0xcd, 0xc4, 0xd9, 0x9f, 0x4c, 0xbc, 0xca, 0xa5, 0x89,
-	0xf7, 0x8b, 0x4b, 0xcb, 0xc0, 0x85, 0x17, 0x29, 0x03, 0xad, 0x5c, 0x7a, 0xb9, 0x2f, 0x29, 0x06,
-	0x6b, 0x5f, 0x2f, 0x80, 0x29, 0xc0, 0x9c, 0xad, 0x05, 0xda, 0x81, 0xff, 0x0a, 0x99, 0xff, 0x12,
-	0x96, 0x30, 0x87, 0xec, 0xb8, 0x7c, 0x6b, 0x27, 0xe1, 0x90, 0x44, 0x18, 0x4a, 0xeb, 0xef, 0x4e,
-	0xf7, 0x50, 0xe0, 0x97, 0x7e, 0xde, 0xc0, 0xcf, 0x69, 0x66, 0x87, 0xe7, 0x71, 0x0e, 0xee, 0xf0,
-	0x36, 0x60, 0x23, 0xf4, 0x5e, 0x61, 0x01, 0x7a, 0xfd, 0x8d, 0x41, 0xfe, 0xfa, 0x1a, 0x03, 0xf5,
-	0x7c, 0x63, 0x30, 0x2e, 0xe0, 0x0a, 0x93, 0x05, 0xdc, 0xb8, 0x36, 0xd3, 0x2e, 0xa8, 0xcd, 0x8a,
-	0x57, 0x78, 0xa4, 0x60, 0x9e, 0x8f, 0x94, 0xec, 0x68, 0x4a, 0x97, 0x75, 0x34, 0xe5, 0x29, 0x1d,
-	0xcd, 0x1b, 0x57, 0xeb, 0x68, 0xac, 0xcf, 0x8e, 0x9f, 0x19, 0x99, 0xa7, 0xcf, 0x8c, 0xcc, 0x8f,
-	0x43, 0x43, 0x39, 0x1e, 0x1a, 0xca, 0x93, 0xa1, 0xa1, 0xfc, 0x39, 0x34, 0x94, 0xef, 0x4e, 0x8c,
-	0xcc, 0x93, 0x13, 0x23, 0xf3, 0xf4, 0xc4, 0xc8, 0x7c, 0x7e, 0x77, 0xc2, 0x8b, 0x4d, 0xca, 0x7a,
-	0x0f, 0x47, 0x2d, 0xaa, 0xd7, 0x3c, 0x14, 0xad, 0x2a, 0xf7, 0xa4, 0xa5, 0xf2, 0x46, 0xf5, 0xfd,
-	0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xe5, 0x18, 0x9f, 0x71, 0x4c, 0x0f, 0x00, 0x00,
+	// 1095 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x57, 0xbf, 0x6f, 0x23, 0xc5,
+	0x17, 0xf7, 0xc6, 0xf6, 0xda, 0x7e, 0xf6, 0xf7, 0xbe, 0x66, 0x2e, 0x3f, 0xf6, 0x72, 0x61, 0xd7,
+	0xf2, 0xa1, 0x93, 0x9b, 0xb3, 0x49, 0x4e, 0x42, 0x90, 0x2e, 0x6b, 0x90, 0xc8, 0x89, 0x48, 0xd1,
+	0x46, 0xd1, 0x49, 0x20, 0x61, 0x8d, 0x77, 0x27, 0xf6, 0x8a, 0xf5, 0x8e, 0xb5, 0x33, 0x9b, 0x1f,
+	0x35, 0x0d, 0x25, 0x15, 0xe2, 0x4f, 0x40, 0xd4, 0x57, 0x52, 0x50, 0x06, 0x1a, 0x8e, 0xee, 0x0a,
+	0x64, 0x38, 0xa7, 0xa3, 0x4c, 0x49, 0x03, 0xda, 0x99, 0xb1, 0x71, 0x7e, 0xfa, 0x8e, 0x8b, 0xaf,
+	0x40, 0x34, 0xf6, 0xbe, 0x7d, 0x6f, 0x76, 0x3e, 0x9f, 0xcf, 0x9b, 0x1f, 0xef, 0x81, 0xe5, 0x52,
+	0xd6, 0x3b, 0xc0, 0xac, 0xd7, 0x10, 0x3f, 0xfb, 0xab, 0x8d, 0x7e, 0x44, 0xfb, 0x94, 0xe1, 0xa0,
+	0xde, 0x8f, 0x28, 0xa7, 0xa8, 0x3c, 0x0a, 0xa8, 0x8b, 0x9f, 0xfd, 0xd5, 0xe5, 0xf9, 0x0e, 0xed,
+	0x50, 0xe1, 0x6c, 0x24, 0x4f, 0x32, 0x6e, 0xf9, 0x4e, 0x12, 0x47, 0x59, 0x4b, 0x3a, 0xa4, 0xa1,
+	0x5c, 0xa6, 0xb4, 0x1a, 0x6d, 0xcc, 0x48, 0x63, 0x7f, 0xb5, 0x4d, 0x38, 0x5e, 0x6d, 0xb8, 0xd4,
+	0x0f, 0x95, 0x7f, 0xe5, 0x02, 0x06, 0x7e, 0xd4, 0x27, 0x6a, 0x74, 0xf5, 0xfb, 0x34, 0xbc, 0xb1,
+	0xc3, 0x69, 0x44, 0x9a, 0xd4, 0x23, 0xdb, 0x0a, 0x1c, 0x9a, 0x87, 0x2c, 0xf7, 0x79, 0x40, 0x0c,
+	0xad, 0xa2, 0xd5, 0x0a, 0x8e, 0x34, 0x50, 0x05, 0x8a, 0x1e, 0x61, 0x6e, 0xe4, 0xf7, 0xb9, 0x4f,
+	0x43, 0x63, 0x4e, 0xf8, 0x26, 0x5f, 0xa1, 0x05, 0xd0, 0xa3, 0x38, 0x6c, 0x61, 0x66, 0xa4, 0xe5,
+	0xc0, 0x28, 0x0e, 0x37, 0x18, 0x7a, 0x07, 0x6e, 0x25, 0x73, 0xb7, 0xda, 0x47, 0x9c, 0xb4, 0x5c,
+	0xea, 0x11, 0x23, 0x53, 0xd1, 0x6a, 0x25, 0xbb, 0x3c, 0x1c, 0x58, 0xa5, 0xc7, 0x1b, 0x3b, 0x5b,
+	0xf6, 0x11, 0x17, 0x00, 0x9c, 0x52, 0x12, 0x37, 0xb2, 0xd0, 0x2e, 0x2c, 0xfa, 0x21, 0xe3, 0x38,
+	0xe4, 0x3e, 0xe6, 0xa4, 0xd5, 0x27, 0x51, 0xcf, 0x67, 0x2c, 0x99, 0x3b, 0x57, 0xd1, 0x6a, 0xc5,
+	0x35, 0xb3, 0x7e, 0x5e, 0xbe, 0xfa, 0x86, 0xeb, 0x12, 0xc6, 0x9a, 0x34, 0xdc, 0xf3, 0x3b, 0xce,
+	0xc2, 0xc4, 0xe8, 0xed, 0xf1, 0x60, 0xf4, 0x26, 0x40, 0x1c, 0xf6, 0xfd, 0x50, 0x42, 0xc9, 0x57,
+	0xb4, 0x5a, 0xde, 0x29, 0x88, 0x37, 0x62, 0xd6, 0x45, 0xd0, 0x19, 0x8d, 0x23, 0x97, 0x18, 0x05,
+	0x41, 0x42, 0x59, 0xc8, 0x80, 0x5c, 0x3b, 0xf6, 0x03, 0x8f, 0x44, 0x06, 0x08, 0xc7, 0xc8, 0x44,
+	0x77, 0xa1, 0x90, 0x7c, 0xaa, 0xd5, 0xc5, 0xac, 0x6b, 0x14, 0x13, 0x6a, 0x4e, 0x3e, 0x79, 0xf1,
+	0x21, 0x66, 0x5d, 0xf4, 0x10, 0x72, 0x51, 0x1c, 0x72, 0xbf, 0x47, 0x8c, 0x52, 0x45, 0xab, 0xdd,
+	0x5a, 0xbb, 0x73, 0x11, 0xb5, 0x23, 0x03, 0x9c, 0x51, 0xe4, 0xba, 0xf9, 0xe3, 0x93, 0x07, 0xcb,
+	0x2a, 0xcd, 0x1d, 0xba, 0x5f, 0x57, 0x79, 0xad, 0x37, 0x69, 0xc8, 0x49, 0xc8, 0x1f, 0x65, 0xf2,
+	0xd9, 0xb2, 0xfe, 0x28, 0x93, 0xd7, 0xcb, 0xb9, 0xea, 0xef, 0x73, 0x70, 0x77, 0xf3, 0x6f, 0xa2,
+	0x49, 0x48, 0x84, 0x5d, 0x3e, 0xab, 0x64, 0xce, 0x43, 0x16, 0x7b, 0x3d, 0x3f, 0x14, 0x39, 0x2c,
+	0x38, 0xd2, 0x40, 0xf7, 0x20, 0x27, 0x24, 0xf0, 0x3d, 0x23, 0x5b, 0xd1, 0x6a, 0x19, 0x1b, 0x86,
+	0x03, 0x4b, 0x4f, 0xf4, 0xdc, 0x7c, 0xdf, 0xd1, 0x13, 0xd7, 0xa6, 0x97, 0x0c, 0x0d, 0x70, 0x9b,
+	0x04, 0x86, 0x2e, 0x87, 0x0a, 0x03, 0xd5, 0x20, 0xdd, 0x63, 0x1d, 0x91, 0xd2, 0x92, 0xbd, 0xf8,
+	0xc7, 0xc0, 0x42, 0x0e, 0x3e, 0x18, 0xb1, 0xd8, 0x22, 0x8c, 0xe1, 0x0e, 0x71, 0x92, 0x10, 0x84,
+	0x21, 0xbb, 0x17, 0x87, 0x1e, 0x33, 0xf2, 0x95, 0x74, 0xad, 0xa8, 0x84, 0xa4, 0xac, 0x9e, 0x2c,
+	0xfd, 0x09, 0x89, 0xfc, 0xd0, 0x7e, 0xfb, 0x78, 0x60, 0xa5, 0xbe, 0xfd, 0xd5, 0xaa, 0x75, 0x7c,
+	0xde, 0x8d, 0xdb, 0x75, 0x97, 0xf6, 0xd4, 0xae, 0x51, 0x7f, 0x0f, 0x98, 0xf7, 0x99, 0xda, 0x08,
+	0xc9, 0x00, 0xe6, 0xc8, 0x2f, 0x4f, 0x13, 0xbe, 0xfa, 0x75, 0x1a, 0x56, 0x2e, 0x11, 0x7b, 0xed,
+	0x3f, 0xb5, 0xff, 0x81, 0xda, 0x08, 0x41, 0x86, 0xe1, 0x80, 0x8b, 0x8d, 0x56, 0x72, 0xc4, 0x33,
+	0x5a, 0x82, 0xdc, 0x9e, 0x7f, 0xd8, 0x4a, 0x40, 0x82, 0xd8, 0x9a, 0xfa, 0x9e, 0x7f, 0xb8, 0xc5,
+	0x3a, 0x53, 0x53, 0xf3, 0x8b, 0x06, 0x4b, 0x5b, 0x7e, 0x27, 0xba, 0xc9, 0x3d, 0xb0, 0x0c, 0x79,
+	0x57, 0x7d, 0x4b, 0x65, 0x60, 0x6c, 0xbf, 0x58, 0x12, 0x94, 0xdc, 0xfa, 0x54, 0xb9, 0xa7, 0xd2,
+	0x7b, 0xa2, 0xc1, 0xfc, 0x4e, 0xec, 0xd1, 0x99, 0x70, 0x4b, 0x9f, 0xe3, 0xa6, 0x60, 0x67, 0x5e,
+	0x1d, 0xf6, 0x0f, 0x73, 0xb0, 0xf4, 0xc1, 0x21, 0x71, 0xe3, 0xd9, 0x9f, 0x4c, 0xd7, 0x25, 0x4b,
+	0x11, 0xca, 0xbe, 0xc4, 0xb2, 0xd7, 0x67, 0xb6, 0xec, 0x17, 0x41, 0xef, 0x11, 0xde, 0xa5, 0x9e,
+	0xd8, 0x86, 0x05, 0x47, 0x59, 0xd3, 0xb5, 0xd4, 0x60, 0xe5, 0x9c, 0x96, 0x36, 0xe6, 0x6e, 0x77,
+	0x56, 0x82, 0xae, 0x43, 0xd6, 0xc5, 0x41, 0xc0, 0x8c, 0x4c, 0x25, 0x7d, 0xf9, 0x75, 0x3b, 0x82,
+	0xd1, 0xc4, 0x41, 0x60, 0x67, 0x12, 0x3d, 0x1c, 0x39, 0x64, 0x2a, 0x97, 0xef, 0x34, 0xb8, 0xbd,
+	0xdb, 0xf7, 0x30, 0x27, 0x1b, 0xc9, 0x91, 0xf6, 0xca, 0x14, 0x56, 0xa1, 0x10, 0x92, 0x83, 0x96,
+	0x3c, 0x2c, 0x05, 0x0b, 0x7b, 0xfe, 0x74, 0x60, 0x95, 0x8f, 0x70, 0x2f, 0x58, 0xaf, 0x8e, 0x5d,
+	0x55, 0x27, 0x1f, 0x92, 0x03, 0x31, 0xe5, 0x75, 0xeb, 0x65, 0x2a, 0xfc, 0x2f, 0x34, 0x40, 0xcd,
+	0x80, 0xe0, 0xe8, 0x66, 0xd0, 0x5f, 0xb3, 0x17, 0xa7, 0x42, 0xf9, 0x49, 0x83, 0xf2, 0xb6, 0xac,
+	0x5d, 0xd8, 0x18, 0xc8, 0xfd, 0x33, 0x40, 0xec, 0xf2, 0xe9, 0xc0, 0x2a, 0x49, 0x29, 0xc4, 0xeb,
+	0xea, 0x08, 0xda, 0xbb, 0x97, 0x40, 0xb3, 0x17, 0x4f, 0x07, 0x16, 0x92, 0xd1, 0x13, 0xce, 0xea,
+	0x59, 0xc8, 0xef, 0x41, 0x5e, 0x1d, 0x7f, 0xc9, 0xaa, 0x49, 0xd7, 0x32, 0xb6, 0x39, 0x1c, 0x58,
+	0x39, 0x79, 0xfe, 0xb1, 0xd3, 0x81, 0xf5, 0x7f, 0xf9, 0x85, 0x51, 0x50, 0xd5, 0xc9, 0xc9, 0x33,
+	0x71, 0xfa, 0xda, 0xf8, 0x59, 0x03, 0xb4, 0x1b, 0xf6, 0xff, 0x55, 0x9c, 0xbe, 0xd2, 0x00, 0x4d,
+	0x16, 0xa7, 0x72, 0xed, 0x4f, 0x5e, 0x22, 0xda, 0x95, 0x97, 0xc8, 0x27, 0x57, 0xd6, 0xc1, 0x73,
+	0x2f, 0x52, 0x07, 0xab, 0x8d, 0x79, 0x79, 0x35, 0x5c, 0xfd, 0x7c, 0x0e, 0x2c, 0x09, 0xe6, 0x6c,
+	0x5d, 0xb3, 0xe7, 0x77, 0x5e, 0xa3, 0xf2, 0x9f, 0xc2, 0x02, 0x16, 0x90, 0x5b, 0xae, 0x98, 0xba,
+	0x15, 0x0b, 0x48, 0x32, 0x0d, 0xc5, 0xb5, 0xb7, 0xae, 0x67, 0x28, 0xf1, 0x2b, 0x9e, 0xb7, 0xf1,
+	0x05, 0xcf, 0xf4, 0xf4, 0xfc, 0x99, 0x81, 0x7b, 0xa2, 0x0f, 0xda, 0x08, 0xbd, 0xd7, 0x58, 0x4c,
+	0xdf, 0x7c, 0x67, 0x94, 0xbd, 0xb9, 0xce, 0x48, 0x3f, 0xdf, 0x19, 0x8d, 0x8b, 0xd1, 0xdc, 0x64,
+	0x31, 0x3a, 0xae, 0x33, 0xf3, 0x97, 0xd4, 0x99, 0x85, 0x97, 0xb8, 0x70, 0x61, 0x96, 0x17, 0xae,
+	0x6a, 0xe9, 0x8a, 0x57, 0xb5, 0x74, 0xa5, 0x6b, 0x5a, 0xba, 0xff, 0x5d, 0xdd, 0xd2, 0xdd, 0xba,
+	0xa9, 0x96, 0xce, 0xfe, 0xe8, 0xf8, 0xb9, 0x99, 0x7a, 0xf6, 0xdc, 0x4c, 0x7d, 0x33, 0x34, 0xb5,
+	0xe3, 0xa1, 0xa9, 0x3d, 0x1d, 0x9a, 0xda, 0x6f, 0x43, 0x53, 0xfb, 0xf2, 0xc4, 0x4c, 0x3d, 0x3d,
+	0x31, 0x53, 0xcf, 0x4e, 0xcc, 0xd4, 0xc7, 0xf7, 0x27, 0xa8, 0x37, 0x29, 0xeb, 0x3d, 0x1e, 0x35,
+	0xf6, 0x5e, 0xe3, 0x50, 0xfc, 0x4b, 0xfa, 0x6d, 0x5d, 0xb4, 0xf7, 0x0f, 0xff, 0x1a, 0x00, 0x49,
+	0xc0, 0x74, 0x5b, 0x82, 0x10, 0x00, 0x00,
 }
 
 func (this *StoreCodeProposal) Equal(that interface{}) bool {
@@ -791,6 +847,9 @@ func (this *StoreCodeProposal) Equal(that interface{}) bool {
 	if !bytes.Equal(this.CodeHash, that1.CodeHash) {
 		return false
 	}
+	if this.Runtime != that1.Runtime {
+		return false
+	}
 	return true
 }
 func (this *InstantiateContractProposal) Equal(that interface{}) bool {
@@ -1015,6 +1074,44 @@ func (this *ExecuteContractProposal) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *ExecuteContractBatchProposal) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ExecuteContractBatchProposal)
+	if !ok {
+		that2, ok := that.(ExecuteContractBatchProposal)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Title != that1.Title {
+		return false
+	}
+	if this.Description != that1.Description {
+		return false
+	}
+	if this.RunAs != that1.RunAs {
+		return false
+	}
+	if len(this.Calls) != len(that1.Calls) {
+		return false
+	}
+	for i := range this.Calls {
+		if !this.Calls[i].Equal(&that1.Calls[i]) {
+			return false
+		}
+	}
+	return true
+}
 func (this *UpdateAdminProposal) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -1273,6 +1370,9 @@ func (this *StoreAndInstantiateContractProposal) Equal(that interface{}) bool {
 	if !bytes.Equal(this.CodeHash, that1.CodeHash) {
 		return false
 	}
+	if this.Runtime != that1.Runtime {
+		return false
+	}
 	return true
 }
 func (m *StoreCodeProposal) Marshal() (dAtA []byte, err error) {
@@ -1295,6 +1395,11 @@ func (m *StoreCodeProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Runtime != 0 {
+		i = encodeVarintProposal(dAtA, i, uint64(m.Runtime))
+		i--
+		dAtA[i] = 0x60
+	}
 	if len(m.CodeHash) > 0 {
 		i -= len(m.CodeHash)
 		copy(dAtA[i:], m.CodeHash)
@@ -1740,6 +1845,64 @@ func (m *ExecuteContractProposal) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *ExecuteContractBatchProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExecuteContractBatchProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExecuteContractBatchProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Calls) > 0 {
+		for iNdEx := len(m.Calls) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Calls[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProposal(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.RunAs) > 0 {
+		i -= len(m.RunAs)
+		copy(dAtA[i:], m.RunAs)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.RunAs)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Description) > 0 {
+		i -= len(m.Description)
+		copy(dAtA[i:], m.Description)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.Description)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Title) > 0 {
+		i -= len(m.Title)
+		copy(dAtA[i:], m.Title)
+		i = encodeVarintProposal(dAtA, i, uint64(len(m.Title)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *UpdateAdminProposal) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -2054,6 +2217,11 @@ func (m *StoreAndInstantiateContractProposal) MarshalToSizedBuffer(dAtA []byte)
 	_ = i
 	var l int
 	_ = l
+	if m.Runtime != 0 {
+		i = encodeVarintProposal(dAtA, i, uint64(m.Runtime))
+		i--
+		dAtA[i] = 0x70
+	}
 	if len(m.CodeHash) > 0 {
 		i -= len(m.CodeHash)
 		copy(dAtA[i:], m.CodeHash)
@@ -2215,6 +2383,9 @@ func (m *StoreCodeProposal) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovProposal(uint64(l))
 	}
+	if m.Runtime != 0 {
+		n += 1 + sovProposal(uint64(m.Runtime))
+	}
 	return n
 }
 
@@ -2401,6 +2572,33 @@ func (m *ExecuteContractProposal) Size() (n int) {
 	return n
 }
 
+func (m *ExecuteContractBatchProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Title)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	l = len(m.Description)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	l = len(m.RunAs)
+	if l > 0 {
+		n += 1 + l + sovProposal(uint64(l))
+	}
+	if len(m.Calls) > 0 {
+		for _, e := range m.Calls {
+			l = e.Size()
+			n += 1 + l + sovProposal(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *UpdateAdminProposal) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2591,6 +2789,9 @@ func (m *StoreAndInstantiateContractProposal) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovProposal(uint64(l))
 	}
+	if m.Runtime != 0 {
+		n += 1 + sovProposal(uint64(m.Runtime))
+	}
 	return n
 }
 
@@ -2913,6 +3114,25 @@ func (m *StoreCodeProposal) Unmarshal(dAtA []byte) error {
 				m.CodeHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Runtime", wireType)
+			}
+			m.Runtime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Runtime |= Runtime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProposal(dAtA[iNdEx:])
@@ -4239,6 +4459,186 @@ func (m *ExecuteContractProposal) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ExecuteContractBatchProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProposal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExecuteContractBatchProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExecuteContractBatchProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Title", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Title = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RunAs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RunAs = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Calls", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProposal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Calls = append(m.Calls, ContractCall{})
+			if err := m.Calls[len(m.Calls)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProposal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProposal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *UpdateAdminProposal) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -5638,6 +6038,25 @@ func (m *StoreAndInstantiateContractProposal) Unmarshal(dAtA []byte) error {
 				m.CodeHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Runtime", wireType)
+			}
+			m.Runtime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProposal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Runtime |= Runtime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProposal(dAtA[iNdEx:])
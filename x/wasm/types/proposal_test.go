@@ -688,6 +688,71 @@ func TestValidateExecuteContractProposal(t *testing.T) {
 	}
 }
 
+func TestValidateExecuteContractBatchProposal(t *testing.T) {
+	invalidAddress := "invalid address"
+
+	specs := map[string]struct {
+		src    *ExecuteContractBatchProposal
+		expErr bool
+	}{
+		"all good": {
+			src: ExecuteContractBatchProposalFixture(),
+		},
+		"calls missing": {
+			src: ExecuteContractBatchProposalFixture(func(p *ExecuteContractBatchProposal) {
+				p.Calls = nil
+			}),
+			expErr: true,
+		},
+		"msg is nil": {
+			src: ExecuteContractBatchProposalFixture(func(p *ExecuteContractBatchProposal) {
+				p.Calls[1].Msg = nil
+			}),
+			expErr: true,
+		},
+		"msg with invalid json": {
+			src: ExecuteContractBatchProposalFixture(func(p *ExecuteContractBatchProposal) {
+				p.Calls[1].Msg = []byte("not a valid json message")
+			}),
+			expErr: true,
+		},
+		"invalid method": {
+			src: ExecuteContractBatchProposalFixture(func(p *ExecuteContractBatchProposal) {
+				p.Calls[0].Method = "set-params"
+			}),
+			expErr: true,
+		},
+		"base data missing": {
+			src: ExecuteContractBatchProposalFixture(func(p *ExecuteContractBatchProposal) {
+				p.Title = ""
+			}),
+			expErr: true,
+		},
+		"contract invalid": {
+			src: ExecuteContractBatchProposalFixture(func(p *ExecuteContractBatchProposal) {
+				p.Calls[1].Contract = invalidAddress
+			}),
+			expErr: true,
+		},
+		"run as is invalid": {
+			src: ExecuteContractBatchProposalFixture(func(p *ExecuteContractBatchProposal) {
+				p.RunAs = invalidAddress
+			}),
+			expErr: true,
+		},
+	}
+	for msg, spec := range specs {
+		t.Run(msg, func(t *testing.T) {
+			err := spec.src.ValidateBasic()
+			if spec.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateUpdateAdminProposal(t *testing.T) {
 	invalidAddress := "invalid address"
 
@@ -850,6 +915,23 @@ func TestProposalStrings(t *testing.T) {
   Contract:    cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr
   Code id:     1
   Msg:         "{\"verifier\":\"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqs2m6sx4\"}"
+`,
+		},
+		"execute contract batch": {
+			src: ExecuteContractBatchProposalFixture(),
+			exp: `Execute Contract Batch Proposal:
+  Title:       Foo
+  Description: Bar
+  Run as:      cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqs2m6sx4
+  Calls:
+    - Contract: cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr
+      Method:   setParams
+      Msg:      "{\"fee\":\"1\"}"
+      Funds:    
+    - Contract: cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr
+      Method:   
+      Msg:      "{\"do\":\"something\"}"
+      Funds:    1stake
 `,
 		},
 		"update admin": {
@@ -969,6 +1051,24 @@ description: Bar
 contract: cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr
 code_id: 1
 msg: '{"verifier":"cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqs2m6sx4"}'
+`,
+		},
+		"execute contract batch": {
+			src: ExecuteContractBatchProposalFixture(),
+			exp: `title: Foo
+description: Bar
+run_as: cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqs2m6sx4
+calls:
+- contract: cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr
+  method: setParams
+  msg: '{"fee":"1"}'
+  funds: []
+- contract: cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr
+  method: ""
+  msg: '{"do":"something"}'
+  funds:
+  - denom: stake
+    amount: "1"
 `,
 		},
 		"update admin": {
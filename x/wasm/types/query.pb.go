@@ -450,6 +450,8 @@ type QuerySmartContractStateRequest struct {
 	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	// QueryData contains the query data passed to the contract
 	QueryData RawContractMessage `protobuf:"bytes,2,opt,name=query_data,json=queryData,proto3,casttype=RawContractMessage" json:"query_data,omitempty"`
+	// Smart contract method to query
+	Method string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
 }
 
 func (m *QuerySmartContractStateRequest) Reset()         { *m = QuerySmartContractStateRequest{} }
@@ -569,6 +571,7 @@ type CodeInfoResponse struct {
 	Creator               string                                               `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
 	DataHash              github_com_tendermint_tendermint_libs_bytes.HexBytes `protobuf:"bytes,3,opt,name=data_hash,json=dataHash,proto3,casttype=github.com/tendermint/tendermint/libs/bytes.HexBytes" json:"data_hash,omitempty"`
 	InstantiatePermission AccessConfig                                         `protobuf:"bytes,6,opt,name=instantiate_permission,json=instantiatePermission,proto3" json:"instantiate_permission"`
+	Runtime               Runtime                                              `protobuf:"varint,7,opt,name=runtime,proto3,enum=cosmwasm.wasm.v1.Runtime" json:"runtime,omitempty"`
 }
 
 func (m *CodeInfoResponse) Reset()         { *m = CodeInfoResponse{} }
@@ -963,6 +966,85 @@ func (m *QueryContractsByCreatorResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_QueryContractsByCreatorResponse proto.InternalMessageInfo
 
+// QueryCodeABIRequest is the request type for the Query/CodeABI RPC method
+type QueryCodeABIRequest struct {
+	CodeId uint64 `protobuf:"varint,1,opt,name=code_id,json=codeId,proto3" json:"code_id,omitempty"`
+}
+
+func (m *QueryCodeABIRequest) Reset()         { *m = QueryCodeABIRequest{} }
+func (m *QueryCodeABIRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryCodeABIRequest) ProtoMessage()    {}
+func (*QueryCodeABIRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9677c207036b9f2b, []int{23}
+}
+func (m *QueryCodeABIRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryCodeABIRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryCodeABIRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryCodeABIRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryCodeABIRequest.Merge(m, src)
+}
+func (m *QueryCodeABIRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryCodeABIRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryCodeABIRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryCodeABIRequest proto.InternalMessageInfo
+
+// QueryCodeABIResponse is the response type for the Query/CodeABI RPC method
+type QueryCodeABIResponse struct {
+	// Manifest msgpack encoded wrap.info as uploaded with the wrapper
+	Manifest []byte `protobuf:"bytes,1,opt,name=manifest,proto3" json:"manifest,omitempty"`
+	// ABI json encoded module methods and custom types of the wrapper
+	ABI RawContractMessage `protobuf:"bytes,2,opt,name=abi,proto3,casttype=RawContractMessage" json:"abi,omitempty"`
+}
+
+func (m *QueryCodeABIResponse) Reset()         { *m = QueryCodeABIResponse{} }
+func (m *QueryCodeABIResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryCodeABIResponse) ProtoMessage()    {}
+func (*QueryCodeABIResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_9677c207036b9f2b, []int{24}
+}
+func (m *QueryCodeABIResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryCodeABIResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryCodeABIResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryCodeABIResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryCodeABIResponse.Merge(m, src)
+}
+func (m *QueryCodeABIResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryCodeABIResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryCodeABIResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryCodeABIResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*QueryContractInfoRequest)(nil), "cosmwasm.wasm.v1.QueryContractInfoRequest")
 	proto.RegisterType((*QueryContractInfoResponse)(nil), "cosmwasm.wasm.v1.QueryContractInfoResponse")
@@ -987,96 +1069,104 @@ func init() {
 	proto.RegisterType((*QueryParamsResponse)(nil), "cosmwasm.wasm.v1.QueryParamsResponse")
 	proto.RegisterType((*QueryContractsByCreatorRequest)(nil), "cosmwasm.wasm.v1.QueryContractsByCreatorRequest")
 	proto.RegisterType((*QueryContractsByCreatorResponse)(nil), "cosmwasm.wasm.v1.QueryContractsByCreatorResponse")
+	proto.RegisterType((*QueryCodeABIRequest)(nil), "cosmwasm.wasm.v1.QueryCodeABIRequest")
+	proto.RegisterType((*QueryCodeABIResponse)(nil), "cosmwasm.wasm.v1.QueryCodeABIResponse")
 }
 
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/query.proto", fileDescriptor_9677c207036b9f2b) }
 
 var fileDescriptor_9677c207036b9f2b = []byte{
-	// 1331 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x98, 0xcd, 0x6f, 0x1b, 0xc5,
-	0x1b, 0xc7, 0x3d, 0xa9, 0xe3, 0x97, 0x69, 0xfa, 0xab, 0x3b, 0xbf, 0xd2, 0x18, 0x93, 0xae, 0xa3,
-	0xa5, 0xa4, 0x69, 0x9a, 0xee, 0x92, 0x34, 0xa1, 0x80, 0x84, 0x50, 0x9c, 0x42, 0x93, 0x48, 0x91,
-	0xd2, 0xad, 0x50, 0x25, 0x7a, 0xb0, 0xc6, 0xde, 0x89, 0xb3, 0x52, 0xbc, 0xeb, 0xec, 0x4c, 0x92,
-	0x5a, 0x51, 0x00, 0x55, 0xe2, 0x86, 0x78, 0x11, 0xe2, 0xc0, 0x01, 0xc1, 0x01, 0x15, 0xce, 0x70,
-	0x41, 0x5c, 0xb9, 0xe4, 0x18, 0x89, 0x0b, 0x27, 0x0b, 0x1c, 0x0e, 0x28, 0x7f, 0x42, 0x4f, 0x68,
-	0x67, 0x67, 0x9c, 0x5d, 0xdb, 0x1b, 0x3b, 0x95, 0xc5, 0xc5, 0xda, 0xdd, 0x79, 0x66, 0x9e, 0xcf,
-	0xf3, 0x9d, 0x67, 0xe6, 0x79, 0x64, 0x38, 0x56, 0x76, 0x68, 0x75, 0x17, 0xd3, 0xaa, 0xce, 0x7f,
-	0x76, 0x66, 0xf4, 0xad, 0x6d, 0xe2, 0xd6, 0xb5, 0x9a, 0xeb, 0x30, 0x07, 0x65, 0xe4, 0xa8, 0xc6,
-	0x7f, 0x76, 0x66, 0x72, 0x97, 0x2b, 0x4e, 0xc5, 0xe1, 0x83, 0xba, 0xf7, 0xe4, 0xdb, 0xe5, 0x3a,
-	0x57, 0x61, 0xf5, 0x1a, 0xa1, 0x72, 0xb4, 0xe2, 0x38, 0x95, 0x4d, 0xa2, 0xe3, 0x9a, 0xa5, 0x63,
-	0xdb, 0x76, 0x18, 0x66, 0x96, 0x63, 0xcb, 0xd1, 0x29, 0x6f, 0xae, 0x43, 0xf5, 0x12, 0xa6, 0xc4,
-	0x77, 0xae, 0xef, 0xcc, 0x94, 0x08, 0xc3, 0x33, 0x7a, 0x0d, 0x57, 0x2c, 0x9b, 0x1b, 0xfb, 0xb6,
-	0xea, 0x1c, 0xcc, 0xde, 0xf7, 0x2c, 0x16, 0x1d, 0x9b, 0xb9, 0xb8, 0xcc, 0x96, 0xed, 0x75, 0xc7,
-	0x20, 0x5b, 0xdb, 0x84, 0x32, 0x94, 0x85, 0x49, 0x6c, 0x9a, 0x2e, 0xa1, 0x34, 0x0b, 0xc6, 0xc1,
-	0x64, 0xda, 0x90, 0xaf, 0xea, 0xa7, 0x00, 0xbe, 0xd8, 0x65, 0x1a, 0xad, 0x39, 0x36, 0x25, 0xd1,
-	0xf3, 0xd0, 0x7d, 0x78, 0xa1, 0x2c, 0x66, 0x14, 0x2d, 0x7b, 0xdd, 0xc9, 0x0e, 0x8d, 0x83, 0xc9,
-	0xf3, 0xb3, 0x8a, 0xd6, 0xae, 0x8a, 0x16, 0x5c, 0xb8, 0x30, 0x72, 0xd0, 0xc8, 0xc7, 0x0e, 0x1b,
-	0x79, 0x70, 0xdc, 0xc8, 0xc7, 0x8c, 0x91, 0x72, 0x60, 0xec, 0xcd, 0xf8, 0x3f, 0xdf, 0xe5, 0x81,
-	0xfa, 0x21, 0x7c, 0x29, 0xc4, 0xb3, 0x64, 0x51, 0xe6, 0xb8, 0xf5, 0x9e, 0x91, 0xa0, 0x77, 0x21,
-	0x3c, 0xd1, 0x44, 0xe0, 0x4c, 0x68, 0xbe, 0x80, 0x9a, 0x27, 0xa0, 0xe6, 0xef, 0x9e, 0x10, 0x50,
-	0x5b, 0xc3, 0x15, 0x22, 0x56, 0x35, 0x02, 0x33, 0xd5, 0x9f, 0x01, 0x1c, 0xeb, 0x4e, 0x20, 0x44,
-	0x59, 0x81, 0x49, 0x62, 0x33, 0xd7, 0x22, 0x1e, 0xc2, 0xb9, 0xc9, 0xf3, 0xb3, 0x53, 0xd1, 0x41,
-	0x2f, 0x3a, 0x26, 0x11, 0xf3, 0xdf, 0xb1, 0x99, 0x5b, 0x2f, 0xc4, 0x3d, 0x01, 0x0c, 0xb9, 0x00,
-	0xba, 0xd7, 0x05, 0xfa, 0x7a, 0x4f, 0x68, 0x1f, 0x24, 0x44, 0xfd, 0x41, 0x9b, 0x6c, 0xb4, 0x50,
-	0xf7, 0x7c, 0x4b, 0xd9, 0x46, 0x61, 0xb2, 0xec, 0x98, 0xa4, 0x68, 0x99, 0x5c, 0xb6, 0xb8, 0x91,
-	0xf0, 0x5e, 0x97, 0xcd, 0x81, 0xa9, 0xf6, 0x71, 0xbb, 0x6a, 0x2d, 0x00, 0xa1, 0xda, 0x18, 0x4c,
-	0xcb, 0xdd, 0xf6, 0x75, 0x4b, 0x1b, 0x27, 0x1f, 0x06, 0xa7, 0xc3, 0x47, 0x92, 0x63, 0x61, 0x73,
-	0x53, 0xa2, 0x3c, 0x60, 0x98, 0x91, 0xff, 0x2e, 0x81, 0xbe, 0x05, 0xf0, 0x6a, 0x04, 0x82, 0xd0,
-	0x62, 0x1e, 0x26, 0xaa, 0x8e, 0x49, 0x36, 0x65, 0x02, 0x8d, 0x76, 0x26, 0xd0, 0xaa, 0x37, 0x2e,
-	0xb2, 0x45, 0x18, 0x0f, 0x4e, 0xa4, 0x87, 0x42, 0x23, 0x03, 0xef, 0x9e, 0x51, 0xa3, 0xab, 0x10,
-	0x72, 0x1f, 0x45, 0x13, 0x33, 0xcc, 0x11, 0x46, 0x8c, 0x34, 0xff, 0x72, 0x17, 0x33, 0xac, 0xde,
-	0x16, 0x91, 0x77, 0x2e, 0x2c, 0x22, 0x47, 0x30, 0xce, 0x67, 0x02, 0x3e, 0x93, 0x3f, 0xab, 0x5b,
-	0x50, 0xe1, 0x93, 0x1e, 0x54, 0xb1, 0xcb, 0xce, 0xc8, 0x33, 0xdf, 0xc9, 0x53, 0xb8, 0xf2, 0xac,
-	0x91, 0x47, 0x01, 0x82, 0x55, 0x42, 0xa9, 0xa7, 0x44, 0x80, 0x73, 0x15, 0xe6, 0x23, 0x5d, 0x0a,
-	0xd2, 0xa9, 0x20, 0x69, 0xe4, 0x9a, 0x7e, 0x04, 0x37, 0x61, 0x46, 0xe4, 0x7e, 0xef, 0x13, 0xa7,
-	0x7e, 0x33, 0x04, 0x33, 0x9e, 0x61, 0xe8, 0xa2, 0xbd, 0xd1, 0x66, 0x5d, 0xc8, 0x34, 0x1b, 0xf9,
-	0x04, 0x37, 0xbb, 0x7b, 0xdc, 0xc8, 0x0f, 0x59, 0x66, 0xeb, 0xc4, 0x66, 0x61, 0xb2, 0xec, 0x12,
-	0xcc, 0x1c, 0x97, 0xc7, 0x9b, 0x36, 0xe4, 0x2b, 0x7a, 0x0f, 0xa6, 0x3d, 0x9c, 0xe2, 0x06, 0xa6,
-	0x1b, 0xd9, 0x73, 0x9c, 0xfb, 0xf5, 0x67, 0x8d, 0xfc, 0x5c, 0xc5, 0x62, 0x1b, 0xdb, 0x25, 0xad,
-	0xec, 0x54, 0x75, 0x46, 0x6c, 0x93, 0xb8, 0x55, 0xcb, 0x66, 0xc1, 0xc7, 0x4d, 0xab, 0x44, 0xf5,
-	0x52, 0x9d, 0x11, 0xaa, 0x2d, 0x91, 0xc7, 0x05, 0xef, 0xc1, 0x48, 0x79, 0x4b, 0x2d, 0x61, 0xba,
-	0x81, 0x1e, 0xc1, 0x2b, 0x96, 0x4d, 0x19, 0xb6, 0x99, 0x85, 0x19, 0x29, 0xd6, 0xbc, 0x49, 0x94,
-	0x7a, 0x29, 0x98, 0x88, 0xba, 0xf3, 0x17, 0xca, 0x65, 0x42, 0xe9, 0xa2, 0x63, 0xaf, 0x5b, 0x15,
-	0x91, 0xc4, 0x2f, 0x04, 0xd6, 0x58, 0x6b, 0x2d, 0xe1, 0x5f, 0xfa, 0x2b, 0xf1, 0x54, 0x3c, 0x33,
-	0xbc, 0x12, 0x4f, 0x0d, 0x67, 0x12, 0xea, 0x13, 0x00, 0x2f, 0x05, 0xd4, 0x14, 0x02, 0x2d, 0x7b,
-	0xd7, 0x87, 0x27, 0x90, 0x57, 0x6b, 0x00, 0xf7, 0xab, 0x76, 0xbb, 0x76, 0xc3, 0xba, 0x16, 0x52,
-	0xad, 0x5a, 0x93, 0x2a, 0x8b, 0x31, 0x34, 0x26, 0x76, 0xd6, 0xcf, 0x96, 0xd4, 0x71, 0x23, 0xcf,
-	0xdf, 0xfd, 0xbd, 0x14, 0x55, 0xe8, 0x51, 0x80, 0x81, 0xca, 0x2d, 0x0d, 0x5f, 0x10, 0xe0, 0xb9,
-	0x2f, 0x88, 0xa7, 0x00, 0xa2, 0xe0, 0xea, 0x22, 0xc4, 0x7b, 0x10, 0xb6, 0x42, 0x94, 0x37, 0x43,
-	0x3f, 0x31, 0xfa, 0xfa, 0xa6, 0x65, 0x7c, 0x03, 0xbc, 0x27, 0x30, 0x1c, 0xe5, 0x9c, 0x6b, 0x96,
-	0x6d, 0x13, 0xf3, 0x14, 0x2d, 0x9e, 0xff, 0xb2, 0xfc, 0x0c, 0x88, 0xb6, 0x25, 0xe4, 0xa3, 0x75,
-	0x06, 0x53, 0xe2, 0x54, 0xf8, 0x7a, 0xc4, 0x0b, 0x17, 0xbd, 0x58, 0x9b, 0x8d, 0x7c, 0xd2, 0x3f,
-	0x1a, 0xd4, 0x48, 0xfa, 0xa7, 0x62, 0x80, 0x41, 0x5f, 0x16, 0x9b, 0xb3, 0x86, 0x5d, 0x5c, 0x95,
-	0xf1, 0xaa, 0xab, 0xf0, 0xff, 0xa1, 0xaf, 0x82, 0xf0, 0x35, 0x98, 0xa8, 0xf1, 0x2f, 0x22, 0x1d,
-	0xb2, 0x9d, 0xfb, 0xe5, 0xcf, 0x90, 0x57, 0xb9, 0x6f, 0xad, 0x7e, 0x01, 0xc4, 0xa5, 0x17, 0x2c,
-	0x97, 0xfe, 0x31, 0x96, 0x0a, 0x5f, 0x87, 0x17, 0xc5, 0xc1, 0x2e, 0x86, 0x2f, 0xbf, 0xff, 0x89,
-	0xcf, 0x0b, 0x03, 0xae, 0x5b, 0x5f, 0x03, 0x71, 0x2b, 0x76, 0x63, 0x12, 0xf1, 0xde, 0x82, 0xa8,
-	0xd5, 0xf6, 0x09, 0x2a, 0x22, 0xcb, 0xf9, 0x25, 0x39, 0xb2, 0x20, 0x07, 0x06, 0xb6, 0x29, 0xb3,
-	0xbf, 0x5d, 0x80, 0xc3, 0x9c, 0x0d, 0x7d, 0x05, 0xe0, 0x48, 0xb0, 0xa5, 0x44, 0x5d, 0xba, 0xaf,
-	0xa8, 0x3e, 0x38, 0x77, 0xb3, 0x2f, 0x5b, 0xdf, 0xbf, 0x3a, 0xfd, 0xe4, 0xf7, 0xbf, 0xbf, 0x1c,
-	0x9a, 0x40, 0xd7, 0xf4, 0x8e, 0x0e, 0x5e, 0x46, 0xaa, 0xef, 0x09, 0x11, 0xf6, 0xd1, 0x53, 0x00,
-	0x2f, 0xb6, 0x75, 0x8c, 0xe8, 0x56, 0x0f, 0x77, 0xe1, 0xde, 0x36, 0xa7, 0xf5, 0x6b, 0x2e, 0x00,
-	0xe7, 0x38, 0xa0, 0x86, 0xa6, 0xfb, 0x01, 0xd4, 0x37, 0x04, 0xd4, 0xf7, 0x01, 0x50, 0xd1, 0xa4,
-	0xf5, 0x04, 0x0d, 0x77, 0x93, 0x3d, 0x41, 0xdb, 0x7a, 0x3f, 0x75, 0x96, 0x83, 0x4e, 0xa3, 0xa9,
-	0x6e, 0xa0, 0x26, 0xd1, 0xf7, 0xc4, 0x29, 0xdf, 0xd7, 0x4f, 0x3a, 0xc2, 0x1f, 0x00, 0xcc, 0xb4,
-	0x37, 0x50, 0x28, 0xca, 0x71, 0x44, 0xb3, 0x97, 0xd3, 0xfb, 0xb6, 0xef, 0x87, 0xb4, 0x43, 0x52,
-	0xca, 0xa1, 0x7e, 0x02, 0x30, 0xd3, 0xde, 0xf0, 0x44, 0x92, 0x46, 0xb4, 0x5c, 0x91, 0xa4, 0x51,
-	0x9d, 0x94, 0xfa, 0x16, 0x27, 0xbd, 0x83, 0xe6, 0xfb, 0x22, 0x75, 0xf1, 0xae, 0xbe, 0x77, 0xd2,
-	0x29, 0xed, 0xa3, 0x5f, 0x01, 0x44, 0x9d, 0xdd, 0x0f, 0x7a, 0x35, 0x02, 0x23, 0xb2, 0x37, 0xcb,
-	0xcd, 0x9c, 0x61, 0x86, 0x40, 0x7f, 0x9b, 0xa3, 0xbf, 0x81, 0xee, 0xf4, 0x27, 0xb2, 0xb7, 0x50,
-	0x18, 0xbe, 0x0e, 0xe3, 0x3c, 0x6d, 0xd5, 0xc8, 0x3c, 0x3c, 0xc9, 0xd5, 0x97, 0x4f, 0xb5, 0x11,
-	0x44, 0x93, 0x9c, 0x48, 0x45, 0xe3, 0xbd, 0x12, 0x14, 0xb9, 0x70, 0x98, 0xd7, 0x28, 0x74, 0xda,
-	0xba, 0xb2, 0x6a, 0xe4, 0xae, 0x9d, 0x6e, 0x24, 0xbc, 0x2b, 0xdc, 0x7b, 0x16, 0x5d, 0xe9, 0xee,
-	0x1d, 0x7d, 0x02, 0xe0, 0xf9, 0x40, 0x79, 0x44, 0x37, 0x22, 0x56, 0xed, 0x2c, 0xd3, 0xb9, 0xa9,
-	0x7e, 0x4c, 0x05, 0xc6, 0x04, 0xc7, 0x18, 0x47, 0x4a, 0x77, 0x0c, 0xaa, 0xd7, 0xf8, 0x24, 0xb4,
-	0x0f, 0x13, 0x7e, 0x4d, 0x43, 0x51, 0xe1, 0x85, 0x4a, 0x67, 0xee, 0x95, 0x1e, 0x56, 0x7d, 0xbb,
-	0xf7, 0x9d, 0xfe, 0x02, 0x20, 0xea, 0xac, 0x50, 0x91, 0x99, 0x1b, 0x59, 0x60, 0x23, 0x33, 0x37,
-	0xba, 0xfc, 0xf5, 0x73, 0xe8, 0xa8, 0x2e, 0xca, 0xb3, 0xbe, 0xd7, 0x56, 0xbe, 0xf7, 0x0b, 0x4b,
-	0x07, 0x7f, 0x29, 0xb1, 0x1f, 0x9b, 0x4a, 0xec, 0xa0, 0xa9, 0x80, 0xc3, 0xa6, 0x02, 0xfe, 0x6c,
-	0x2a, 0xe0, 0xf3, 0x23, 0x25, 0x76, 0x78, 0xa4, 0xc4, 0xfe, 0x38, 0x52, 0x62, 0xef, 0x4f, 0x04,
-	0x7a, 0xf5, 0x45, 0x87, 0x56, 0x1f, 0x4a, 0x17, 0xa6, 0xfe, 0xd8, 0x77, 0xc5, 0xff, 0x3c, 0x2a,
-	0x25, 0xf8, 0x7f, 0x3e, 0xb7, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x78, 0x46, 0xe1, 0xa8, 0xa3,
-	0x12, 0x00, 0x00,
+	// 1440 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xcd, 0x6f, 0x1b, 0xd5,
+	0x16, 0xf7, 0x4d, 0x1c, 0x7f, 0x9c, 0xe6, 0xb5, 0xee, 0x7d, 0x7d, 0xa9, 0x3b, 0x2f, 0xb1, 0xa3,
+	0x79, 0x6d, 0x9a, 0xa6, 0xed, 0xcc, 0x4b, 0xda, 0x52, 0x40, 0x42, 0x28, 0x4e, 0xa1, 0x49, 0xa5,
+	0x48, 0xe9, 0x54, 0xa8, 0x12, 0x5d, 0x44, 0xd7, 0x9e, 0x1b, 0x67, 0xa4, 0x78, 0xc6, 0x9d, 0x7b,
+	0xd3, 0xd6, 0x8a, 0x02, 0xa8, 0x12, 0x48, 0x48, 0x88, 0x0f, 0x21, 0x16, 0xac, 0x60, 0x81, 0x0a,
+	0x6b, 0xd8, 0x20, 0xfe, 0x82, 0x2e, 0x58, 0x54, 0xb0, 0x61, 0x65, 0x81, 0xcb, 0x02, 0xf5, 0x4f,
+	0xe8, 0x0a, 0xcd, 0x9d, 0x3b, 0xf6, 0xf8, 0x63, 0xe2, 0x49, 0x65, 0xb1, 0xb1, 0xe6, 0xce, 0x3d,
+	0x1f, 0xbf, 0xf3, 0xbb, 0xe7, 0x9e, 0x73, 0x3c, 0x30, 0x5d, 0x71, 0x58, 0xed, 0x3e, 0x61, 0x35,
+	0x5d, 0xfc, 0xdc, 0x5b, 0xd4, 0xef, 0xee, 0x52, 0xb7, 0xa1, 0xd5, 0x5d, 0x87, 0x3b, 0x38, 0x17,
+	0xec, 0x6a, 0xe2, 0xe7, 0xde, 0xa2, 0x72, 0xa2, 0xea, 0x54, 0x1d, 0xb1, 0xa9, 0x7b, 0x4f, 0xbe,
+	0x9c, 0xd2, 0x6f, 0x85, 0x37, 0xea, 0x94, 0x05, 0xbb, 0x55, 0xc7, 0xa9, 0xee, 0x50, 0x9d, 0xd4,
+	0x2d, 0x9d, 0xd8, 0xb6, 0xc3, 0x09, 0xb7, 0x1c, 0x3b, 0xd8, 0x5d, 0xf0, 0x74, 0x1d, 0xa6, 0x97,
+	0x09, 0xa3, 0xbe, 0x73, 0xfd, 0xde, 0x62, 0x99, 0x72, 0xb2, 0xa8, 0xd7, 0x49, 0xd5, 0xb2, 0x85,
+	0xb0, 0x2f, 0xab, 0x5e, 0x86, 0xfc, 0x4d, 0x4f, 0x62, 0xc5, 0xb1, 0xb9, 0x4b, 0x2a, 0x7c, 0xcd,
+	0xde, 0x72, 0x0c, 0x7a, 0x77, 0x97, 0x32, 0x8e, 0xf3, 0x90, 0x26, 0xa6, 0xe9, 0x52, 0xc6, 0xf2,
+	0x68, 0x16, 0xcd, 0x67, 0x8d, 0x60, 0xa9, 0x7e, 0x8c, 0xe0, 0xd4, 0x00, 0x35, 0x56, 0x77, 0x6c,
+	0x46, 0xa3, 0xf5, 0xf0, 0x4d, 0xf8, 0x57, 0x45, 0x6a, 0x6c, 0x5a, 0xf6, 0x96, 0x93, 0x1f, 0x9b,
+	0x45, 0xf3, 0x47, 0x96, 0x0a, 0x5a, 0x2f, 0x2b, 0x5a, 0xd8, 0x70, 0x69, 0xf2, 0x71, 0xb3, 0x98,
+	0x78, 0xd2, 0x2c, 0xa2, 0x67, 0xcd, 0x62, 0xc2, 0x98, 0xac, 0x84, 0xf6, 0x5e, 0x4d, 0xfe, 0xf5,
+	0x75, 0x11, 0xa9, 0xef, 0xc2, 0x7f, 0xbb, 0xf0, 0xac, 0x5a, 0x8c, 0x3b, 0x6e, 0x63, 0x68, 0x24,
+	0xf8, 0x4d, 0x80, 0x0e, 0x27, 0x12, 0xce, 0x9c, 0xe6, 0x13, 0xa8, 0x79, 0x04, 0x6a, 0xfe, 0xe9,
+	0x49, 0x02, 0xb5, 0x0d, 0x52, 0xa5, 0xd2, 0xaa, 0x11, 0xd2, 0x54, 0x7f, 0x40, 0x30, 0x3d, 0x18,
+	0x81, 0x24, 0xe5, 0x06, 0xa4, 0xa9, 0xcd, 0x5d, 0x8b, 0x7a, 0x10, 0xc6, 0xe7, 0x8f, 0x2c, 0x2d,
+	0x44, 0x07, 0xbd, 0xe2, 0x98, 0x54, 0xea, 0xbf, 0x61, 0x73, 0xb7, 0x51, 0x4a, 0x7a, 0x04, 0x18,
+	0x81, 0x01, 0x7c, 0x7d, 0x00, 0xe8, 0xb3, 0x43, 0x41, 0xfb, 0x40, 0xba, 0x50, 0xbf, 0xd3, 0x43,
+	0x1b, 0x2b, 0x35, 0x3c, 0xdf, 0x01, 0x6d, 0x27, 0x21, 0x5d, 0x71, 0x4c, 0xba, 0x69, 0x99, 0x82,
+	0xb6, 0xa4, 0x91, 0xf2, 0x96, 0x6b, 0xe6, 0xc8, 0x58, 0x7b, 0xbf, 0x97, 0xb5, 0x36, 0x00, 0xc9,
+	0xda, 0x34, 0x64, 0x83, 0xd3, 0xf6, 0x79, 0xcb, 0x1a, 0x9d, 0x17, 0xa3, 0xe3, 0xe1, 0xbd, 0x00,
+	0xc7, 0xf2, 0xce, 0x4e, 0x00, 0xe5, 0x16, 0x27, 0x9c, 0xfe, 0x73, 0x09, 0xf4, 0x15, 0x82, 0x99,
+	0x08, 0x08, 0x92, 0x8b, 0x2b, 0x90, 0xaa, 0x39, 0x26, 0xdd, 0x09, 0x12, 0xe8, 0x64, 0x7f, 0x02,
+	0xad, 0x7b, 0xfb, 0x32, 0x5b, 0xa4, 0xf0, 0xe8, 0x48, 0xba, 0x2d, 0x39, 0x32, 0xc8, 0xfd, 0x43,
+	0x72, 0x34, 0x03, 0x20, 0x7c, 0x6c, 0x9a, 0x84, 0x13, 0x01, 0x61, 0xd2, 0xc8, 0x8a, 0x37, 0xd7,
+	0x08, 0x27, 0xea, 0x25, 0x98, 0x89, 0x30, 0x2c, 0x23, 0xc7, 0x90, 0x14, 0x9a, 0x48, 0x68, 0x8a,
+	0x67, 0xf5, 0x43, 0x04, 0x05, 0xa1, 0x75, 0xab, 0x46, 0x5c, 0x7e, 0x48, 0x40, 0x57, 0xfa, 0x01,
+	0x95, 0xa6, 0x9e, 0x37, 0x8b, 0x38, 0x04, 0x61, 0x9d, 0x32, 0xe6, 0x51, 0xd1, 0x01, 0x8a, 0xa7,
+	0x20, 0x55, 0xa3, 0x7c, 0xdb, 0x31, 0xf3, 0xe3, 0xc2, 0x9e, 0x5c, 0xa9, 0xeb, 0x50, 0x8c, 0x84,
+	0x22, 0x43, 0x58, 0x08, 0x87, 0x10, 0xe9, 0xcb, 0x0f, 0xed, 0x3c, 0xe4, 0xe4, 0xa5, 0x18, 0x7e,
+	0x15, 0xd5, 0x5f, 0xc6, 0x20, 0xe7, 0x09, 0x76, 0x55, 0xe0, 0x73, 0x3d, 0xd2, 0xa5, 0x5c, 0xab,
+	0x59, 0x4c, 0x09, 0xb1, 0x6b, 0xcf, 0x9a, 0xc5, 0x31, 0xcb, 0x6c, 0x5f, 0xe5, 0x3c, 0xa4, 0x2b,
+	0x2e, 0x25, 0xdc, 0x71, 0x05, 0x0f, 0x59, 0x23, 0x58, 0xe2, 0xb7, 0x20, 0xeb, 0xc1, 0xd9, 0xdc,
+	0x26, 0x6c, 0x5b, 0x04, 0x3c, 0x59, 0x7a, 0xf9, 0x79, 0xb3, 0x78, 0xb9, 0x6a, 0xf1, 0xed, 0xdd,
+	0xb2, 0x56, 0x71, 0x6a, 0x3a, 0xa7, 0xb6, 0x49, 0xdd, 0x9a, 0x65, 0xf3, 0xf0, 0xe3, 0x8e, 0x55,
+	0x66, 0x7a, 0xb9, 0xc1, 0x29, 0xd3, 0x56, 0xe9, 0x83, 0x92, 0xf7, 0x60, 0x64, 0x3c, 0x53, 0xab,
+	0x84, 0x6d, 0xe3, 0x3b, 0x30, 0x65, 0xd9, 0x8c, 0x13, 0x9b, 0x5b, 0x84, 0xd3, 0xcd, 0xba, 0xa7,
+	0xc4, 0x98, 0x97, 0x9b, 0xa9, 0xa8, 0x66, 0xb0, 0x5c, 0xa9, 0x50, 0xc6, 0x56, 0x1c, 0x7b, 0xcb,
+	0xaa, 0xca, 0xec, 0xfe, 0x4f, 0xc8, 0xc6, 0x46, 0xdb, 0x04, 0xbe, 0x04, 0x69, 0x77, 0xd7, 0xe6,
+	0x56, 0x8d, 0xe6, 0xd3, 0xb3, 0x68, 0xfe, 0xe8, 0xd2, 0xa9, 0x7e, 0x6b, 0x86, 0x2f, 0x60, 0x04,
+	0x92, 0x7e, 0x0b, 0xb9, 0x91, 0xcc, 0x24, 0x73, 0x13, 0x37, 0x92, 0x99, 0x89, 0x5c, 0x4a, 0x7d,
+	0x88, 0xe0, 0x78, 0xe8, 0x08, 0x24, 0xab, 0x6b, 0x90, 0xf5, 0x59, 0xf5, 0x3a, 0x17, 0x12, 0x60,
+	0xd5, 0x41, 0x45, 0xbc, 0xfb, 0x30, 0x4a, 0x99, 0x76, 0xe7, 0xca, 0x54, 0xe4, 0x1e, 0x9e, 0x96,
+	0xe9, 0xe0, 0xa7, 0x5e, 0xe6, 0x59, 0xb3, 0x28, 0xd6, 0x7e, 0x02, 0xc8, 0x9e, 0x76, 0x27, 0x84,
+	0x81, 0x05, 0x79, 0xd0, 0x5d, 0x6e, 0xd0, 0x0b, 0x97, 0x9b, 0x47, 0x08, 0x70, 0xd8, 0xba, 0x0c,
+	0xf1, 0x3a, 0x40, 0x3b, 0xc4, 0xa0, 0xce, 0xc4, 0x89, 0xd1, 0x3f, 0x94, 0x6c, 0x10, 0xdf, 0x08,
+	0xab, 0x0e, 0x81, 0x93, 0x02, 0xe7, 0x86, 0x65, 0xdb, 0xd4, 0x3c, 0x80, 0x8b, 0x17, 0x2f, 0xbd,
+	0x9f, 0x20, 0xc8, 0xf7, 0xfb, 0x68, 0x5f, 0xdc, 0x8c, 0xbc, 0x4a, 0x3e, 0x1f, 0xc9, 0xd2, 0x31,
+	0x2f, 0xd6, 0x56, 0xb3, 0x98, 0xf6, 0xef, 0x13, 0x33, 0xd2, 0xfe, 0x55, 0x1a, 0x61, 0xd0, 0x27,
+	0xe4, 0xe1, 0x6c, 0x10, 0x97, 0xd4, 0x82, 0x78, 0xd5, 0x75, 0xf8, 0x77, 0xd7, 0x5b, 0x89, 0xf0,
+	0x25, 0x48, 0xd5, 0xc5, 0x1b, 0x99, 0x0e, 0xf9, 0xfe, 0xf3, 0xf2, 0x35, 0x82, 0xc6, 0xe0, 0x4b,
+	0xab, 0x9f, 0x05, 0x15, 0x34, 0xdc, 0x7c, 0xfd, 0xbb, 0x1f, 0x30, 0x7c, 0x16, 0x8e, 0xc9, 0x6a,
+	0xb0, 0xd9, 0x5d, 0x49, 0x8f, 0xca, 0xd7, 0xcb, 0x23, 0xee, 0x82, 0x5f, 0x22, 0x28, 0x46, 0x62,
+	0x92, 0xf1, 0x5e, 0x04, 0xdc, 0x1e, 0x22, 0x25, 0x2a, 0x1a, 0x0c, 0x07, 0xc7, 0x83, 0x9d, 0xe5,
+	0x60, 0x63, 0x74, 0x87, 0xa2, 0x49, 0xfa, 0xbd, 0x63, 0x5f, 0x2e, 0xad, 0x0d, 0xad, 0xcc, 0x15,
+	0x38, 0xd1, 0x2d, 0x2f, 0xf1, 0x2b, 0x90, 0xa9, 0x11, 0xdb, 0xda, 0xa2, 0x8c, 0xcb, 0x8e, 0xd6,
+	0x5e, 0x63, 0x1d, 0xc6, 0x49, 0xd9, 0x92, 0x65, 0x61, 0xa6, 0xd5, 0x2c, 0x8e, 0x2f, 0x97, 0xd6,
+	0x22, 0x9a, 0x85, 0x27, 0xb9, 0xf4, 0xf3, 0x51, 0x98, 0x10, 0x5e, 0xf0, 0x17, 0x08, 0x26, 0xc3,
+	0x53, 0x33, 0x1e, 0x30, 0x60, 0x46, 0x8d, 0xfa, 0xca, 0xf9, 0x58, 0xb2, 0x7e, 0x00, 0xea, 0x85,
+	0x87, 0xbf, 0xfe, 0xf9, 0xf9, 0xd8, 0x1c, 0x3e, 0xad, 0xf7, 0xfd, 0x49, 0x09, 0xe8, 0xd7, 0xf7,
+	0xe4, 0xc9, 0xec, 0xe3, 0x47, 0x08, 0x8e, 0xf5, 0x0c, 0xc5, 0xf8, 0xe2, 0x10, 0x77, 0xdd, 0xe3,
+	0xbb, 0xa2, 0xc5, 0x15, 0x97, 0x00, 0x2f, 0x0b, 0x80, 0x1a, 0xbe, 0x10, 0x07, 0xa0, 0xbe, 0x2d,
+	0x41, 0x7d, 0x13, 0x02, 0x2a, 0xe7, 0xd0, 0xa1, 0x40, 0xbb, 0x07, 0x66, 0x45, 0x8b, 0x2b, 0x2e,
+	0x81, 0x2e, 0x09, 0xa0, 0x17, 0xf0, 0xc2, 0x20, 0xa0, 0x26, 0xd5, 0xf7, 0x64, 0x66, 0xed, 0xeb,
+	0x9d, 0xa1, 0xf7, 0x5b, 0x04, 0xb9, 0xde, 0x19, 0x11, 0x47, 0x39, 0x8e, 0x98, 0x67, 0x15, 0x3d,
+	0xb6, 0x7c, 0x1c, 0xa4, 0x7d, 0x94, 0x32, 0x01, 0xea, 0x7b, 0x04, 0xb9, 0xde, 0x99, 0x2e, 0x12,
+	0x69, 0xc4, 0x54, 0xa9, 0xe8, 0xb1, 0xe5, 0x25, 0xd2, 0xd7, 0x04, 0xd2, 0xab, 0xf8, 0x4a, 0x2c,
+	0xa4, 0x2e, 0xb9, 0xaf, 0xef, 0x75, 0x66, 0xc1, 0x7d, 0xfc, 0x13, 0x02, 0xdc, 0x3f, 0xc7, 0xe1,
+	0xff, 0x47, 0xc0, 0x88, 0x9c, 0x3e, 0x95, 0xc5, 0x43, 0x68, 0x48, 0xe8, 0xaf, 0x0b, 0xe8, 0xaf,
+	0xe0, 0xab, 0xf1, 0x48, 0xf6, 0x0c, 0x75, 0x83, 0x6f, 0x40, 0x52, 0xa4, 0xad, 0x1a, 0x99, 0x87,
+	0x9d, 0x5c, 0xfd, 0xdf, 0x81, 0x32, 0x12, 0xd1, 0xbc, 0x40, 0xa4, 0xe2, 0xd9, 0x61, 0x09, 0x8a,
+	0x5d, 0x98, 0xf0, 0x34, 0x19, 0x3e, 0xc8, 0x6e, 0xd0, 0xca, 0x94, 0xd3, 0x07, 0x0b, 0x49, 0xef,
+	0x05, 0xe1, 0x3d, 0x8f, 0xa7, 0x06, 0x7b, 0xc7, 0x1f, 0x21, 0x38, 0x12, 0xea, 0xd9, 0xf8, 0x5c,
+	0x84, 0xd5, 0xfe, 0xd9, 0x41, 0x59, 0x88, 0x23, 0x2a, 0x61, 0xcc, 0x09, 0x18, 0xb3, 0xb8, 0x30,
+	0x18, 0x06, 0xd3, 0xeb, 0x42, 0x09, 0xef, 0x43, 0xca, 0x6f, 0xb4, 0x38, 0x2a, 0xbc, 0xae, 0x7e,
+	0xae, 0x9c, 0x19, 0x22, 0x15, 0xdb, 0xbd, 0xef, 0xf4, 0x47, 0x04, 0xb8, 0xbf, 0x6d, 0x46, 0x66,
+	0x6e, 0x64, 0xd7, 0x57, 0x16, 0x0f, 0xa1, 0x11, 0xff, 0xd2, 0x31, 0x5d, 0xce, 0x0c, 0xfa, 0x5e,
+	0xcf, 0x4c, 0xb1, 0x8f, 0x3f, 0x40, 0x90, 0x96, 0x6d, 0x12, 0x9f, 0x39, 0x20, 0x35, 0x3a, 0x6d,
+	0x57, 0x99, 0x1b, 0x26, 0x16, 0xa7, 0x59, 0x75, 0x95, 0x58, 0x52, 0xb6, 0x4a, 0xab, 0x8f, 0xff,
+	0x28, 0x24, 0xbe, 0x6b, 0x15, 0x12, 0x8f, 0x5b, 0x05, 0xf4, 0xa4, 0x55, 0x40, 0xbf, 0xb7, 0x0a,
+	0xe8, 0xd3, 0xa7, 0x85, 0xc4, 0x93, 0xa7, 0x85, 0xc4, 0x6f, 0x4f, 0x0b, 0x89, 0xb7, 0xe7, 0x42,
+	0x7f, 0x7f, 0x56, 0x1c, 0x56, 0xbb, 0x1d, 0x58, 0x34, 0xf5, 0x07, 0xbe, 0x65, 0xf1, 0xa1, 0xae,
+	0x9c, 0x12, 0xdf, 0xd7, 0x2e, 0xfd, 0x3d, 0x00, 0xcd, 0xe9, 0xc4, 0xe9, 0x0f, 0x14, 0x00, 0x00,
 }
 
 func (this *QueryContractInfoResponse) Equal(that interface{}) bool {
@@ -1137,6 +1227,9 @@ func (this *CodeInfoResponse) Equal(that interface{}) bool {
 	if !this.InstantiatePermission.Equal(&that1.InstantiatePermission) {
 		return false
 	}
+	if this.Runtime != that1.Runtime {
+		return false
+	}
 	return true
 }
 func (this *QueryCodeResponse) Equal(that interface{}) bool {
@@ -1201,6 +1294,8 @@ type QueryClient interface {
 	Params(ctx context.Context, in *QueryParamsRequest, opts ...grpc.CallOption) (*QueryParamsResponse, error)
 	// ContractsByCreator gets the contracts by creator
 	ContractsByCreator(ctx context.Context, in *QueryContractsByCreatorRequest, opts ...grpc.CallOption) (*QueryContractsByCreatorResponse, error)
+	// CodeABI gets the wrap.info manifest and ABI of a Polywrap wrapper code
+	CodeABI(ctx context.Context, in *QueryCodeABIRequest, opts ...grpc.CallOption) (*QueryCodeABIResponse, error)
 }
 
 type queryClient struct {
@@ -1310,6 +1405,15 @@ func (c *queryClient) ContractsByCreator(ctx context.Context, in *QueryContracts
 	return out, nil
 }
 
+func (c *queryClient) CodeABI(ctx context.Context, in *QueryCodeABIRequest, opts ...grpc.CallOption) (*QueryCodeABIResponse, error) {
+	out := new(QueryCodeABIResponse)
+	err := c.cc.Invoke(ctx, "/cosmwasm.wasm.v1.Query/CodeABI", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QueryServer is the server API for Query service.
 type QueryServer interface {
 	// ContractInfo gets the contract meta data
@@ -1334,6 +1438,8 @@ type QueryServer interface {
 	Params(context.Context, *QueryParamsRequest) (*QueryParamsResponse, error)
 	// ContractsByCreator gets the contracts by creator
 	ContractsByCreator(context.Context, *QueryContractsByCreatorRequest) (*QueryContractsByCreatorResponse, error)
+	// CodeABI gets the wrap.info manifest and ABI of a Polywrap wrapper code
+	CodeABI(context.Context, *QueryCodeABIRequest) (*QueryCodeABIResponse, error)
 }
 
 // UnimplementedQueryServer can be embedded to have forward compatible implementations.
@@ -1373,6 +1479,9 @@ func (*UnimplementedQueryServer) Params(ctx context.Context, req *QueryParamsReq
 func (*UnimplementedQueryServer) ContractsByCreator(ctx context.Context, req *QueryContractsByCreatorRequest) (*QueryContractsByCreatorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ContractsByCreator not implemented")
 }
+func (*UnimplementedQueryServer) CodeABI(ctx context.Context, req *QueryCodeABIRequest) (*QueryCodeABIResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CodeABI not implemented")
+}
 
 func RegisterQueryServer(s grpc1.Server, srv QueryServer) {
 	s.RegisterService(&_Query_serviceDesc, srv)
@@ -1576,6 +1685,24 @@ func _Query_ContractsByCreator_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Query_CodeABI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryCodeABIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).CodeABI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmwasm.wasm.v1.Query/CodeABI",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).CodeABI(ctx, req.(*QueryCodeABIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Query_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "cosmwasm.wasm.v1.Query",
 	HandlerType: (*QueryServer)(nil),
@@ -1624,6 +1751,10 @@ var _Query_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ContractsByCreator",
 			Handler:    _Query_ContractsByCreator_Handler,
 		},
+		{
+			MethodName: "CodeABI",
+			Handler:    _Query_CodeABI_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cosmwasm/wasm/v1/query.proto",
@@ -2052,6 +2183,13 @@ func (m *QuerySmartContractStateRequest) MarshalToSizedBuffer(dAtA []byte) (int,
 	_ = i
 	var l int
 	_ = l
+	if len(m.Method) > 0 {
+		i -= len(m.Method)
+		copy(dAtA[i:], m.Method)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Method)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.QueryData) > 0 {
 		i -= len(m.QueryData)
 		copy(dAtA[i:], m.QueryData)
@@ -2147,6 +2285,11 @@ func (m *CodeInfoResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Runtime != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Runtime))
+		i--
+		dAtA[i] = 0x38
+	}
 	{
 		size, err := m.InstantiatePermission.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -2535,6 +2678,71 @@ func (m *QueryContractsByCreatorResponse) MarshalToSizedBuffer(dAtA []byte) (int
 	return len(dAtA) - i, nil
 }
 
+func (m *QueryCodeABIRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryCodeABIRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryCodeABIRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CodeId != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.CodeId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryCodeABIResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryCodeABIResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryCodeABIResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ABI) > 0 {
+		i -= len(m.ABI)
+		copy(dAtA[i:], m.ABI)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.ABI)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Manifest) > 0 {
+		i -= len(m.Manifest)
+		copy(dAtA[i:], m.Manifest)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Manifest)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
 	offset -= sovQuery(v)
 	base := offset
@@ -2725,6 +2933,10 @@ func (m *QuerySmartContractStateRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovQuery(uint64(l))
 	}
+	l = len(m.Method)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
 	return n
 }
 
@@ -2772,6 +2984,9 @@ func (m *CodeInfoResponse) Size() (n int) {
 	}
 	l = m.InstantiatePermission.Size()
 	n += 1 + l + sovQuery(uint64(l))
+	if m.Runtime != 0 {
+		n += 1 + sovQuery(uint64(m.Runtime))
+	}
 	return n
 }
 
@@ -2913,6 +3128,35 @@ func (m *QueryContractsByCreatorResponse) Size() (n int) {
 	return n
 }
 
+func (m *QueryCodeABIRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CodeId != 0 {
+		n += 1 + sovQuery(uint64(m.CodeId))
+	}
+	return n
+}
+
+func (m *QueryCodeABIResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Manifest)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	l = len(m.ABI)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
 func sovQuery(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -4110,6 +4354,38 @@ func (m *QuerySmartContractStateRequest) Unmarshal(dAtA []byte) error {
 				m.QueryData = []byte{}
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Method = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipQuery(dAtA[iNdEx:])
@@ -4431,6 +4707,25 @@ func (m *CodeInfoResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Runtime", wireType)
+			}
+			m.Runtime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Runtime |= Runtime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipQuery(dAtA[iNdEx:])
@@ -5395,6 +5690,193 @@ func (m *QueryContractsByCreatorResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *QueryCodeABIRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryCodeABIRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryCodeABIRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CodeId", wireType)
+			}
+			m.CodeId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CodeId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryCodeABIResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryCodeABIResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryCodeABIResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Manifest", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Manifest = append(m.Manifest[:0], dAtA[iNdEx:postIndex]...)
+			if m.Manifest == nil {
+				m.Manifest = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ABI", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ABI = append(m.ABI[:0], dAtA[iNdEx:postIndex]...)
+			if m.ABI == nil {
+				m.ABI = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipQuery(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
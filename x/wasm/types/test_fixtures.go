@@ -411,6 +411,31 @@ func ExecuteContractProposalFixture(mutators ...func(p *ExecuteContractProposal)
 	return p
 }
 
+func ExecuteContractBatchProposalFixture(mutators ...func(p *ExecuteContractBatchProposal)) *ExecuteContractBatchProposal {
+	const (
+		contractAddr = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
+		anyAddress   = "cosmos1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqs2m6sx4"
+	)
+
+	p := &ExecuteContractBatchProposal{
+		Title:       "Foo",
+		Description: "Bar",
+		RunAs:       anyAddress,
+		Calls: []ContractCall{
+			{Contract: contractAddr, Method: "setParams", Msg: []byte(`{"fee":"1"}`)},
+			{Contract: contractAddr, Msg: []byte(`{"do":"something"}`), Funds: sdk.Coins{{
+				Denom:  "stake",
+				Amount: sdk.NewInt(1),
+			}}},
+		},
+	}
+
+	for _, m := range mutators {
+		m(p)
+	}
+	return p
+}
+
 func UpdateAdminProposalFixture(mutators ...func(p *UpdateAdminProposal)) *UpdateAdminProposal {
 	const (
 		contractAddr = "cosmos14hj2tavq8fpesdwxxcu44rty3hh90vhujrvcmstl4zr3txmfvw9s4hmalr"
@@ -69,6 +69,10 @@ func (msg MsgStoreCode) ValidateBasic() error {
 			return sdkerrors.Wrap(err, "instantiate permission")
 		}
 	}
+
+	if err := msg.Runtime.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "runtime")
+	}
 	return nil
 }
 
@@ -151,6 +155,9 @@ func (msg MsgExecuteContract) ValidateBasic() error {
 	if !msg.Funds.IsValid() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "sentFunds")
 	}
+	if err := ValidateMethod(msg.Method); err != nil {
+		return err
+	}
 	if err := msg.Msg.ValidateBasic(); err != nil {
 		return sdkerrors.Wrap(err, "payload msg")
 	}
@@ -184,6 +191,83 @@ func (msg MsgExecuteContract) GetContract() string {
 	return msg.Contract
 }
 
+// GetMethod returns the wrapper method to execute
+func (msg MsgExecuteContract) GetMethod() string {
+	return msg.Method
+}
+
+// ValidateBasic performs the stateless checks of a single execution
+func (c ContractCall) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(c.Contract); err != nil {
+		return sdkerrors.Wrap(err, "contract")
+	}
+	if !c.Funds.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "sentFunds")
+	}
+	if err := ValidateMethod(c.Method); err != nil {
+		return err
+	}
+	if err := c.Msg.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "payload msg")
+	}
+	return nil
+}
+
+// validateContractCalls checks the calls of a batch
+func validateContractCalls(calls []ContractCall) error {
+	if len(calls) == 0 {
+		return sdkerrors.Wrap(ErrEmpty, "calls")
+	}
+	for i, c := range calls {
+		if err := c.ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "call %d", i)
+		}
+	}
+	return nil
+}
+
+func (msg MsgExecuteContractBatch) Route() string {
+	return RouterKey
+}
+
+func (msg MsgExecuteContractBatch) Type() string {
+	return "execute-batch"
+}
+
+func (msg MsgExecuteContractBatch) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return sdkerrors.Wrap(err, "sender")
+	}
+	return validateContractCalls(msg.Calls)
+}
+
+func (msg MsgExecuteContractBatch) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(&msg))
+}
+
+func (msg MsgExecuteContractBatch) GetSigners() []sdk.AccAddress {
+	senderAddr, err := sdk.AccAddressFromBech32(msg.Sender)
+	if err != nil { // should never happen as valid basic rejects invalid addresses
+		panic(err.Error())
+	}
+	return []sdk.AccAddress{senderAddr}
+}
+
+// Executions returns a MsgExecuteContract of the sender for every call in order
+func (msg MsgExecuteContractBatch) Executions() []*MsgExecuteContract {
+	r := make([]*MsgExecuteContract, len(msg.Calls))
+	for i, c := range msg.Calls {
+		r[i] = &MsgExecuteContract{
+			Sender:   msg.Sender,
+			Contract: c.Contract,
+			Msg:      c.Msg,
+			Funds:    c.Funds,
+			Method:   c.Method,
+		}
+	}
+	return r
+}
+
 func (msg MsgMigrateContract) Route() string {
 	return RouterKey
 }
@@ -39,6 +39,9 @@ type MsgStoreCode struct {
 	// InstantiatePermission access control to apply on contract creation,
 	// optional
 	InstantiatePermission *AccessConfig `protobuf:"bytes,5,opt,name=instantiate_permission,json=instantiatePermission,proto3" json:"instantiate_permission,omitempty"`
+	// Runtime engine that executes the code, detected from the wasm exports when
+	// unspecified
+	Runtime Runtime `protobuf:"varint,6,opt,name=runtime,proto3,enum=cosmwasm.wasm.v1.Runtime" json:"runtime,omitempty"`
 }
 
 func (m *MsgStoreCode) Reset()         { *m = MsgStoreCode{} }
@@ -388,6 +391,88 @@ func (m *MsgExecuteContractResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_MsgExecuteContractResponse proto.InternalMessageInfo
 
+// MsgExecuteContractBatch executes the calls in order in one transaction. All
+// state changes are reverted when any call fails.
+type MsgExecuteContractBatch struct {
+	// Sender is the that actor that signed the messages
+	Sender string `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	// Calls are the contract executions in the order they are run
+	Calls []ContractCall `protobuf:"bytes,2,rep,name=calls,proto3" json:"calls"`
+}
+
+func (m *MsgExecuteContractBatch) Reset()         { *m = MsgExecuteContractBatch{} }
+func (m *MsgExecuteContractBatch) String() string { return proto.CompactTextString(m) }
+func (*MsgExecuteContractBatch) ProtoMessage()    {}
+func (*MsgExecuteContractBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f74d82755520264, []int{8}
+}
+func (m *MsgExecuteContractBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgExecuteContractBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgExecuteContractBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgExecuteContractBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgExecuteContractBatch.Merge(m, src)
+}
+func (m *MsgExecuteContractBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgExecuteContractBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgExecuteContractBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgExecuteContractBatch proto.InternalMessageInfo
+
+// MsgExecuteContractBatchResponse returns the execution result data of every
+// call.
+type MsgExecuteContractBatchResponse struct {
+	// Data contains the bytes returned from the contract for each call in order
+	Data [][]byte `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *MsgExecuteContractBatchResponse) Reset()         { *m = MsgExecuteContractBatchResponse{} }
+func (m *MsgExecuteContractBatchResponse) String() string { return proto.CompactTextString(m) }
+func (*MsgExecuteContractBatchResponse) ProtoMessage()    {}
+func (*MsgExecuteContractBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4f74d82755520264, []int{9}
+}
+func (m *MsgExecuteContractBatchResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MsgExecuteContractBatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MsgExecuteContractBatchResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MsgExecuteContractBatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MsgExecuteContractBatchResponse.Merge(m, src)
+}
+func (m *MsgExecuteContractBatchResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *MsgExecuteContractBatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MsgExecuteContractBatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MsgExecuteContractBatchResponse proto.InternalMessageInfo
+
 // MsgMigrateContract runs a code upgrade/ downgrade for a smart contract
 type MsgMigrateContract struct {
 	// Sender is the that actor that signed the messages
@@ -404,7 +489,7 @@ func (m *MsgMigrateContract) Reset()         { *m = MsgMigrateContract{} }
 func (m *MsgMigrateContract) String() string { return proto.CompactTextString(m) }
 func (*MsgMigrateContract) ProtoMessage()    {}
 func (*MsgMigrateContract) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f74d82755520264, []int{8}
+	return fileDescriptor_4f74d82755520264, []int{10}
 }
 func (m *MsgMigrateContract) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -444,7 +529,7 @@ func (m *MsgMigrateContractResponse) Reset()         { *m = MsgMigrateContractRe
 func (m *MsgMigrateContractResponse) String() string { return proto.CompactTextString(m) }
 func (*MsgMigrateContractResponse) ProtoMessage()    {}
 func (*MsgMigrateContractResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f74d82755520264, []int{9}
+	return fileDescriptor_4f74d82755520264, []int{11}
 }
 func (m *MsgMigrateContractResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -487,7 +572,7 @@ func (m *MsgUpdateAdmin) Reset()         { *m = MsgUpdateAdmin{} }
 func (m *MsgUpdateAdmin) String() string { return proto.CompactTextString(m) }
 func (*MsgUpdateAdmin) ProtoMessage()    {}
 func (*MsgUpdateAdmin) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f74d82755520264, []int{10}
+	return fileDescriptor_4f74d82755520264, []int{12}
 }
 func (m *MsgUpdateAdmin) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -524,7 +609,7 @@ func (m *MsgUpdateAdminResponse) Reset()         { *m = MsgUpdateAdminResponse{}
 func (m *MsgUpdateAdminResponse) String() string { return proto.CompactTextString(m) }
 func (*MsgUpdateAdminResponse) ProtoMessage()    {}
 func (*MsgUpdateAdminResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f74d82755520264, []int{11}
+	return fileDescriptor_4f74d82755520264, []int{13}
 }
 func (m *MsgUpdateAdminResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -565,7 +650,7 @@ func (m *MsgClearAdmin) Reset()         { *m = MsgClearAdmin{} }
 func (m *MsgClearAdmin) String() string { return proto.CompactTextString(m) }
 func (*MsgClearAdmin) ProtoMessage()    {}
 func (*MsgClearAdmin) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f74d82755520264, []int{12}
+	return fileDescriptor_4f74d82755520264, []int{14}
 }
 func (m *MsgClearAdmin) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -602,7 +687,7 @@ func (m *MsgClearAdminResponse) Reset()         { *m = MsgClearAdminResponse{} }
 func (m *MsgClearAdminResponse) String() string { return proto.CompactTextString(m) }
 func (*MsgClearAdminResponse) ProtoMessage()    {}
 func (*MsgClearAdminResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f74d82755520264, []int{13}
+	return fileDescriptor_4f74d82755520264, []int{15}
 }
 func (m *MsgClearAdminResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -645,7 +730,7 @@ func (m *MsgUpdateInstantiateConfig) Reset()         { *m = MsgUpdateInstantiate
 func (m *MsgUpdateInstantiateConfig) String() string { return proto.CompactTextString(m) }
 func (*MsgUpdateInstantiateConfig) ProtoMessage()    {}
 func (*MsgUpdateInstantiateConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f74d82755520264, []int{14}
+	return fileDescriptor_4f74d82755520264, []int{16}
 }
 func (m *MsgUpdateInstantiateConfig) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -682,7 +767,7 @@ func (m *MsgUpdateInstantiateConfigResponse) Reset()         { *m = MsgUpdateIns
 func (m *MsgUpdateInstantiateConfigResponse) String() string { return proto.CompactTextString(m) }
 func (*MsgUpdateInstantiateConfigResponse) ProtoMessage()    {}
 func (*MsgUpdateInstantiateConfigResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4f74d82755520264, []int{15}
+	return fileDescriptor_4f74d82755520264, []int{17}
 }
 func (m *MsgUpdateInstantiateConfigResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -720,6 +805,8 @@ func init() {
 	proto.RegisterType((*MsgInstantiateContract2Response)(nil), "cosmwasm.wasm.v1.MsgInstantiateContract2Response")
 	proto.RegisterType((*MsgExecuteContract)(nil), "cosmwasm.wasm.v1.MsgExecuteContract")
 	proto.RegisterType((*MsgExecuteContractResponse)(nil), "cosmwasm.wasm.v1.MsgExecuteContractResponse")
+	proto.RegisterType((*MsgExecuteContractBatch)(nil), "cosmwasm.wasm.v1.MsgExecuteContractBatch")
+	proto.RegisterType((*MsgExecuteContractBatchResponse)(nil), "cosmwasm.wasm.v1.MsgExecuteContractBatchResponse")
 	proto.RegisterType((*MsgMigrateContract)(nil), "cosmwasm.wasm.v1.MsgMigrateContract")
 	proto.RegisterType((*MsgMigrateContractResponse)(nil), "cosmwasm.wasm.v1.MsgMigrateContractResponse")
 	proto.RegisterType((*MsgUpdateAdmin)(nil), "cosmwasm.wasm.v1.MsgUpdateAdmin")
@@ -733,65 +820,70 @@ func init() {
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/tx.proto", fileDescriptor_4f74d82755520264) }
 
 var fileDescriptor_4f74d82755520264 = []byte{
-	// 919 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x56, 0x4f, 0x6f, 0xe3, 0x44,
-	0x14, 0x8f, 0x9b, 0xc4, 0x4d, 0x5f, 0xc3, 0x52, 0x99, 0x6c, 0xea, 0x35, 0xc8, 0x89, 0xcc, 0x6a,
-	0x31, 0xd2, 0xe2, 0x34, 0x01, 0x71, 0x6f, 0xb2, 0x1c, 0xba, 0x92, 0x01, 0xb9, 0x5a, 0x2a, 0x10,
-	0x52, 0x34, 0xb1, 0x27, 0xae, 0xb5, 0xb5, 0x27, 0x64, 0xa6, 0x4d, 0x7a, 0xe0, 0x2b, 0x20, 0x6e,
-	0x7c, 0x07, 0xbe, 0x00, 0x17, 0x2e, 0x88, 0x4b, 0x8f, 0x7b, 0xe4, 0x54, 0x20, 0x3d, 0xf3, 0x05,
-	0x38, 0x21, 0x8f, 0xff, 0xd4, 0x4d, 0xed, 0x34, 0x05, 0x71, 0xda, 0x4b, 0x32, 0xcf, 0xf3, 0x7b,
-	0xff, 0x7e, 0xef, 0x3d, 0x3f, 0xc3, 0x23, 0x9b, 0x50, 0x7f, 0x86, 0xa8, 0xdf, 0xe1, 0x3f, 0x67,
-	0xdd, 0x0e, 0x9b, 0x1b, 0x93, 0x29, 0x61, 0x44, 0xda, 0x49, 0xae, 0x0c, 0xfe, 0x73, 0xd6, 0x55,
-	0xd4, 0xf0, 0x09, 0xa1, 0x9d, 0x11, 0xa2, 0xb8, 0x73, 0xd6, 0x1d, 0x61, 0x86, 0xba, 0x1d, 0x9b,
-	0x78, 0x41, 0xa4, 0xa1, 0x34, 0x5c, 0xe2, 0x12, 0x7e, 0xec, 0x84, 0xa7, 0xf8, 0xe9, 0x3b, 0xb7,
-	0x5d, 0x9c, 0x4f, 0x30, 0x8d, 0x6e, 0xb5, 0x5f, 0x04, 0xa8, 0x9b, 0xd4, 0x3d, 0x64, 0x64, 0x8a,
-	0x07, 0xc4, 0xc1, 0x52, 0x13, 0x44, 0x8a, 0x03, 0x07, 0x4f, 0x65, 0xa1, 0x2d, 0xe8, 0x5b, 0x56,
-	0x2c, 0x49, 0x1f, 0xc3, 0x83, 0x50, 0x7f, 0x38, 0x3a, 0x67, 0x78, 0x68, 0x13, 0x07, 0xcb, 0x1b,
-	0x6d, 0x41, 0xaf, 0xf7, 0x77, 0x16, 0x97, 0xad, 0xfa, 0xd1, 0xfe, 0xa1, 0xd9, 0x3f, 0x67, 0xdc,
-	0x82, 0x55, 0x0f, 0x71, 0x89, 0x24, 0xbd, 0x80, 0xa6, 0x17, 0x50, 0x86, 0x02, 0xe6, 0x21, 0x86,
-	0x87, 0x13, 0x3c, 0xf5, 0x3d, 0x4a, 0x3d, 0x12, 0xc8, 0xd5, 0xb6, 0xa0, 0x6f, 0xf7, 0x54, 0x63,
-	0x39, 0x4f, 0x63, 0xdf, 0xb6, 0x31, 0xa5, 0x03, 0x12, 0x8c, 0x3d, 0xd7, 0x7a, 0x98, 0xd1, 0xfe,
-	0x3c, 0x55, 0x7e, 0x5e, 0xa9, 0x95, 0x77, 0x2a, 0xcf, 0x2b, 0xb5, 0xca, 0x4e, 0x55, 0x3b, 0x82,
-	0x46, 0x36, 0x05, 0x0b, 0xd3, 0x09, 0x09, 0x28, 0x96, 0xde, 0x85, 0xcd, 0x30, 0xd0, 0xa1, 0xe7,
-	0xf0, 0x5c, 0x2a, 0x7d, 0x58, 0x5c, 0xb6, 0xc4, 0x10, 0x72, 0xf0, 0xcc, 0x12, 0xc3, 0xab, 0x03,
-	0x47, 0x52, 0xa0, 0x66, 0x1f, 0x63, 0xfb, 0x25, 0x3d, 0xf5, 0xa3, 0x8c, 0xac, 0x54, 0xd6, 0xbe,
-	0xdb, 0x80, 0xa6, 0x49, 0xdd, 0x83, 0xeb, 0x08, 0x06, 0x24, 0x60, 0x53, 0x64, 0xb3, 0x42, 0x9a,
-	0x1a, 0x50, 0x45, 0x8e, 0xef, 0x05, 0xdc, 0xd6, 0x96, 0x15, 0x09, 0xd9, 0x48, 0xca, 0x85, 0x91,
-	0x34, 0xa0, 0x7a, 0x82, 0x46, 0xf8, 0x44, 0xae, 0x44, 0xaa, 0x5c, 0x90, 0x74, 0x28, 0xfb, 0xd4,
-	0xe5, 0x64, 0xd5, 0xfb, 0xcd, 0xbf, 0x2f, 0x5b, 0x92, 0x85, 0x66, 0x49, 0x18, 0x26, 0xa6, 0x14,
-	0xb9, 0xd8, 0x0a, 0x21, 0x12, 0x82, 0xea, 0xf8, 0x34, 0x70, 0xa8, 0x2c, 0xb6, 0xcb, 0xfa, 0x76,
-	0xef, 0x91, 0x11, 0xb5, 0x8b, 0x11, 0xb6, 0x8b, 0x11, 0xb7, 0x8b, 0x31, 0x20, 0x5e, 0xd0, 0xdf,
-	0xbb, 0xb8, 0x6c, 0x95, 0x7e, 0xfc, 0xbd, 0xa5, 0xbb, 0x1e, 0x3b, 0x3e, 0x1d, 0x19, 0x36, 0xf1,
-	0x3b, 0x71, 0x6f, 0x45, 0x7f, 0x1f, 0x50, 0xe7, 0x65, 0xdc, 0x26, 0xa1, 0x02, 0xb5, 0x22, 0xcb,
-	0xda, 0xcf, 0x1b, 0xb0, 0x9b, 0x4f, 0x48, 0xef, 0xf5, 0x64, 0x44, 0x92, 0xa0, 0x42, 0xd1, 0x09,
-	0x93, 0x37, 0x79, 0xeb, 0xf0, 0xb3, 0xb4, 0x0b, 0x9b, 0x63, 0x6f, 0x3e, 0x0c, 0x83, 0xac, 0xb5,
-	0x05, 0xbd, 0x66, 0x89, 0x63, 0x6f, 0x6e, 0x52, 0x57, 0xfb, 0x14, 0xd4, 0x7c, 0xf6, 0xd2, 0x96,
-	0x95, 0x61, 0x13, 0x39, 0xce, 0x14, 0x53, 0x1a, 0xb3, 0x98, 0x88, 0xa1, 0x23, 0x07, 0x31, 0x14,
-	0xf7, 0x28, 0x3f, 0x6b, 0x9f, 0x41, 0xab, 0xa0, 0x1a, 0xff, 0xd2, 0xe0, 0x5f, 0x02, 0x48, 0x26,
-	0x75, 0x3f, 0x99, 0x63, 0xfb, 0x74, 0x8d, 0x66, 0x0f, 0x67, 0x27, 0xc6, 0xc4, 0xd5, 0x4d, 0xe5,
-	0xa4, 0x4a, 0xe5, 0x7b, 0x54, 0xa9, 0xfa, 0xbf, 0x55, 0xa9, 0x09, 0xa2, 0x8f, 0xd9, 0x31, 0x71,
-	0x64, 0x31, 0x4a, 0x20, 0x92, 0xb4, 0x3d, 0x50, 0x6e, 0xa7, 0x9b, 0x72, 0x97, 0x30, 0x24, 0x64,
-	0x18, 0xfa, 0x21, 0x62, 0xc8, 0xf4, 0xdc, 0x29, 0xfa, 0x8f, 0x0c, 0xad, 0x35, 0x02, 0x31, 0x8d,
-	0x95, 0x3b, 0x69, 0x8c, 0x73, 0x59, 0x0a, 0x6c, 0x65, 0x2e, 0x08, 0x1e, 0x98, 0xd4, 0x7d, 0x31,
-	0x71, 0x10, 0xc3, 0xfb, 0x7c, 0x2a, 0x8b, 0xd2, 0x78, 0x1b, 0xb6, 0x02, 0x3c, 0x1b, 0x66, 0xe7,
-	0xb8, 0x16, 0xe0, 0x59, 0xa4, 0x94, 0xcd, 0xb1, 0x7c, 0x33, 0x47, 0x4d, 0xe6, 0x2f, 0xd0, 0x8c,
-	0x8b, 0x24, 0x20, 0x6d, 0x00, 0x6f, 0x98, 0xd4, 0x1d, 0x9c, 0x60, 0x34, 0x5d, 0xed, 0x7b, 0x95,
-	0xf9, 0x5d, 0x78, 0x78, 0xc3, 0x48, 0x6a, 0xfd, 0x27, 0x81, 0xb3, 0x11, 0x39, 0xbe, 0x39, 0x20,
-	0x63, 0xcf, 0x2d, 0xf4, 0x95, 0x29, 0xc9, 0x46, 0x61, 0x49, 0xbe, 0x06, 0x25, 0x24, 0xa3, 0x60,
-	0xab, 0x95, 0xd7, 0xda, 0x6a, 0x72, 0x80, 0x67, 0x07, 0x79, 0x8b, 0x4d, 0x7b, 0x0c, 0x5a, 0x71,
-	0xe0, 0x49, 0x7e, 0xbd, 0x5f, 0x45, 0x28, 0x9b, 0xd4, 0x95, 0x0e, 0x61, 0xeb, 0x7a, 0x75, 0xe7,
-	0x38, 0xcd, 0xee, 0x45, 0xe5, 0xc9, 0xea, 0xfb, 0xb4, 0x57, 0xbe, 0x81, 0xb7, 0xf2, 0x56, 0x9e,
-	0x9e, 0xab, 0x9e, 0x83, 0x54, 0xf6, 0xd6, 0x45, 0xa6, 0x2e, 0x19, 0x34, 0x72, 0x97, 0xca, 0xfb,
-	0xeb, 0x5a, 0xea, 0x29, 0xdd, 0xb5, 0xa1, 0xa9, 0x57, 0x0c, 0x6f, 0x2e, 0xbf, 0xea, 0x1e, 0xe7,
-	0x5a, 0x59, 0x42, 0x29, 0x4f, 0xd7, 0x41, 0x65, 0xdd, 0x2c, 0xbf, 0x2f, 0xf2, 0xdd, 0x2c, 0xa1,
-	0x0a, 0xdc, 0x14, 0x8d, 0xf8, 0x97, 0xb0, 0x9d, 0x9d, 0xe5, 0x76, 0xae, 0x72, 0x06, 0xa1, 0xe8,
-	0x77, 0x21, 0x52, 0xd3, 0x5f, 0x00, 0x64, 0x26, 0xb5, 0x95, 0xab, 0x77, 0x0d, 0x50, 0xde, 0xbb,
-	0x03, 0x90, 0xda, 0xfd, 0x16, 0x76, 0x8b, 0x46, 0xf4, 0xe9, 0x8a, 0xe0, 0x6e, 0xa1, 0x95, 0x8f,
-	0xee, 0x83, 0x4e, 0xdc, 0xf7, 0x9f, 0x5d, 0xfc, 0xa9, 0x96, 0x2e, 0x16, 0xaa, 0xf0, 0x6a, 0xa1,
-	0x0a, 0x7f, 0x2c, 0x54, 0xe1, 0xfb, 0x2b, 0xb5, 0xf4, 0xea, 0x4a, 0x2d, 0xfd, 0x76, 0xa5, 0x96,
-	0xbe, 0x7a, 0x92, 0xd9, 0x32, 0x03, 0x42, 0xfd, 0xa3, 0xe4, 0x1b, 0xda, 0xe9, 0xcc, 0xa3, 0x6f,
-	0x69, 0xbe, 0x69, 0x46, 0x22, 0xff, 0x92, 0xfe, 0xf0, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x75,
-	0x4d, 0xbb, 0x21, 0xcc, 0x0b, 0x00, 0x00,
+	// 997 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x57, 0xcd, 0x6e, 0xe3, 0xd4,
+	0x17, 0x8f, 0xe3, 0x7c, 0x9e, 0xe6, 0xdf, 0x7f, 0x65, 0x32, 0x89, 0xc7, 0x20, 0x27, 0x32, 0xa3,
+	0xc1, 0x23, 0x95, 0xa4, 0xc9, 0x00, 0x0b, 0x76, 0x4d, 0x86, 0x45, 0x47, 0x32, 0x20, 0x57, 0x43,
+	0x05, 0x42, 0x8a, 0x6e, 0xec, 0x1b, 0xd7, 0x9a, 0xd8, 0x0e, 0xb9, 0x37, 0x4d, 0xba, 0xe0, 0x15,
+	0x10, 0x3b, 0xde, 0x81, 0x17, 0x40, 0x48, 0x3c, 0x40, 0x97, 0xb3, 0x64, 0x55, 0x20, 0x5d, 0xb3,
+	0x65, 0xc1, 0x0a, 0xf9, 0xb3, 0x6e, 0x62, 0xa7, 0x1e, 0x10, 0x2b, 0x36, 0xc9, 0x3d, 0xb9, 0xbf,
+	0xf3, 0xf5, 0x3b, 0xc7, 0xe7, 0xc4, 0xf0, 0x50, 0x73, 0x88, 0xb5, 0x44, 0xc4, 0xea, 0x7a, 0x1f,
+	0x17, 0xbd, 0x2e, 0x5d, 0x75, 0x66, 0x73, 0x87, 0x3a, 0xdc, 0x41, 0x78, 0xd5, 0xf1, 0x3e, 0x2e,
+	0x7a, 0x82, 0xe8, 0xfe, 0xe2, 0x90, 0xee, 0x18, 0x11, 0xdc, 0xbd, 0xe8, 0x8d, 0x31, 0x45, 0xbd,
+	0xae, 0xe6, 0x98, 0xb6, 0xaf, 0x21, 0xd4, 0x0d, 0xc7, 0x70, 0xbc, 0x63, 0xd7, 0x3d, 0x05, 0xbf,
+	0xbe, 0xb5, 0xed, 0xe2, 0x72, 0x86, 0x89, 0x7f, 0x2b, 0xfd, 0xc1, 0x40, 0x4d, 0x21, 0xc6, 0x29,
+	0x75, 0xe6, 0x78, 0xe8, 0xe8, 0x98, 0x6b, 0x40, 0x89, 0x60, 0x5b, 0xc7, 0x73, 0x9e, 0x69, 0x33,
+	0x72, 0x55, 0x0d, 0x24, 0xee, 0x03, 0xd8, 0x77, 0xf5, 0x47, 0xe3, 0x4b, 0x8a, 0x47, 0x9a, 0xa3,
+	0x63, 0x3e, 0xdf, 0x66, 0xe4, 0xda, 0xe0, 0x60, 0x7d, 0xdd, 0xaa, 0x9d, 0x1d, 0x9f, 0x2a, 0x83,
+	0x4b, 0xea, 0x59, 0x50, 0x6b, 0x2e, 0x2e, 0x94, 0xb8, 0x17, 0xd0, 0x30, 0x6d, 0x42, 0x91, 0x4d,
+	0x4d, 0x44, 0xf1, 0x68, 0x86, 0xe7, 0x96, 0x49, 0x88, 0xe9, 0xd8, 0x7c, 0xb1, 0xcd, 0xc8, 0x7b,
+	0x7d, 0xb1, 0xb3, 0x99, 0x67, 0xe7, 0x58, 0xd3, 0x30, 0x21, 0x43, 0xc7, 0x9e, 0x98, 0x86, 0xfa,
+	0x20, 0xa6, 0xfd, 0x69, 0xa4, 0xcc, 0x3d, 0x85, 0xf2, 0x7c, 0x61, 0x53, 0xd3, 0xc2, 0x7c, 0xa9,
+	0xcd, 0xc8, 0xfb, 0xfd, 0x87, 0xdb, 0x76, 0x54, 0x1f, 0xa0, 0x86, 0xc8, 0xe7, 0x85, 0x0a, 0x7b,
+	0x50, 0x78, 0x5e, 0xa8, 0x14, 0x0e, 0x8a, 0xd2, 0x19, 0xd4, 0xe3, 0x79, 0xab, 0x98, 0xcc, 0x1c,
+	0x9b, 0x60, 0xee, 0x6d, 0x28, 0xbb, 0xd9, 0x8d, 0x4c, 0xdd, 0x23, 0xa0, 0x30, 0x80, 0xf5, 0x75,
+	0xab, 0xe4, 0x42, 0x4e, 0x9e, 0xa9, 0x25, 0xf7, 0xea, 0x44, 0xe7, 0x04, 0xa8, 0x68, 0xe7, 0x58,
+	0x7b, 0x49, 0x16, 0x96, 0x4f, 0x83, 0x1a, 0xc9, 0xd2, 0x37, 0x79, 0x68, 0x28, 0xc4, 0x38, 0xb9,
+	0x0d, 0x7b, 0xe8, 0xd8, 0x74, 0x8e, 0x34, 0x9a, 0xca, 0x6d, 0x1d, 0x8a, 0x48, 0xb7, 0x4c, 0xdb,
+	0xb3, 0x55, 0x55, 0x7d, 0x21, 0x1e, 0x09, 0x9b, 0x1a, 0x49, 0x1d, 0x8a, 0x53, 0x34, 0xc6, 0x53,
+	0xbe, 0xe0, 0xab, 0x7a, 0x02, 0x27, 0x03, 0x6b, 0x11, 0xc3, 0x63, 0xb8, 0x36, 0x68, 0xfc, 0x79,
+	0xdd, 0xe2, 0x54, 0xb4, 0x0c, 0xc3, 0x50, 0x30, 0x21, 0xc8, 0xc0, 0xaa, 0x0b, 0xe1, 0x10, 0x14,
+	0x27, 0x0b, 0x5b, 0x27, 0x7c, 0xa9, 0xcd, 0xca, 0x7b, 0x01, 0x8b, 0x0e, 0xe9, 0xb8, 0x3d, 0xd6,
+	0x09, 0x7a, 0xac, 0x33, 0x74, 0x4c, 0x7b, 0x70, 0x74, 0x75, 0xdd, 0xca, 0x7d, 0xff, 0x4b, 0x4b,
+	0x36, 0x4c, 0x7a, 0xbe, 0x18, 0x77, 0x34, 0xc7, 0xea, 0x06, 0x0d, 0xe9, 0x7f, 0xbd, 0x4b, 0xf4,
+	0x97, 0x41, 0x6f, 0xb9, 0x0a, 0x44, 0xf5, 0x2d, 0x4b, 0x3f, 0xe5, 0xa1, 0x99, 0x4c, 0x48, 0xff,
+	0xbf, 0xc9, 0x08, 0xc7, 0x41, 0x81, 0xa0, 0x29, 0xe5, 0xcb, 0x5e, 0xeb, 0x78, 0x67, 0xae, 0x09,
+	0xe5, 0x89, 0xb9, 0x1a, 0xb9, 0x41, 0x56, 0xda, 0x8c, 0x5c, 0x51, 0x4b, 0x13, 0x73, 0xa5, 0x10,
+	0x43, 0xfa, 0x18, 0xc4, 0x64, 0xf6, 0xa2, 0x96, 0xe5, 0xa1, 0x8c, 0x74, 0x7d, 0x8e, 0x09, 0x09,
+	0x58, 0x0c, 0x45, 0xd7, 0x91, 0x8e, 0x28, 0x0a, 0x7a, 0xd4, 0x3b, 0x4b, 0x9f, 0x40, 0x2b, 0xa5,
+	0x1a, 0x7f, 0xd3, 0xe0, 0xef, 0x0c, 0x70, 0x0a, 0x31, 0x3e, 0x5a, 0x61, 0x6d, 0x91, 0xa1, 0xd9,
+	0xdd, 0x67, 0x27, 0xc0, 0x04, 0xd5, 0x8d, 0xe4, 0xb0, 0x4a, 0xec, 0x6b, 0x54, 0xa9, 0xf8, 0xaf,
+	0x55, 0xa9, 0x01, 0x25, 0x0b, 0xd3, 0x73, 0x47, 0xf7, 0x26, 0x4c, 0x55, 0x0d, 0x24, 0xe9, 0x08,
+	0x84, 0xed, 0x74, 0x23, 0xee, 0x42, 0x86, 0x98, 0x18, 0x43, 0x16, 0x34, 0xb7, 0x35, 0x06, 0x88,
+	0x6a, 0xe7, 0xa9, 0x2c, 0x7d, 0x08, 0x45, 0x0d, 0x4d, 0xa7, 0x84, 0xcf, 0xb7, 0xd9, 0xe4, 0x29,
+	0x19, 0xda, 0x19, 0xa2, 0xe9, 0x74, 0x50, 0x70, 0x93, 0x54, 0x7d, 0x15, 0xe9, 0x7d, 0x68, 0xa5,
+	0xb8, 0x4b, 0x88, 0x92, 0x8d, 0xa2, 0xfc, 0xce, 0xaf, 0xa3, 0x62, 0x1a, 0x73, 0xf4, 0x0f, 0xeb,
+	0x98, 0xe9, 0x41, 0x0d, 0x8a, 0x5d, 0xb8, 0xb7, 0xd8, 0x01, 0xe3, 0x1b, 0x81, 0xed, 0x64, 0x1c,
+	0xc1, 0xbe, 0x42, 0x8c, 0x17, 0x33, 0x1d, 0x51, 0x7c, 0xec, 0xcd, 0x8e, 0xb4, 0x34, 0xde, 0x84,
+	0xaa, 0x8d, 0x97, 0xa3, 0xf8, 0xb4, 0xa9, 0xd8, 0x78, 0xe9, 0x2b, 0xc5, 0x73, 0x64, 0xef, 0xe6,
+	0x28, 0xf1, 0xd0, 0xb8, 0xeb, 0x22, 0x0c, 0x48, 0x1a, 0xc2, 0xff, 0x14, 0x62, 0x0c, 0xa7, 0x18,
+	0xcd, 0x77, 0xfb, 0xde, 0x65, 0xbe, 0x09, 0x0f, 0xee, 0x18, 0x89, 0xac, 0xff, 0xc0, 0x80, 0x10,
+	0x39, 0xbe, 0xfb, 0x18, 0x4f, 0x4c, 0x23, 0xd5, 0x57, 0xac, 0x24, 0xf9, 0xd4, 0x92, 0x7c, 0x09,
+	0x82, 0x4b, 0x46, 0xca, 0xc2, 0x66, 0x33, 0x2d, 0x6c, 0xde, 0xc6, 0xcb, 0x93, 0xa4, 0x9d, 0x2d,
+	0x3d, 0x02, 0x29, 0x3d, 0xf0, 0x30, 0xbf, 0xfe, 0x8f, 0x65, 0x60, 0x15, 0x62, 0x70, 0xa7, 0x50,
+	0xbd, 0xfd, 0x57, 0x92, 0xe0, 0x34, 0xbe, 0xbd, 0x85, 0xc7, 0xbb, 0xef, 0xa3, 0x5e, 0xf9, 0x0a,
+	0xde, 0x48, 0x5a, 0xcc, 0x72, 0xa2, 0x7a, 0x02, 0x52, 0x38, 0xca, 0x8a, 0x8c, 0x5c, 0x52, 0xa8,
+	0x27, 0xae, 0xbe, 0x27, 0x59, 0x2d, 0xf5, 0x85, 0x5e, 0x66, 0x68, 0xe4, 0x15, 0xc3, 0xff, 0x37,
+	0x07, 0xf2, 0xa3, 0x44, 0x2b, 0x1b, 0x28, 0xe1, 0x30, 0x0b, 0x2a, 0x9e, 0x5c, 0xe2, 0x58, 0x7b,
+	0x92, 0xc5, 0x8a, 0x07, 0x15, 0x7a, 0x99, 0xa1, 0xf1, 0xe4, 0x36, 0xa7, 0x54, 0x72, 0x72, 0x1b,
+	0x28, 0xe1, 0x30, 0x0b, 0x2a, 0x72, 0xf3, 0x39, 0xec, 0xc5, 0x27, 0x48, 0x3b, 0x51, 0x39, 0x86,
+	0x10, 0xe4, 0xfb, 0x10, 0x91, 0xe9, 0xcf, 0x00, 0x62, 0xf3, 0xa1, 0x95, 0xa8, 0x77, 0x0b, 0x10,
+	0xde, 0xb9, 0x07, 0x10, 0xd9, 0xfd, 0x1a, 0x9a, 0x69, 0x83, 0xe1, 0x70, 0x47, 0x70, 0x5b, 0x68,
+	0xe1, 0xbd, 0xd7, 0x41, 0x87, 0xee, 0x07, 0xcf, 0xae, 0x7e, 0x13, 0x73, 0x57, 0x6b, 0x91, 0x79,
+	0xb5, 0x16, 0x99, 0x5f, 0xd7, 0x22, 0xf3, 0xed, 0x8d, 0x98, 0x7b, 0x75, 0x23, 0xe6, 0x7e, 0xbe,
+	0x11, 0x73, 0x5f, 0x3c, 0x8e, 0x6d, 0xe0, 0xa1, 0x43, 0xac, 0xb3, 0xf0, 0xa5, 0x44, 0xef, 0xae,
+	0xbc, 0x6f, 0x7f, 0x0b, 0x8f, 0x4b, 0xde, 0xab, 0xc9, 0xd3, 0xbf, 0x06, 0x00, 0xec, 0x19, 0xd7,
+	0x50, 0x1d, 0x0d, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -816,6 +908,9 @@ type MsgClient interface {
 	InstantiateContract2(ctx context.Context, in *MsgInstantiateContract2, opts ...grpc.CallOption) (*MsgInstantiateContract2Response, error)
 	// Execute submits the given message data to a smart contract
 	ExecuteContract(ctx context.Context, in *MsgExecuteContract, opts ...grpc.CallOption) (*MsgExecuteContractResponse, error)
+	// ExecuteContractBatch submits several contract executions that succeed or
+	// fail together
+	ExecuteContractBatch(ctx context.Context, in *MsgExecuteContractBatch, opts ...grpc.CallOption) (*MsgExecuteContractBatchResponse, error)
 	// Migrate runs a code upgrade/ downgrade for a smart contract
 	MigrateContract(ctx context.Context, in *MsgMigrateContract, opts ...grpc.CallOption) (*MsgMigrateContractResponse, error)
 	// UpdateAdmin sets a new   admin for a smart contract
@@ -870,6 +965,15 @@ func (c *msgClient) ExecuteContract(ctx context.Context, in *MsgExecuteContract,
 	return out, nil
 }
 
+func (c *msgClient) ExecuteContractBatch(ctx context.Context, in *MsgExecuteContractBatch, opts ...grpc.CallOption) (*MsgExecuteContractBatchResponse, error) {
+	out := new(MsgExecuteContractBatchResponse)
+	err := c.cc.Invoke(ctx, "/cosmwasm.wasm.v1.Msg/ExecuteContractBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *msgClient) MigrateContract(ctx context.Context, in *MsgMigrateContract, opts ...grpc.CallOption) (*MsgMigrateContractResponse, error) {
 	out := new(MsgMigrateContractResponse)
 	err := c.cc.Invoke(ctx, "/cosmwasm.wasm.v1.Msg/MigrateContract", in, out, opts...)
@@ -918,6 +1022,9 @@ type MsgServer interface {
 	InstantiateContract2(context.Context, *MsgInstantiateContract2) (*MsgInstantiateContract2Response, error)
 	// Execute submits the given message data to a smart contract
 	ExecuteContract(context.Context, *MsgExecuteContract) (*MsgExecuteContractResponse, error)
+	// ExecuteContractBatch submits several contract executions that succeed or
+	// fail together
+	ExecuteContractBatch(context.Context, *MsgExecuteContractBatch) (*MsgExecuteContractBatchResponse, error)
 	// Migrate runs a code upgrade/ downgrade for a smart contract
 	MigrateContract(context.Context, *MsgMigrateContract) (*MsgMigrateContractResponse, error)
 	// UpdateAdmin sets a new   admin for a smart contract
@@ -944,6 +1051,9 @@ func (*UnimplementedMsgServer) InstantiateContract2(ctx context.Context, req *Ms
 func (*UnimplementedMsgServer) ExecuteContract(ctx context.Context, req *MsgExecuteContract) (*MsgExecuteContractResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ExecuteContract not implemented")
 }
+func (*UnimplementedMsgServer) ExecuteContractBatch(ctx context.Context, req *MsgExecuteContractBatch) (*MsgExecuteContractBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteContractBatch not implemented")
+}
 func (*UnimplementedMsgServer) MigrateContract(ctx context.Context, req *MsgMigrateContract) (*MsgMigrateContractResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MigrateContract not implemented")
 }
@@ -1033,6 +1143,24 @@ func _Msg_ExecuteContract_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Msg_ExecuteContractBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MsgExecuteContractBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MsgServer).ExecuteContractBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cosmwasm.wasm.v1.Msg/ExecuteContractBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MsgServer).ExecuteContractBatch(ctx, req.(*MsgExecuteContractBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Msg_MigrateContract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MsgMigrateContract)
 	if err := dec(in); err != nil {
@@ -1125,6 +1253,10 @@ var _Msg_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ExecuteContract",
 			Handler:    _Msg_ExecuteContract_Handler,
 		},
+		{
+			MethodName: "ExecuteContractBatch",
+			Handler:    _Msg_ExecuteContractBatch_Handler,
+		},
 		{
 			MethodName: "MigrateContract",
 			Handler:    _Msg_MigrateContract_Handler,
@@ -1166,6 +1298,11 @@ func (m *MsgStoreCode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Runtime != 0 {
+		i = encodeVarintTx(dAtA, i, uint64(m.Runtime))
+		i--
+		dAtA[i] = 0x30
+	}
 	if m.InstantiatePermission != nil {
 		{
 			size, err := m.InstantiatePermission.MarshalToSizedBuffer(dAtA[:i])
@@ -1556,6 +1693,82 @@ func (m *MsgExecuteContractResponse) MarshalToSizedBuffer(dAtA []byte) (int, err
 	return len(dAtA) - i, nil
 }
 
+func (m *MsgExecuteContractBatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgExecuteContractBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgExecuteContractBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Calls) > 0 {
+		for iNdEx := len(m.Calls) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Calls[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTx(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintTx(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MsgExecuteContractBatchResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MsgExecuteContractBatchResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MsgExecuteContractBatchResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		for iNdEx := len(m.Data) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Data[iNdEx])
+			copy(dAtA[i:], m.Data[iNdEx])
+			i = encodeVarintTx(dAtA, i, uint64(len(m.Data[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *MsgMigrateContract) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1861,6 +2074,9 @@ func (m *MsgStoreCode) Size() (n int) {
 		l = m.InstantiatePermission.Size()
 		n += 1 + l + sovTx(uint64(l))
 	}
+	if m.Runtime != 0 {
+		n += 1 + sovTx(uint64(m.Runtime))
+	}
 	return n
 }
 
@@ -2033,6 +2249,40 @@ func (m *MsgExecuteContractResponse) Size() (n int) {
 	return n
 }
 
+func (m *MsgExecuteContractBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovTx(uint64(l))
+	}
+	if len(m.Calls) > 0 {
+		for _, e := range m.Calls {
+			l = e.Size()
+			n += 1 + l + sovTx(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MsgExecuteContractBatchResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		for _, b := range m.Data {
+			l = len(b)
+			n += 1 + l + sovTx(uint64(l))
+		}
+	}
+	return n
+}
+
 func (m *MsgMigrateContract) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2292,6 +2542,25 @@ func (m *MsgStoreCode) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Runtime", wireType)
+			}
+			m.Runtime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Runtime |= Runtime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTx(dAtA[iNdEx:])
@@ -3466,6 +3735,204 @@ func (m *MsgExecuteContractResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *MsgExecuteContractBatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgExecuteContractBatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgExecuteContractBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Calls", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Calls = append(m.Calls, ContractCall{})
+			if err := m.Calls[len(m.Calls)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MsgExecuteContractBatchResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTx
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MsgExecuteContractBatchResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MsgExecuteContractBatchResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTx
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTx
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTx
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data, make([]byte, postIndex-iNdEx))
+			copy(m.Data[len(m.Data)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTx(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTx
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *MsgMigrateContract) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
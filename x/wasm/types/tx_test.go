@@ -64,6 +64,22 @@ func TestStoreCodeValidation(t *testing.T) {
 			},
 			valid: false,
 		},
+		"with runtime": {
+			msg: MsgStoreCode{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Runtime:      RuntimePolywrap,
+			},
+			valid: true,
+		},
+		"unknown runtime": {
+			msg: MsgStoreCode{
+				Sender:       goodAddress,
+				WASMByteCode: []byte("foo"),
+				Runtime:      Runtime(100),
+			},
+			valid: false,
+		},
 	}
 
 	for name, tc := range cases {
@@ -417,6 +433,108 @@ func TestExecuteContractValidation(t *testing.T) {
 			},
 			valid: false,
 		},
+		"with method": {
+			msg: MsgExecuteContract{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+				Msg:      []byte(`{"newName": "Joe"}`),
+				Method:   "updateName",
+			},
+			valid: true,
+		},
+		"invalid method name": {
+			msg: MsgExecuteContract{
+				Sender:   goodAddress,
+				Contract: goodAddress,
+				Msg:      []byte(`{}`),
+				Method:   "update-name",
+			},
+			valid: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.msg.ValidateBasic()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestExecuteContractBatchValidation(t *testing.T) {
+	sdk.GetConfig().SetAddressVerifier(VerifyAddressLen())
+	bad, err := sdk.AccAddressFromHex("012345")
+	require.NoError(t, err)
+	badAddress := bad.String()
+	// proper address size
+	goodAddress := sdk.AccAddress(make([]byte, 20)).String()
+
+	cases := map[string]struct {
+		msg   MsgExecuteContractBatch
+		valid bool
+	}{
+		"empty": {
+			msg:   MsgExecuteContractBatch{},
+			valid: false,
+		},
+		"correct": {
+			msg: MsgExecuteContractBatch{
+				Sender: goodAddress,
+				Calls: []ContractCall{
+					{Contract: goodAddress, Msg: []byte(`{}`), Method: "init"},
+					{Contract: goodAddress, Msg: []byte(`{"some": "data"}`), Funds: sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdk.NewInt(200)}}},
+				},
+			},
+			valid: true,
+		},
+		"no calls": {
+			msg: MsgExecuteContractBatch{
+				Sender: goodAddress,
+			},
+			valid: false,
+		},
+		"bad sender": {
+			msg: MsgExecuteContractBatch{
+				Sender: badAddress,
+				Calls:  []ContractCall{{Contract: goodAddress, Msg: []byte(`{}`)}},
+			},
+			valid: false,
+		},
+		"bad contract": {
+			msg: MsgExecuteContractBatch{
+				Sender: goodAddress,
+				Calls: []ContractCall{
+					{Contract: goodAddress, Msg: []byte(`{}`)},
+					{Contract: badAddress, Msg: []byte(`{}`)},
+				},
+			},
+			valid: false,
+		},
+		"invalid method name": {
+			msg: MsgExecuteContractBatch{
+				Sender: goodAddress,
+				Calls:  []ContractCall{{Contract: goodAddress, Msg: []byte(`{}`), Method: "update-name"}},
+			},
+			valid: false,
+		},
+		"negative funds": {
+			msg: MsgExecuteContractBatch{
+				Sender: goodAddress,
+				Calls:  []ContractCall{{Contract: goodAddress, Msg: []byte(`{}`), Funds: sdk.Coins{sdk.Coin{Denom: "foobar", Amount: sdk.NewInt(-1)}}}},
+			},
+			valid: false,
+		},
+		"non json msg": {
+			msg: MsgExecuteContractBatch{
+				Sender: goodAddress,
+				Calls:  []ContractCall{{Contract: goodAddress, Msg: []byte("invalid-json")}},
+			},
+			valid: false,
+		},
 	}
 
 	for name, tc := range cases {
@@ -661,6 +779,14 @@ func TestMsgJsonSignBytes(t *testing.T) {
 {
 	"type":"wasm/MsgExecuteContract",
 	"value": {"msg": {"foo":"bar"}, "funds":[]}
+}`,
+		},
+		"MsgExecuteContractBatch": {
+			src: &MsgExecuteContractBatch{Calls: []ContractCall{{Method: "init", Msg: RawContractMessage(myInnerMsg)}}},
+			exp: `
+{
+	"type":"wasm/MsgExecuteContractBatch",
+	"value": {"calls": [{"method": "init", "msg": {"foo":"bar"}, "funds":[]}]}
 }`,
 		},
 		"MsgMigrateContract": {
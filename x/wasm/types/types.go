@@ -39,9 +39,29 @@ func (c CodeInfo) ValidateBasic() error {
 	if err := c.InstantiateConfig.ValidateBasic(); err != nil {
 		return sdkerrors.Wrap(err, "instantiate config")
 	}
+	if err := c.Runtime.ValidateBasic(); err != nil {
+		return sdkerrors.Wrap(err, "runtime")
+	}
+	return nil
+}
+
+// AllRuntimes lists the runtimes code can be stored for
+var AllRuntimes = []Runtime{RuntimeCosmWasm, RuntimePolywrap}
+
+// ValidateBasic rejects unknown runtimes. RuntimeUnspecified is valid and resolved when the code is stored.
+func (r Runtime) ValidateBasic() error {
+	if _, ok := Runtime_name[int32(r)]; !ok {
+		return sdkerrors.Wrapf(ErrInvalid, "unknown runtime: %d", r)
+	}
 	return nil
 }
 
+// IsPolywrap reports whether code of the runtime is a Polywrap wrapper. Code recorded without a runtime
+// was stored before CosmWasm code was supported, so it is a wrapper as well.
+func (r Runtime) IsPolywrap() bool {
+	return r == RuntimePolywrap || r == RuntimeUnspecified
+}
+
 // NewCodeInfo fills a new CodeInfo struct
 func NewCodeInfo(codeHash []byte, creator sdk.AccAddress, instantiatePermission AccessConfig) CodeInfo {
 	return CodeInfo{
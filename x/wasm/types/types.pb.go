@@ -8,6 +8,8 @@ import (
 	fmt "fmt"
 	_ "github.com/cosmos/cosmos-proto"
 	types "github.com/cosmos/cosmos-sdk/codec/types"
+	github_com_cosmos_cosmos_sdk_types "github.com/cosmos/cosmos-sdk/types"
+	types1 "github.com/cosmos/cosmos-sdk/types"
 	_ "github.com/cosmos/gogoproto/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
 	github_com_tendermint_tendermint_libs_bytes "github.com/tendermint/tendermint/libs/bytes"
@@ -64,6 +66,39 @@ func (AccessType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_e6155d98fa173e02, []int{0}
 }
 
+// Runtime selects the engine that executes the code
+type Runtime int32
+
+const (
+	// RuntimeUnspecified detects the runtime from the wasm exports when the code
+	// is stored
+	RuntimeUnspecified Runtime = 0
+	// RuntimeCosmWasm CosmWasm contract executed by wasmvm
+	RuntimeCosmWasm Runtime = 1
+	// RuntimePolywrap Polywrap wrapper executed by the polywrap VM
+	RuntimePolywrap Runtime = 2
+)
+
+var Runtime_name = map[int32]string{
+	0: "RUNTIME_UNSPECIFIED",
+	1: "RUNTIME_COSMWASM",
+	2: "RUNTIME_POLYWRAP",
+}
+
+var Runtime_value = map[string]int32{
+	"RUNTIME_UNSPECIFIED": 0,
+	"RUNTIME_COSMWASM":    1,
+	"RUNTIME_POLYWRAP":    2,
+}
+
+func (x Runtime) String() string {
+	return proto.EnumName(Runtime_name, int32(x))
+}
+
+func (Runtime) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{1}
+}
+
 // ContractCodeHistoryOperationType actions that caused a code change
 type ContractCodeHistoryOperationType int32
 
@@ -97,7 +132,7 @@ func (x ContractCodeHistoryOperationType) String() string {
 }
 
 func (ContractCodeHistoryOperationType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_e6155d98fa173e02, []int{1}
+	return fileDescriptor_e6155d98fa173e02, []int{2}
 }
 
 // AccessTypeParam
@@ -226,6 +261,8 @@ type CodeInfo struct {
 	Creator string `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
 	// InstantiateConfig access control to apply on contract creation, optional
 	InstantiateConfig AccessConfig `protobuf:"bytes,5,opt,name=instantiate_config,json=instantiateConfig,proto3" json:"instantiate_config"`
+	// Runtime engine that executes the code
+	Runtime Runtime `protobuf:"varint,6,opt,name=runtime,proto3,enum=cosmwasm.wasm.v1.Runtime" json:"runtime,omitempty"`
 }
 
 func (m *CodeInfo) Reset()         { *m = CodeInfo{} }
@@ -439,8 +476,95 @@ func (m *Model) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Model proto.InternalMessageInfo
 
+// SnapshotCode is the state sync snapshot item of a single code
+type SnapshotCode struct {
+	// Runtime engine that executes the code
+	Runtime Runtime `protobuf:"varint,1,opt,name=runtime,proto3,enum=cosmwasm.wasm.v1.Runtime" json:"runtime,omitempty"`
+	// WASMByteCode gzipped wasm byte code
+	WASMByteCode []byte `protobuf:"bytes,2,opt,name=wasm_byte_code,json=wasmByteCode,proto3" json:"wasm_byte_code,omitempty"`
+}
+
+func (m *SnapshotCode) Reset()         { *m = SnapshotCode{} }
+func (m *SnapshotCode) String() string { return proto.CompactTextString(m) }
+func (*SnapshotCode) ProtoMessage()    {}
+func (*SnapshotCode) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{8}
+}
+func (m *SnapshotCode) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotCode) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotCode.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SnapshotCode) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotCode.Merge(m, src)
+}
+func (m *SnapshotCode) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotCode) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotCode.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotCode proto.InternalMessageInfo
+
+// ContractCall is a single contract execution of a batch
+type ContractCall struct {
+	// Contract is the address of the smart contract
+	Contract string `protobuf:"bytes,1,opt,name=contract,proto3" json:"contract,omitempty"`
+	// Smart contract method to execute
+	Method string `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	// Msg json encoded message to be passed to the contract
+	Msg RawContractMessage `protobuf:"bytes,3,opt,name=msg,proto3,casttype=RawContractMessage" json:"msg,omitempty"`
+	// Funds coins that are transferred to the contract on execution
+	Funds github_com_cosmos_cosmos_sdk_types.Coins `protobuf:"bytes,4,rep,name=funds,proto3,castrepeated=github.com/cosmos/cosmos-sdk/types.Coins" json:"funds"`
+}
+
+func (m *ContractCall) Reset()         { *m = ContractCall{} }
+func (m *ContractCall) String() string { return proto.CompactTextString(m) }
+func (*ContractCall) ProtoMessage()    {}
+func (*ContractCall) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6155d98fa173e02, []int{9}
+}
+func (m *ContractCall) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ContractCall) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ContractCall.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ContractCall) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContractCall.Merge(m, src)
+}
+func (m *ContractCall) XXX_Size() int {
+	return m.Size()
+}
+func (m *ContractCall) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContractCall.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContractCall proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterEnum("cosmwasm.wasm.v1.AccessType", AccessType_name, AccessType_value)
+	proto.RegisterEnum("cosmwasm.wasm.v1.Runtime", Runtime_name, Runtime_value)
 	proto.RegisterEnum("cosmwasm.wasm.v1.ContractCodeHistoryOperationType", ContractCodeHistoryOperationType_name, ContractCodeHistoryOperationType_value)
 	proto.RegisterType((*AccessTypeParam)(nil), "cosmwasm.wasm.v1.AccessTypeParam")
 	proto.RegisterType((*AccessConfig)(nil), "cosmwasm.wasm.v1.AccessConfig")
@@ -450,86 +574,104 @@ func init() {
 	proto.RegisterType((*ContractCodeHistoryEntry)(nil), "cosmwasm.wasm.v1.ContractCodeHistoryEntry")
 	proto.RegisterType((*AbsoluteTxPosition)(nil), "cosmwasm.wasm.v1.AbsoluteTxPosition")
 	proto.RegisterType((*Model)(nil), "cosmwasm.wasm.v1.Model")
+	proto.RegisterType((*SnapshotCode)(nil), "cosmwasm.wasm.v1.SnapshotCode")
+	proto.RegisterType((*ContractCall)(nil), "cosmwasm.wasm.v1.ContractCall")
 }
 
 func init() { proto.RegisterFile("cosmwasm/wasm/v1/types.proto", fileDescriptor_e6155d98fa173e02) }
 
 var fileDescriptor_e6155d98fa173e02 = []byte{
-	// 1178 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcf, 0x8f, 0xdb, 0xc4,
-	0x17, 0x8f, 0x93, 0xec, 0x8f, 0x4c, 0xf7, 0xdb, 0xaf, 0x3b, 0xec, 0xd2, 0x6c, 0x58, 0x25, 0xa9,
-	0x29, 0xb0, 0xfd, 0x95, 0xd0, 0x05, 0x01, 0xea, 0xa1, 0x52, 0x7e, 0xb8, 0x5d, 0x57, 0x6c, 0x1c,
-	0x4d, 0x52, 0xaa, 0x45, 0xaa, 0x2c, 0xc7, 0x9e, 0xcd, 0x5a, 0x75, 0x3c, 0x91, 0x67, 0xb2, 0x8d,
-	0xff, 0x03, 0x14, 0x09, 0xc1, 0x91, 0x4b, 0x24, 0x04, 0x08, 0x95, 0x3b, 0x57, 0xee, 0x15, 0x5c,
-	0x7a, 0xe4, 0x14, 0xc1, 0xf6, 0xc2, 0x39, 0xc7, 0x72, 0x41, 0x9e, 0x89, 0x1b, 0xd3, 0x6e, 0xbb,
-	0xe1, 0x62, 0xcd, 0xbc, 0xf7, 0x3e, 0x9f, 0xf7, 0xde, 0x67, 0xe6, 0x8d, 0x0c, 0xb6, 0x2c, 0x42,
-	0x7b, 0x0f, 0x4d, 0xda, 0x2b, 0xf3, 0xcf, 0xd1, 0xf5, 0x32, 0x0b, 0xfa, 0x98, 0x96, 0xfa, 0x3e,
-	0x61, 0x04, 0xca, 0x91, 0xb7, 0xc4, 0x3f, 0x47, 0xd7, 0x73, 0x9b, 0xa1, 0x85, 0x50, 0x83, 0xfb,
-	0xcb, 0x62, 0x23, 0x82, 0x73, 0xeb, 0x5d, 0xd2, 0x25, 0xc2, 0x1e, 0xae, 0x66, 0xd6, 0xcd, 0x2e,
-	0x21, 0x5d, 0x17, 0x97, 0xf9, 0xae, 0x33, 0x38, 0x28, 0x9b, 0x5e, 0x20, 0x5c, 0xca, 0x7d, 0xf0,
-	0xff, 0x8a, 0x65, 0x61, 0x4a, 0xdb, 0x41, 0x1f, 0x37, 0x4d, 0xdf, 0xec, 0xc1, 0x3a, 0x58, 0x3a,
-	0x32, 0xdd, 0x01, 0xce, 0x4a, 0x45, 0x69, 0xfb, 0xec, 0xce, 0x56, 0xe9, 0xc5, 0x02, 0x4a, 0x73,
-	0x44, 0x55, 0x9e, 0x4e, 0x0a, 0x6b, 0x81, 0xd9, 0x73, 0x6f, 0x28, 0x1c, 0xa4, 0x20, 0x01, 0xbe,
-	0x91, 0xfe, 0xe6, 0xdb, 0x82, 0xa4, 0xfc, 0x26, 0x81, 0x35, 0x11, 0x5d, 0x23, 0xde, 0x81, 0xd3,
-	0x85, 0x2d, 0x00, 0xfa, 0xd8, 0xef, 0x39, 0x94, 0x3a, 0xc4, 0x5b, 0x28, 0xc3, 0xc6, 0x74, 0x52,
-	0x38, 0x27, 0x32, 0xcc, 0x91, 0x0a, 0x8a, 0xd1, 0xc0, 0xab, 0x60, 0xc5, 0xb4, 0x6d, 0x1f, 0x53,
-	0x9a, 0x4d, 0x16, 0xa5, 0xed, 0x4c, 0x15, 0x4e, 0x27, 0x85, 0xb3, 0x02, 0x33, 0x73, 0x28, 0x28,
-	0x0a, 0x81, 0x3b, 0x20, 0x33, 0x5b, 0x62, 0x9a, 0x4d, 0x15, 0x53, 0xdb, 0x99, 0xea, 0xfa, 0x74,
-	0x52, 0x90, 0xff, 0x15, 0x8f, 0xa9, 0x82, 0xe6, 0x61, 0xb3, 0x6e, 0xbe, 0x4a, 0x82, 0x65, 0xae,
-	0x11, 0x85, 0x04, 0x40, 0x8b, 0xd8, 0xd8, 0x18, 0xf4, 0x5d, 0x62, 0xda, 0x86, 0xc9, 0xeb, 0xe5,
-	0xfd, 0x9c, 0xd9, 0xc9, 0xbf, 0xaa, 0x1f, 0xa1, 0x41, 0xf5, 0xc2, 0xe3, 0x49, 0x21, 0x31, 0x9d,
-	0x14, 0x36, 0x45, 0xc6, 0x97, 0x79, 0x14, 0x24, 0x87, 0xc6, 0xbb, 0xdc, 0x26, 0xa0, 0xf0, 0x4b,
-	0x09, 0xe4, 0x1d, 0x8f, 0x32, 0xd3, 0x63, 0x8e, 0xc9, 0xb0, 0x61, 0xe3, 0x03, 0x73, 0xe0, 0x32,
-	0x23, 0xa6, 0x66, 0x72, 0x01, 0x35, 0x2f, 0x4d, 0x27, 0x85, 0x77, 0x44, 0xde, 0xd7, 0xb3, 0x29,
-	0x68, 0x2b, 0x16, 0x50, 0x17, 0xfe, 0xe6, 0x73, 0x37, 0x57, 0x24, 0xa1, 0x7c, 0x27, 0x81, 0xd5,
-	0x1a, 0xb1, 0xb1, 0xe6, 0x1d, 0x10, 0xf8, 0x16, 0xc8, 0xf0, 0x5e, 0x0e, 0x4d, 0x7a, 0xc8, 0xa5,
-	0x58, 0x43, 0xab, 0xa1, 0x61, 0xd7, 0xa4, 0x87, 0x30, 0x0b, 0x56, 0x2c, 0x1f, 0x9b, 0x8c, 0xf8,
-	0xe2, 0x8c, 0x50, 0xb4, 0x85, 0x2d, 0x00, 0xe3, 0xa5, 0x58, 0x5c, 0xa4, 0xec, 0xd2, 0x42, 0x52,
-	0xa6, 0x43, 0x29, 0xd1, 0xb9, 0x18, 0x5e, 0x38, 0xee, 0xa4, 0x57, 0x53, 0x72, 0xfa, 0x4e, 0x7a,
-	0x35, 0x2d, 0x2f, 0x29, 0xbf, 0x24, 0xc1, 0x5a, 0x8d, 0x78, 0xcc, 0x37, 0x2d, 0xc6, 0x0b, 0x7d,
-	0x1b, 0xac, 0xf0, 0x42, 0x1d, 0x9b, 0x97, 0x99, 0xae, 0x82, 0xe3, 0x49, 0x61, 0x99, 0xf7, 0x51,
-	0x47, 0xcb, 0xa1, 0x4b, 0xb3, 0x5f, 0x53, 0xf0, 0x3a, 0x58, 0x32, 0xed, 0x9e, 0xe3, 0x65, 0x53,
-	0xdc, 0x2e, 0x36, 0xa1, 0xd5, 0x35, 0x3b, 0xd8, 0xcd, 0xa6, 0x85, 0x95, 0x6f, 0xe0, 0xcd, 0x19,
-	0x0b, 0xb6, 0x67, 0x1d, 0x5d, 0x3c, 0xa1, 0xa3, 0x0e, 0x25, 0xee, 0x80, 0xe1, 0xf6, 0xb0, 0x49,
-	0xa8, 0xc3, 0x1c, 0xe2, 0xa1, 0x08, 0x04, 0xaf, 0x81, 0x33, 0x4e, 0xc7, 0x32, 0xfa, 0xc4, 0x67,
-	0x61, 0xb9, 0xcb, 0xfc, 0x7a, 0xff, 0xef, 0x78, 0x52, 0xc8, 0x68, 0xd5, 0x5a, 0x93, 0xf8, 0x4c,
-	0xab, 0xa3, 0x8c, 0xd3, 0xb1, 0xf8, 0xd2, 0x86, 0x7b, 0x20, 0x83, 0x87, 0x0c, 0x7b, 0xfc, 0x3e,
-	0xac, 0xf0, 0x84, 0xeb, 0x25, 0x31, 0xfd, 0xa5, 0x68, 0xfa, 0x4b, 0x15, 0x2f, 0xa8, 0x6e, 0xfe,
-	0xfa, 0xf3, 0xb5, 0x8d, 0xb8, 0x28, 0x6a, 0x04, 0x43, 0x73, 0x86, 0x1b, 0xe9, 0xbf, 0xc2, 0x6b,
-	0xff, 0xb7, 0x04, 0xb2, 0x51, 0x68, 0x28, 0xd2, 0xae, 0x43, 0x19, 0xf1, 0x03, 0xd5, 0x63, 0x7e,
-	0x00, 0x9b, 0x20, 0x43, 0xfa, 0xd8, 0x37, 0xd9, 0x7c, 0x9e, 0x77, 0x5e, 0x6e, 0xf1, 0x04, 0xb8,
-	0x1e, 0xa1, 0xc2, 0x7b, 0x89, 0xe6, 0x24, 0xf1, 0xd3, 0x49, 0xbe, 0xf2, 0x74, 0x6e, 0x82, 0x95,
-	0x41, 0xdf, 0xe6, 0xba, 0xa6, 0xfe, 0x8b, 0xae, 0x33, 0x10, 0xdc, 0x06, 0xa9, 0x1e, 0xed, 0xf2,
-	0xb3, 0x5a, 0xab, 0xbe, 0xf9, 0x6c, 0x52, 0x80, 0xc8, 0x7c, 0x18, 0x55, 0xb9, 0x87, 0x29, 0x35,
-	0xbb, 0x18, 0x85, 0x21, 0x0a, 0x02, 0xf0, 0x65, 0x22, 0x78, 0x01, 0xac, 0x75, 0x5c, 0x62, 0x3d,
-	0x30, 0x0e, 0xb1, 0xd3, 0x3d, 0x64, 0xe2, 0x1e, 0xa1, 0x33, 0xdc, 0xb6, 0xcb, 0x4d, 0x70, 0x13,
-	0xac, 0xb2, 0xa1, 0xe1, 0x78, 0x36, 0x1e, 0x8a, 0x46, 0xd0, 0x0a, 0x1b, 0x6a, 0xe1, 0x56, 0x71,
-	0xc0, 0xd2, 0x1e, 0xb1, 0xb1, 0x0b, 0xef, 0x80, 0xd4, 0x03, 0x1c, 0x88, 0x61, 0xa9, 0x7e, 0xf2,
-	0x6c, 0x52, 0xf8, 0xb0, 0xeb, 0xb0, 0xc3, 0x41, 0xa7, 0x64, 0x91, 0x5e, 0x99, 0x61, 0xcf, 0x0e,
-	0x07, 0xce, 0x63, 0xf1, 0xa5, 0xeb, 0x74, 0x68, 0xb9, 0x13, 0x30, 0x4c, 0x4b, 0xbb, 0x78, 0x58,
-	0x0d, 0x17, 0x28, 0x24, 0x09, 0x2f, 0xa0, 0x78, 0xb7, 0x93, 0x7c, 0xf4, 0xc4, 0xe6, 0xf2, 0x4f,
-	0x49, 0x00, 0xe6, 0xf3, 0x0f, 0x3f, 0x02, 0xe7, 0x2b, 0xb5, 0x9a, 0xda, 0x6a, 0x19, 0xed, 0xfd,
-	0xa6, 0x6a, 0xdc, 0x6d, 0xb4, 0x9a, 0x6a, 0x4d, 0xbb, 0xa5, 0xa9, 0x75, 0x39, 0x91, 0xdb, 0x1c,
-	0x8d, 0x8b, 0x1b, 0xf3, 0xe0, 0xbb, 0x1e, 0xed, 0x63, 0xcb, 0x39, 0x70, 0xb0, 0x0d, 0xaf, 0x02,
-	0x18, 0xc7, 0x35, 0xf4, 0xaa, 0x5e, 0xdf, 0x97, 0xa5, 0xdc, 0xfa, 0x68, 0x5c, 0x94, 0xe7, 0x90,
-	0x06, 0xe9, 0x10, 0x3b, 0x80, 0x1f, 0x83, 0x6c, 0x3c, 0x5a, 0x6f, 0x7c, 0xba, 0x6f, 0x54, 0xea,
-	0x75, 0xa4, 0xb6, 0x5a, 0x72, 0xf2, 0xc5, 0x34, 0xba, 0xe7, 0x06, 0x95, 0xe7, 0x6f, 0xf3, 0x46,
-	0x1c, 0xa8, 0x7e, 0xa6, 0xa2, 0x7d, 0x9e, 0x29, 0x95, 0x3b, 0x3f, 0x1a, 0x17, 0xdf, 0x98, 0xa3,
-	0xd4, 0x23, 0xec, 0x07, 0x3c, 0xd9, 0x4d, 0xb0, 0x15, 0xc7, 0x54, 0x1a, 0xfb, 0x86, 0x7e, 0x2b,
-	0x4a, 0xa7, 0xb6, 0xe4, 0x74, 0x6e, 0x6b, 0x34, 0x2e, 0x66, 0xe7, 0xd0, 0x8a, 0x17, 0xe8, 0x07,
-	0x95, 0xe8, 0x6d, 0xcf, 0xad, 0x7e, 0xf1, 0x7d, 0x3e, 0xf1, 0xe8, 0x87, 0x7c, 0xe2, 0xf2, 0x8f,
-	0x29, 0x50, 0x3c, 0xed, 0xa6, 0x42, 0x0c, 0xde, 0xaf, 0xe9, 0x8d, 0x36, 0xaa, 0xd4, 0xda, 0x46,
-	0x4d, 0xaf, 0xab, 0xc6, 0xae, 0xd6, 0x6a, 0xeb, 0x68, 0xdf, 0xd0, 0x9b, 0x2a, 0xaa, 0xb4, 0x35,
-	0xbd, 0x71, 0x92, 0xb4, 0xe5, 0xd1, 0xb8, 0x78, 0xe5, 0x34, 0xee, 0xb8, 0xe0, 0xf7, 0xc0, 0xa5,
-	0x85, 0xd2, 0x68, 0x0d, 0xad, 0x2d, 0x4b, 0xb9, 0xed, 0xd1, 0xb8, 0x78, 0xf1, 0x34, 0x7e, 0xcd,
-	0x73, 0x18, 0xbc, 0x0f, 0xae, 0x2e, 0x44, 0xbc, 0xa7, 0xdd, 0x46, 0x95, 0xb6, 0x2a, 0x27, 0x73,
-	0x57, 0x46, 0xe3, 0xe2, 0x7b, 0xa7, 0x71, 0xef, 0x39, 0x5d, 0xdf, 0x64, 0x78, 0x61, 0xfa, 0xdb,
-	0x6a, 0x43, 0x6d, 0x69, 0x2d, 0x39, 0xb5, 0x18, 0xfd, 0x6d, 0xec, 0x61, 0xea, 0xd0, 0x5c, 0x3a,
-	0x3c, 0xac, 0xea, 0xee, 0xe3, 0x3f, 0xf3, 0x89, 0x47, 0xc7, 0x79, 0xe9, 0xf1, 0x71, 0x5e, 0x7a,
-	0x72, 0x9c, 0x97, 0xfe, 0x38, 0xce, 0x4b, 0x5f, 0x3f, 0xcd, 0x27, 0x9e, 0x3c, 0xcd, 0x27, 0x7e,
-	0x7f, 0x9a, 0x4f, 0x7c, 0xfe, 0x6e, 0x6c, 0x8e, 0x6a, 0x84, 0xf6, 0xee, 0x45, 0xbf, 0x57, 0x76,
-	0x79, 0x28, 0x7e, 0xb3, 0xf8, 0x3f, 0x56, 0x67, 0x99, 0xbf, 0x8a, 0x1f, 0xfc, 0x13, 0x00, 0x00,
-	0xff, 0xff, 0x7a, 0x16, 0x8c, 0xd9, 0x84, 0x09, 0x00, 0x00,
+	// 1429 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x4d, 0x6c, 0x1b, 0xc5,
+	0x17, 0xf7, 0xda, 0x4e, 0x1c, 0x4f, 0xfc, 0x6f, 0xb7, 0xd3, 0xa4, 0x75, 0xfc, 0x8f, 0x6c, 0x77,
+	0xff, 0xfd, 0x43, 0xfa, 0x65, 0x37, 0x29, 0x2a, 0xa8, 0x87, 0x4a, 0xfe, 0xd8, 0x36, 0x5b, 0xd5,
+	0x1f, 0x1a, 0x3b, 0x44, 0x41, 0xaa, 0x56, 0x6b, 0xef, 0xc4, 0x5e, 0x75, 0xbd, 0x63, 0xed, 0xac,
+	0xd3, 0xf8, 0xc8, 0x0d, 0x59, 0x42, 0x70, 0x42, 0x5c, 0x2c, 0x21, 0x81, 0x50, 0xe1, 0xcc, 0x95,
+	0x7b, 0x05, 0x07, 0x7a, 0x83, 0x93, 0x01, 0xf7, 0xc2, 0x39, 0xc7, 0x72, 0x41, 0x3b, 0xb3, 0x5b,
+	0x6f, 0x9b, 0xb6, 0x31, 0x17, 0xef, 0xbc, 0x8f, 0xdf, 0x7b, 0xf3, 0x7e, 0x6f, 0xde, 0x8c, 0xc1,
+	0x7a, 0x9b, 0xd0, 0xde, 0x23, 0x8d, 0xf6, 0xf2, 0xec, 0xe7, 0x60, 0x33, 0xef, 0x0c, 0xfb, 0x98,
+	0xe6, 0xfa, 0x36, 0x71, 0x08, 0x14, 0x7d, 0x6b, 0x8e, 0xfd, 0x1c, 0x6c, 0xa6, 0xd6, 0x5c, 0x0d,
+	0xa1, 0x2a, 0xb3, 0xe7, 0xb9, 0xc0, 0x9d, 0x53, 0x69, 0x2e, 0xe5, 0x5b, 0x1a, 0xc5, 0xf9, 0x83,
+	0xcd, 0x16, 0x76, 0xb4, 0xcd, 0x7c, 0x9b, 0x18, 0x96, 0x67, 0x5f, 0xe9, 0x90, 0x0e, 0xe1, 0x38,
+	0x77, 0xe5, 0x69, 0xd7, 0x3a, 0x84, 0x74, 0x4c, 0x9c, 0x67, 0x52, 0x6b, 0xb0, 0x9f, 0xd7, 0xac,
+	0x21, 0x37, 0x49, 0x0f, 0xc0, 0xe9, 0x42, 0xbb, 0x8d, 0x29, 0x6d, 0x0e, 0xfb, 0xb8, 0xae, 0xd9,
+	0x5a, 0x0f, 0x96, 0xc1, 0xc2, 0x81, 0x66, 0x0e, 0x70, 0x52, 0xc8, 0x0a, 0x1b, 0xa7, 0xb6, 0xd6,
+	0x73, 0xaf, 0x6e, 0x30, 0x37, 0x43, 0x14, 0xc5, 0xa3, 0x49, 0x26, 0x31, 0xd4, 0x7a, 0xe6, 0x2d,
+	0x89, 0x81, 0x24, 0xc4, 0xc1, 0xb7, 0xa2, 0x5f, 0x7e, 0x95, 0x11, 0xa4, 0x9f, 0x05, 0x90, 0xe0,
+	0xde, 0x25, 0x62, 0xed, 0x1b, 0x1d, 0xd8, 0x00, 0xa0, 0x8f, 0xed, 0x9e, 0x41, 0xa9, 0x41, 0xac,
+	0xb9, 0x32, 0xac, 0x1e, 0x4d, 0x32, 0x67, 0x78, 0x86, 0x19, 0x52, 0x42, 0x81, 0x30, 0xf0, 0x2a,
+	0x88, 0x69, 0xba, 0x6e, 0x63, 0x4a, 0x93, 0xe1, 0xac, 0xb0, 0x11, 0x2f, 0xc2, 0xa3, 0x49, 0xe6,
+	0x14, 0xc7, 0x78, 0x06, 0x09, 0xf9, 0x2e, 0x70, 0x0b, 0xc4, 0xbd, 0x25, 0xa6, 0xc9, 0x48, 0x36,
+	0xb2, 0x11, 0x2f, 0xae, 0x1c, 0x4d, 0x32, 0xe2, 0x4b, 0xfe, 0x98, 0x4a, 0x68, 0xe6, 0xe6, 0x55,
+	0xf3, 0x59, 0x18, 0x2c, 0x32, 0x8e, 0x28, 0x24, 0x00, 0xb6, 0x89, 0x8e, 0xd5, 0x41, 0xdf, 0x24,
+	0x9a, 0xae, 0x6a, 0x6c, 0xbf, 0xac, 0x9e, 0xe5, 0xad, 0xf4, 0x9b, 0xea, 0xe1, 0x1c, 0x14, 0x2f,
+	0x3c, 0x99, 0x64, 0x42, 0x47, 0x93, 0xcc, 0x1a, 0xcf, 0x78, 0x3c, 0x8e, 0x84, 0x44, 0x57, 0xb9,
+	0xc3, 0x74, 0x1c, 0x0a, 0x3f, 0x15, 0x40, 0xda, 0xb0, 0xa8, 0xa3, 0x59, 0x8e, 0xa1, 0x39, 0x58,
+	0xd5, 0xf1, 0xbe, 0x36, 0x30, 0x1d, 0x35, 0xc0, 0x66, 0x78, 0x0e, 0x36, 0x2f, 0x1d, 0x4d, 0x32,
+	0xff, 0xe7, 0x79, 0xdf, 0x1e, 0x4d, 0x42, 0xeb, 0x01, 0x87, 0x32, 0xb7, 0xd7, 0x5f, 0x98, 0x19,
+	0x23, 0x21, 0xe9, 0x57, 0x01, 0x2c, 0x95, 0x88, 0x8e, 0x15, 0x6b, 0x9f, 0xc0, 0xff, 0x82, 0x38,
+	0xab, 0xa5, 0xab, 0xd1, 0x2e, 0xa3, 0x22, 0x81, 0x96, 0x5c, 0xc5, 0xb6, 0x46, 0xbb, 0x30, 0x09,
+	0x62, 0x6d, 0x1b, 0x6b, 0x0e, 0xb1, 0x79, 0x8f, 0x90, 0x2f, 0xc2, 0x06, 0x80, 0xc1, 0xad, 0xb4,
+	0x19, 0x49, 0xc9, 0x85, 0xb9, 0xa8, 0x8c, 0xba, 0x54, 0xa2, 0x33, 0x01, 0xbc, 0x77, 0xce, 0x6e,
+	0x80, 0x98, 0x3d, 0xb0, 0x1c, 0xa3, 0x87, 0x93, 0x8b, 0x8c, 0x96, 0xb5, 0xe3, 0x91, 0x10, 0x77,
+	0x40, 0xbe, 0xe7, 0xbd, 0xe8, 0x52, 0x44, 0x8c, 0xde, 0x8b, 0x2e, 0x45, 0xc5, 0x05, 0xe9, 0xc7,
+	0x30, 0x48, 0x94, 0x88, 0xe5, 0xd8, 0x5a, 0xdb, 0x61, 0xd5, 0xfd, 0x0f, 0xc4, 0x58, 0x75, 0x86,
+	0xce, 0x6a, 0x8b, 0x16, 0xc1, 0x74, 0x92, 0x59, 0x64, 0xc5, 0x97, 0xd1, 0xa2, 0x6b, 0x52, 0xf4,
+	0xb7, 0x54, 0xb9, 0x02, 0x16, 0x34, 0xbd, 0x67, 0x58, 0xc9, 0x08, 0xd3, 0x73, 0xc1, 0xd5, 0x9a,
+	0x5a, 0x0b, 0x9b, 0xc9, 0x28, 0xd7, 0x32, 0x01, 0xde, 0xf6, 0xa2, 0x60, 0xdd, 0xa3, 0xe1, 0xe2,
+	0x6b, 0x68, 0x68, 0x51, 0x62, 0x0e, 0x1c, 0xdc, 0x3c, 0xac, 0x13, 0x6a, 0x38, 0x06, 0xb1, 0x90,
+	0x0f, 0x82, 0xd7, 0xc0, 0xb2, 0xd1, 0x6a, 0xab, 0x7d, 0x62, 0x3b, 0xee, 0x76, 0x17, 0xd9, 0x4c,
+	0xfc, 0x67, 0x3a, 0xc9, 0xc4, 0x95, 0x62, 0xa9, 0x4e, 0x6c, 0x47, 0x29, 0xa3, 0xb8, 0xd1, 0x6a,
+	0xb3, 0xa5, 0x0e, 0x2b, 0x20, 0x8e, 0x0f, 0x1d, 0x6c, 0xb1, 0x43, 0x14, 0x63, 0x09, 0x57, 0x72,
+	0xfc, 0xca, 0xc8, 0xf9, 0x57, 0x46, 0xae, 0x60, 0x0d, 0x8b, 0x6b, 0x3f, 0xfd, 0x70, 0x6d, 0x35,
+	0x48, 0x8a, 0xec, 0xc3, 0xd0, 0x2c, 0xc2, 0xad, 0xe8, 0x5f, 0xee, 0xac, 0xfc, 0x2d, 0x80, 0xa4,
+	0xef, 0xea, 0x92, 0xb4, 0x6d, 0x50, 0x87, 0xd8, 0x43, 0xd9, 0x72, 0xec, 0x21, 0xac, 0x83, 0x38,
+	0xe9, 0x63, 0x5b, 0x73, 0x66, 0x97, 0xc0, 0xd6, 0xf1, 0x12, 0x5f, 0x03, 0xaf, 0xf9, 0x28, 0xf7,
+	0x30, 0xa3, 0x59, 0x90, 0x60, 0x77, 0xc2, 0x6f, 0xec, 0xce, 0x6d, 0x10, 0x1b, 0xf4, 0x75, 0xc6,
+	0x6b, 0xe4, 0xdf, 0xf0, 0xea, 0x81, 0xe0, 0x06, 0x88, 0xf4, 0x68, 0x87, 0xf5, 0x2a, 0x51, 0x3c,
+	0xf7, 0x7c, 0x92, 0x81, 0x48, 0x7b, 0xe4, 0xef, 0xb2, 0x82, 0x29, 0xd5, 0x3a, 0x18, 0xb9, 0x2e,
+	0x12, 0x02, 0xf0, 0x78, 0x20, 0x78, 0x01, 0x24, 0x5a, 0x26, 0x69, 0x3f, 0x54, 0xbb, 0xd8, 0xe8,
+	0x74, 0x1d, 0x7e, 0x8e, 0xd0, 0x32, 0xd3, 0x6d, 0x33, 0x15, 0x5c, 0x03, 0x4b, 0xce, 0xa1, 0x6a,
+	0x58, 0x3a, 0x3e, 0xe4, 0x85, 0xa0, 0x98, 0x73, 0xa8, 0xb8, 0xa2, 0x64, 0x80, 0x85, 0x0a, 0xd1,
+	0xb1, 0x09, 0xef, 0x81, 0xc8, 0x43, 0x3c, 0xe4, 0x13, 0x56, 0xfc, 0xe0, 0xf9, 0x24, 0xf3, 0x5e,
+	0xc7, 0x70, 0xba, 0x83, 0x56, 0xae, 0x4d, 0x7a, 0x79, 0x07, 0x5b, 0xba, 0x3b, 0xa5, 0x96, 0x13,
+	0x5c, 0x9a, 0x46, 0x8b, 0xe6, 0x5b, 0x43, 0x07, 0xd3, 0xdc, 0x36, 0x3e, 0x2c, 0xba, 0x0b, 0xe4,
+	0x06, 0x71, 0x0f, 0x20, 0xbf, 0xec, 0xc3, 0x6c, 0x5e, 0xb9, 0x20, 0x7d, 0x2c, 0x80, 0x44, 0xc3,
+	0xd2, 0xfa, 0xb4, 0x4b, 0x18, 0xfb, 0xc1, 0x71, 0x12, 0xe6, 0x1d, 0x27, 0x78, 0x13, 0x9c, 0x72,
+	0x6d, 0xaa, 0x9b, 0x57, 0x75, 0x5b, 0xc0, 0x93, 0x14, 0xc5, 0xe9, 0x24, 0x93, 0xd8, 0x2d, 0x34,
+	0x2a, 0xee, 0x3e, 0xdc, 0xf0, 0x28, 0xe1, 0xfa, 0xf9, 0x12, 0x1f, 0x43, 0xe9, 0x17, 0x61, 0x36,
+	0x80, 0x25, 0xcd, 0x34, 0x61, 0x0a, 0x2c, 0xb5, 0x3d, 0x99, 0x6d, 0x22, 0x8e, 0x5e, 0xc8, 0xf0,
+	0x1c, 0x58, 0xec, 0x61, 0xa7, 0x4b, 0x74, 0x6f, 0xec, 0x3c, 0xc9, 0xef, 0x58, 0xe4, 0xc4, 0x8e,
+	0x41, 0x0d, 0x2c, 0xec, 0x0f, 0x2c, 0x9d, 0x26, 0xa3, 0xd9, 0xc8, 0xc6, 0xb2, 0x57, 0x1f, 0xa1,
+	0x39, 0xf7, 0xa5, 0xcd, 0x79, 0x2f, 0x6d, 0xae, 0x44, 0x0c, 0xab, 0x78, 0xdd, 0xbd, 0x73, 0xbe,
+	0xff, 0x3d, 0xb3, 0x11, 0x60, 0xdd, 0x7b, 0x96, 0xf9, 0xe7, 0x1a, 0xd5, 0x1f, 0x7a, 0x4f, 0xbc,
+	0x0b, 0xa0, 0x88, 0x47, 0xbe, 0xfc, 0x5d, 0x18, 0x80, 0xd9, 0x55, 0x0c, 0x6f, 0x82, 0xf3, 0x85,
+	0x52, 0x49, 0x6e, 0x34, 0xd4, 0xe6, 0x5e, 0x5d, 0x56, 0x77, 0xaa, 0x8d, 0xba, 0x5c, 0x52, 0xee,
+	0x28, 0x72, 0x59, 0x0c, 0xa5, 0xd6, 0x46, 0xe3, 0xec, 0xea, 0xcc, 0x79, 0xc7, 0xa2, 0x7d, 0xdc,
+	0x36, 0xf6, 0x0d, 0xac, 0xc3, 0xab, 0x00, 0x06, 0x71, 0xd5, 0x5a, 0xb1, 0x56, 0xde, 0x13, 0x85,
+	0xd4, 0xca, 0x68, 0x9c, 0x15, 0x67, 0x90, 0x2a, 0x69, 0x11, 0x7d, 0x08, 0xdf, 0x07, 0xc9, 0xa0,
+	0x77, 0xad, 0x7a, 0x7f, 0x4f, 0x2d, 0x94, 0xcb, 0x48, 0x6e, 0x34, 0xc4, 0xf0, 0xab, 0x69, 0x6a,
+	0x96, 0x39, 0x2c, 0xbc, 0x78, 0x26, 0x57, 0x83, 0x40, 0xf9, 0x43, 0x19, 0xed, 0xb1, 0x4c, 0x91,
+	0xd4, 0xf9, 0xd1, 0x38, 0x7b, 0x76, 0x86, 0x92, 0x0f, 0xb0, 0x3d, 0x64, 0xc9, 0x6e, 0x83, 0xf5,
+	0x20, 0xa6, 0x50, 0xdd, 0x53, 0x6b, 0x77, 0xfc, 0x74, 0x72, 0x43, 0x8c, 0xa6, 0xd6, 0x47, 0xe3,
+	0x6c, 0x72, 0x06, 0x2d, 0x58, 0xc3, 0xda, 0x7e, 0xc1, 0x7f, 0x66, 0x53, 0x4b, 0x9f, 0x7c, 0x9d,
+	0x0e, 0x3d, 0xfe, 0x26, 0x1d, 0xba, 0xfc, 0x85, 0x00, 0x62, 0xde, 0x81, 0x82, 0x79, 0x70, 0x16,
+	0xed, 0x54, 0x9b, 0x4a, 0xe5, 0x55, 0x92, 0xce, 0x8d, 0xc6, 0x59, 0xe8, 0x79, 0x05, 0x19, 0xba,
+	0x04, 0x44, 0x1f, 0x50, 0xaa, 0x35, 0x2a, 0xee, 0x51, 0x13, 0x85, 0xd4, 0xd9, 0xd1, 0x38, 0x7b,
+	0xda, 0xf3, 0x2e, 0x11, 0xda, 0xdb, 0xd5, 0x68, 0x2f, 0xe8, 0x5a, 0xaf, 0xdd, 0xdf, 0xdb, 0x45,
+	0x85, 0xba, 0x18, 0x7e, 0xc9, 0xb5, 0x4e, 0xcc, 0xe1, 0x23, 0x5b, 0xeb, 0xa7, 0xa2, 0xee, 0xe6,
+	0x2e, 0x7f, 0x1b, 0x01, 0xd9, 0x93, 0x2e, 0x26, 0x88, 0xc1, 0xf5, 0x52, 0xad, 0xda, 0x44, 0x85,
+	0x52, 0x53, 0x2d, 0xd5, 0xca, 0xb2, 0xba, 0xad, 0x34, 0x9a, 0x35, 0xb4, 0xa7, 0xd6, 0xea, 0x32,
+	0x2a, 0x34, 0x95, 0x5a, 0xf5, 0x75, 0x3d, 0xcf, 0x8f, 0xc6, 0xd9, 0x2b, 0x27, 0xc5, 0x0e, 0xd6,
+	0xb9, 0x0b, 0x2e, 0xcd, 0x95, 0x46, 0xa9, 0x2a, 0x4d, 0x51, 0x48, 0x6d, 0x8c, 0xc6, 0xd9, 0x8b,
+	0x27, 0xc5, 0x57, 0x2c, 0xc3, 0x81, 0x0f, 0xc0, 0xd5, 0xb9, 0x02, 0x57, 0x94, 0xbb, 0xa8, 0xd0,
+	0x94, 0xc5, 0x70, 0xea, 0xca, 0x68, 0x9c, 0x7d, 0xf7, 0xa4, 0xd8, 0x15, 0xa3, 0x63, 0x6b, 0x0e,
+	0x9e, 0x3b, 0xfc, 0x5d, 0xb9, 0x2a, 0x37, 0x94, 0x86, 0x18, 0x99, 0x2f, 0xfc, 0x5d, 0x6c, 0x61,
+	0x6a, 0x50, 0xde, 0xa8, 0xe2, 0xf6, 0x93, 0x3f, 0xd3, 0xa1, 0xc7, 0xd3, 0xb4, 0xf0, 0x64, 0x9a,
+	0x16, 0x9e, 0x4e, 0xd3, 0xc2, 0x1f, 0xd3, 0xb4, 0xf0, 0xf9, 0xb3, 0x74, 0xe8, 0xe9, 0xb3, 0x74,
+	0xe8, 0xb7, 0x67, 0xe9, 0xd0, 0x47, 0xef, 0x04, 0x06, 0xd8, 0x3f, 0x10, 0xec, 0x2f, 0xba, 0x9e,
+	0x3f, 0x64, 0x5f, 0x3e, 0xc4, 0xad, 0x45, 0xf6, 0x08, 0xde, 0xf8, 0x67, 0x00, 0x1f, 0x92, 0x81,
+	0x04, 0xc8, 0x0b, 0x00, 0x00,
 }
 
 func (this *AccessTypeParam) Equal(that interface{}) bool {
@@ -646,6 +788,9 @@ func (this *CodeInfo) Equal(that interface{}) bool {
 	if !this.InstantiateConfig.Equal(&that1.InstantiateConfig) {
 		return false
 	}
+	if this.Runtime != that1.Runtime {
+		return false
+	}
 	return true
 }
 func (this *ContractInfo) Equal(that interface{}) bool {
@@ -777,6 +922,71 @@ func (this *Model) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *SnapshotCode) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*SnapshotCode)
+	if !ok {
+		that2, ok := that.(SnapshotCode)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Runtime != that1.Runtime {
+		return false
+	}
+	if !bytes.Equal(this.WASMByteCode, that1.WASMByteCode) {
+		return false
+	}
+	return true
+}
+func (this *ContractCall) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ContractCall)
+	if !ok {
+		that2, ok := that.(ContractCall)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Contract != that1.Contract {
+		return false
+	}
+	if this.Method != that1.Method {
+		return false
+	}
+	if !bytes.Equal(this.Msg, that1.Msg) {
+		return false
+	}
+	if len(this.Funds) != len(that1.Funds) {
+		return false
+	}
+	for i := range this.Funds {
+		if !this.Funds[i].Equal(&that1.Funds[i]) {
+			return false
+		}
+	}
+	return true
+}
 func (m *AccessTypeParam) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -907,6 +1117,11 @@ func (m *CodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Runtime != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Runtime))
+		i--
+		dAtA[i] = 0x30
+	}
 	{
 		size, err := m.InstantiateConfig.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -1136,6 +1351,99 @@ func (m *Model) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *SnapshotCode) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotCode) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotCode) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.WASMByteCode) > 0 {
+		i -= len(m.WASMByteCode)
+		copy(dAtA[i:], m.WASMByteCode)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.WASMByteCode)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Runtime != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Runtime))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ContractCall) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ContractCall) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ContractCall) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Funds) > 0 {
+		for iNdEx := len(m.Funds) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Funds[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Msg) > 0 {
+		i -= len(m.Msg)
+		copy(dAtA[i:], m.Msg)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Msg)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Method) > 0 {
+		i -= len(m.Method)
+		copy(dAtA[i:], m.Method)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Method)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Contract) > 0 {
+		i -= len(m.Contract)
+		copy(dAtA[i:], m.Contract)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Contract)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -1211,6 +1519,9 @@ func (m *CodeInfo) Size() (n int) {
 	}
 	l = m.InstantiateConfig.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	if m.Runtime != 0 {
+		n += 1 + sovTypes(uint64(m.Runtime))
+	}
 	return n
 }
 
@@ -1305,6 +1616,49 @@ func (m *Model) Size() (n int) {
 	return n
 }
 
+func (m *SnapshotCode) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Runtime != 0 {
+		n += 1 + sovTypes(uint64(m.Runtime))
+	}
+	l = len(m.WASMByteCode)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ContractCall) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Contract)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Method)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Msg)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Funds) > 0 {
+		for _, e := range m.Funds {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1743,6 +2097,25 @@ func (m *CodeInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Runtime", wireType)
+			}
+			m.Runtime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Runtime |= Runtime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -2397,6 +2770,291 @@ func (m *Model) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SnapshotCode) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SnapshotCode: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SnapshotCode: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Runtime", wireType)
+			}
+			m.Runtime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Runtime |= Runtime(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WASMByteCode", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.WASMByteCode = append(m.WASMByteCode[:0], dAtA[iNdEx:postIndex]...)
+			if m.WASMByteCode == nil {
+				m.WASMByteCode = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ContractCall) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ContractCall: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ContractCall: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Contract", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Contract = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Method", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Method = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = append(m.Msg[:0], dAtA[iNdEx:postIndex]...)
+			if m.Msg == nil {
+				m.Msg = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Funds", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Funds = append(m.Funds, types1.Coin{})
+			if err := m.Funds[len(m.Funds)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"net/url"
+	"strings"
 
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/docker/distribution/reference"
@@ -11,6 +12,9 @@ import (
 // MaxSaltSize is the longest salt that can be used when instantiating a contract
 const MaxSaltSize = 64
 
+// eventTypeMinLength is the length a custom event type must exceed after trimming
+const eventTypeMinLength = 2
+
 var (
 	// MaxLabelSize is the longest label that can be used when instantiating a contract
 	MaxLabelSize = 128 // extension point for chains to customize via compile flag.
@@ -77,3 +81,31 @@ func ValidateVerificationInfo(source, builder string, codeHash []byte) error {
 	}
 	return nil
 }
+
+// ValidateEventType ensures the type of a custom contract event, which is emitted as "wasm-<type>", is
+// long enough after trimming
+func ValidateEventType(typ string) error {
+	typ = strings.TrimSpace(typ)
+	if len(typ) <= eventTypeMinLength {
+		return sdkerrors.Wrap(ErrInvalidEvent, fmt.Sprintf("Event type too short: '%s'", typ))
+	}
+	return nil
+}
+
+// ValidateEventAttribute ensures the key and value of a contract event attribute are non-empty after
+// trimming and the key does not use the prefix reserved for the module
+func ValidateEventAttribute(key, value string) error {
+	key = strings.TrimSpace(key)
+	if len(key) == 0 {
+		return sdkerrors.Wrap(ErrInvalidEvent, fmt.Sprintf("Empty attribute key. Value: %s", value))
+	}
+	// TODO: check if this is legal in the SDK - if it is, we can remove this check
+	if len(strings.TrimSpace(value)) == 0 {
+		return sdkerrors.Wrap(ErrInvalidEvent, fmt.Sprintf("Empty attribute value. Key: %s", key))
+	}
+	// and reserve all _* keys for our use (not contract)
+	if strings.HasPrefix(key, AttributeReservedPrefix) {
+		return sdkerrors.Wrap(ErrInvalidEvent, fmt.Sprintf("Attribute key starts with reserved prefix %s: '%s'", AttributeReservedPrefix, key))
+	}
+	return nil
+}